@@ -1,52 +1,61 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"time"
 
 	"github.com/dykethecreator/GoApp/internal/auth/handler"
+	"github.com/dykethecreator/GoApp/internal/auth/mail"
 	authMiddleware "github.com/dykethecreator/GoApp/internal/auth/middleware"
+	"github.com/dykethecreator/GoApp/internal/auth/otp"
+	"github.com/dykethecreator/GoApp/internal/auth/revocation"
 	authService "github.com/dykethecreator/GoApp/internal/auth/service"
 	authStore "github.com/dykethecreator/GoApp/internal/auth/store"
 	chatHandler "github.com/dykethecreator/GoApp/internal/chat/handler"
 	chatService "github.com/dykethecreator/GoApp/internal/chat/service"
 	chatStore "github.com/dykethecreator/GoApp/internal/chat/store"
+	"github.com/dykethecreator/GoApp/internal/push"
 	"github.com/dykethecreator/GoApp/internal/realtime"
 	realtimeHandler "github.com/dykethecreator/GoApp/internal/realtime/handler"
+	userHandler "github.com/dykethecreator/GoApp/internal/user/handler"
+	userService "github.com/dykethecreator/GoApp/internal/user/service"
+	userStore "github.com/dykethecreator/GoApp/internal/user/store"
+	"github.com/dykethecreator/GoApp/pkg/config"
 	"github.com/dykethecreator/GoApp/pkg/database"
 	appjwt "github.com/dykethecreator/GoApp/pkg/jwt"
-	"github.com/joho/godotenv"
+	"github.com/dykethecreator/GoApp/pkg/phone"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	log.Println("🚀 Starting All-In-One Service (Auth + Chat + Realtime)")
+	log.Println("🚀 Starting All-In-One Service (Auth + Chat + User + Realtime)")
 
-	// Load env
-	_ = godotenv.Load(".env.local")
+	// Load configuration (flags, environment, optional --config file)
+	cfg, err := config.Load(config.AllInOne, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Database
-	db, err := database.NewDB(os.Getenv("DATABASE_URL"))
+	db, err := database.NewDB(cfg.Database.URL)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer db.Close()
 
 	// JWT Token Manager
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("JWT_SECRET not set")
-	}
-	tokenManager, err := appjwt.NewTokenManager(jwtSecret, 15*time.Minute, 7*24*time.Hour)
+	// Auth issues tokens with it; chat and realtime share it in-process for validation.
+	tokenManager, err := appjwt.NewTokenManagerFromConfig(cfg.JWT)
 	if err != nil {
 		log.Fatalf("Failed to create token manager: %v", err)
 	}
 
 	// Realtime Hub (shared in-process)
+	realtime.ConfigureGlobalHub(cfg.Hub)
 	hub := realtime.GetGlobalHub()
 	go hub.Run()
 	log.Println("✅ Realtime Hub started")
@@ -54,32 +63,89 @@ func main() {
 	// Auth Components
 	userRepo := authStore.NewUserStore(db.DB)
 	deviceRepo := authStore.NewUserDeviceStore(db.DB)
-	authSvc := authService.NewAuthService(userRepo, deviceRepo)
-	authHandler := handler.NewAuthHandler(authSvc)
+	otpProvider, err := otp.NewProvider(cfg.OTP)
+	if err != nil {
+		log.Fatalf("Failed to init OTP provider: %v", err)
+	}
+	attemptRepo := authStore.NewAttemptStore(db.DB)
+	eventRepo := authStore.NewSecurityEventStore(db.DB)
+	revocations := revocation.NewList(authStore.NewRevocationStore(db.DB), cfg.Cache.RevocationTTL, cfg.JWT.AccessTokenTTL)
+	mailer, err := mail.NewSender(cfg.Mail)
+	if err != nil {
+		log.Fatalf("Failed to init mail sender: %v", err)
+	}
+	// Phone numbers are stored in E.164; national numbers use phone.default_region
+	phones, err := phone.NewParser(cfg.Phone.DefaultRegion)
+	if err != nil {
+		log.Fatalf("Failed to init phone parser: %v", err)
+	}
+	authSvc := authService.NewAuthService(userRepo, deviceRepo, otpProvider, attemptRepo, eventRepo, tokenManager, revocations, authStore.NewTwoStepStore(db.DB), mailer, authStore.NewDeviceLinkStore(db.DB))
+	authHandler := handler.NewAuthHandler(authSvc, phones)
+
+	// Blocks are enforced by chat (1:1 messages) and the hub (presence, typing);
+	// privacy settings by profile reads and presence
+	blocks := userService.NewBlockList(userStore.NewBlockStore(db.DB), cfg.Cache.BlockTTL)
+	privacy := userService.NewPrivacy(userStore.NewPrivacyStore(db.DB), blocks, cfg.Cache.PrivacyTTL)
+	hub.SetBlockFilter(blocks)
+	hub.SetPresencePolicy(privacy)
 
 	// Chat Components
 	chatRepo := chatStore.NewChatStore(db.DB)
-	chatSvc := chatService.NewChatService(chatRepo)
+
+	// Offline participants get push notifications; muted conversations are skipped
+	dispatcher, err := push.NewDispatcherFromConfig(cfg.Push, authStore.NewPushTokenStore(db.DB), chatRepo)
+	if err != nil {
+		log.Fatalf("Failed to init push notifications: %v", err)
+	}
+	if dispatcher != nil {
+		hub.SetOfflineNotifier(dispatcher)
+	}
+	chatSvc := chatService.NewChatServiceWithConfig(chatRepo, blocks, cfg.Chat)
 	chatHdlr := chatHandler.NewChatHandler(chatSvc)
+	authSvc.SetNumberChangeNotifier(chatHandler.NewSystemNotifier(chatSvc))
+
+	// User Components
+	userRepoPG := userStore.NewUserStore(db.DB)
+	accounts := userService.NewAccounts(userStore.NewAccountStore(db.DB), revocations, cfg.Export.BlobDir)
+	contacts := userStore.NewContactStore(db.DB)
+	if cfg.Contacts.HashSalt != "" {
+		// Hashed contact uploads are matched against users.phone_hash, computed with this salt
+		if err := contacts.SetHashSalt(context.Background(), cfg.Contacts.HashSalt); err != nil {
+			log.Fatalf("Failed to set contact hash salt: %v", err)
+		}
+	}
+	userSvc := userService.NewUserService(userRepoPG, blocks)
+	userSvc.SetContacts(contacts, cfg.Contacts.HashSalt)
+	userSvc.SetPrivacy(privacy)
+	userSvc.SetAccounts(accounts)
+	userSvc.SetPhoneParser(phones)
+	userHdlr := userHandler.NewUserHandler(userSvc)
 
 	// Realtime Handler
 	realtimeHdlr := realtimeHandler.NewRealtimeHandler(hub)
+	realtimeHdlr.SetRevocationChecker(revocations, cfg.Cache.RevocationTTL)
 
 	// gRPC Server
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(authMiddleware.UnaryAuthInterceptor(tokenManager)),
-		grpc.StreamInterceptor(authMiddleware.StreamAuthInterceptor(tokenManager)),
+		grpc.UnaryInterceptor(authMiddleware.UnaryAuthInterceptor(tokenManager, revocations, authMiddleware.DefaultPolicy)),
+		grpc.StreamInterceptor(authMiddleware.StreamAuthInterceptor(tokenManager, revocations, authMiddleware.DefaultPolicy)),
 	)
 
 	// Register all services
 	authHandler.Register(grpcServer)
 	chatHdlr.Register(grpcServer)
+	userHdlr.Register(grpcServer)
 	realtimeHdlr.Register(grpcServer)
 
 	reflection.Register(grpcServer)
 
+	// Every registered method must be declared public, authenticated or admin
+	if err := authMiddleware.DefaultPolicy.Validate(grpcServer); err != nil {
+		log.Fatalf("auth policy: %v", err)
+	}
+
 	// Listen on single port
-	port := "50050"
+	port := fmt.Sprint(cfg.GRPC.AllInOnePort)
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
@@ -93,6 +159,7 @@ func main() {
 ║ Services:                             ║
 ║   ✅ AuthService                       ║
 ║   ✅ ChatService                       ║
+║   ✅ UserService                       ║
 ║   ✅ RealtimeService                   ║
 ║ Realtime Hub: SHARED ✓                ║
 ╚═══════════════════════════════════════╝
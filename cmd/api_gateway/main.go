@@ -1,7 +1,16 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/dykethecreator/GoApp/pkg/config"
+)
 
 func main() {
+	if _, err := config.Load(config.APIGateway, os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
 	fmt.Println("Starting API Gateway...")
 }
@@ -1,86 +1,122 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"time"
 
 	"github.com/dykethecreator/GoApp/internal/auth/handler"
+	"github.com/dykethecreator/GoApp/internal/auth/mail"
 	"github.com/dykethecreator/GoApp/internal/auth/middleware"
+	"github.com/dykethecreator/GoApp/internal/auth/otp"
+	"github.com/dykethecreator/GoApp/internal/auth/revocation"
 	"github.com/dykethecreator/GoApp/internal/auth/service"
 	"github.com/dykethecreator/GoApp/internal/auth/store"
+	chatHandler "github.com/dykethecreator/GoApp/internal/chat/handler"
+	chatSvc "github.com/dykethecreator/GoApp/internal/chat/service"
+	chatStore "github.com/dykethecreator/GoApp/internal/chat/store"
+	userHandler "github.com/dykethecreator/GoApp/internal/user/handler"
+	userSvc "github.com/dykethecreator/GoApp/internal/user/service"
+	profileStore "github.com/dykethecreator/GoApp/internal/user/store"
+	"github.com/dykethecreator/GoApp/pkg/config"
 	"github.com/dykethecreator/GoApp/pkg/database"
 	appjwt "github.com/dykethecreator/GoApp/pkg/jwt"
-	"github.com/joho/godotenv"
+	"github.com/dykethecreator/GoApp/pkg/phone"
 	"google.golang.org/grpc"
 )
 
 func main() {
-	// Environment loading strategy:
-	// 1) If APP_ENV is set, try to load .env.{APP_ENV}
-	// 2) If RUNNING_IN_DOCKER is set, default to .env.docker
-	// 3) Otherwise, default to .env.local
-	// 4) Finally, load base .env if present (for overrides)
-	appEnv := os.Getenv("APP_ENV")
-	if appEnv == "" {
-		if os.Getenv("RUNNING_IN_DOCKER") != "" {
-			appEnv = "docker"
-		} else {
-			appEnv = "local"
-		}
+	// Settings come from flags, the environment (.env.<APP_ENV> and .env
+	// included) and an optional --config file; see pkg/config.
+	cfg, err := config.Load(config.AuthService, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	// Try environment-specific file first
-	_ = godotenv.Load(".env." + appEnv)
-	// Then load base .env optionally to allow local overrides
-	_ = godotenv.Load()
-
 	// Establish database connection
-	db, err := database.NewDB(os.Getenv("DATABASE_URL"))
+	db, err := database.NewDB(cfg.Database.URL)
 	if err != nil {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
 	defer db.Close()
 
-	// Determine gRPC port (default 50051)
-	grpcPort := os.Getenv("AUTH_SERVICE_GRPC_PORT")
-	if grpcPort == "" {
-		grpcPort = "50051"
-	}
-	listenAddr := fmt.Sprintf(":%s", grpcPort)
-
+	listenAddr := fmt.Sprintf(":%d", cfg.GRPC.AuthPort)
 	lis, err := net.Listen("tcp", listenAddr)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	// Build a TokenManager for interceptor (same secret/durations as service)
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("JWT_SECRET not set")
-	}
-	tm, err := appjwt.NewTokenManager(jwtSecret, 15*time.Minute, 7*24*time.Hour)
+	// Build the TokenManager shared by the interceptor and the service
+	tm, err := appjwt.NewTokenManagerFromConfig(cfg.JWT)
 	if err != nil {
 		log.Fatalf("failed to init token manager: %v", err)
 	}
 
-	// Create gRPC server with auth interceptor; AuthService methods are exempt inside the interceptor.
+	// Access-token revocation list, shared with other services through the database
+	revocations := revocation.NewList(store.NewRevocationStore(db.DB), cfg.Cache.RevocationTTL, cfg.JWT.AccessTokenTTL)
+
+	// Create gRPC server with auth interceptor enforcing the per-method auth policy.
 	s := grpc.NewServer(
-		grpc.UnaryInterceptor(middleware.UnaryAuthInterceptor(tm)),
+		grpc.UnaryInterceptor(middleware.UnaryAuthInterceptor(tm, revocations, middleware.DefaultPolicy)),
 	)
 
 	// Create dependencies (DI - Dependency Injection)
 	userStore := store.NewUserStore(db.DB)
 	deviceStore := store.NewUserDeviceStore(db.DB)
-	authService := service.NewAuthService(userStore, deviceStore)
-	authHandler := handler.NewAuthHandler(authService)
+	otpProvider, err := otp.NewProvider(cfg.OTP)
+	if err != nil {
+		log.Fatalf("failed to init OTP provider: %v", err)
+	}
+	attemptStore := store.NewAttemptStore(db.DB)
+	eventStore := store.NewSecurityEventStore(db.DB)
+	mailer, err := mail.NewSender(cfg.Mail)
+	if err != nil {
+		log.Fatalf("failed to init mail sender: %v", err)
+	}
+	// Phone numbers are stored in E.164; national numbers use phone.default_region
+	phones, err := phone.NewParser(cfg.Phone.DefaultRegion)
+	if err != nil {
+		log.Fatalf("failed to init phone parser: %v", err)
+	}
+	authService := service.NewAuthService(userStore, deviceStore, otpProvider, attemptStore, eventStore, tm, revocations, store.NewTwoStepStore(db.DB), mailer, store.NewDeviceLinkStore(db.DB))
+	authHandler := handler.NewAuthHandler(authService, phones)
 
-	// Register handler with gRPC server
+	// User profiles live next to auth, which owns the users table
+	blocks := userSvc.NewBlockList(profileStore.NewBlockStore(db.DB), cfg.Cache.BlockTTL)
+	privacy := userSvc.NewPrivacy(profileStore.NewPrivacyStore(db.DB), blocks, cfg.Cache.PrivacyTTL)
+	accounts := userSvc.NewAccounts(profileStore.NewAccountStore(db.DB), revocations, cfg.Export.BlobDir)
+	contacts := profileStore.NewContactStore(db.DB)
+	if cfg.Contacts.HashSalt != "" {
+		// Hashed contact uploads are matched against users.phone_hash, computed with this salt
+		if err := contacts.SetHashSalt(context.Background(), cfg.Contacts.HashSalt); err != nil {
+			log.Fatalf("failed to set contact hash salt: %v", err)
+		}
+	}
+	userService := userSvc.NewUserService(profileStore.NewUserStore(db.DB), blocks)
+	userService.SetContacts(contacts, cfg.Contacts.HashSalt)
+	userService.SetPrivacy(privacy)
+	userService.SetAccounts(accounts)
+	userService.SetPhoneParser(phones)
+	userHdlr := userHandler.NewUserHandler(userService)
+
+	// Number changes are announced in the user's conversations as system
+	// messages. They are stored for ListMessages; this process's hub has no
+	// streams, so only all_in_one also delivers them live.
+	chats := chatSvc.NewChatServiceWithConfig(chatStore.NewChatStore(db.DB), blocks, cfg.Chat)
+	authService.SetNumberChangeNotifier(chatHandler.NewSystemNotifier(chats))
+
+	// Register handlers with gRPC server
 	authHandler.Register(s)
+	userHdlr.Register(s)
+
+	// Every registered method must be declared public, authenticated or admin
+	if err := middleware.DefaultPolicy.Validate(s); err != nil {
+		log.Fatalf("auth policy: %v", err)
+	}
 
-	log.Printf("auth_service listening on %s (env=%s)", listenAddr, os.Getenv("APP_ENV"))
+	log.Printf("auth_service listening on %s (env=%s)", listenAddr, cfg.Env)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
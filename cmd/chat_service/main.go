@@ -1,85 +1,88 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"time"
 
+	"github.com/dykethecreator/GoApp/internal/auth/keysync"
 	"github.com/dykethecreator/GoApp/internal/auth/middleware"
+	"github.com/dykethecreator/GoApp/internal/auth/revocation"
+	authStore "github.com/dykethecreator/GoApp/internal/auth/store"
 	"github.com/dykethecreator/GoApp/internal/chat/handler"
 	"github.com/dykethecreator/GoApp/internal/chat/service"
 	"github.com/dykethecreator/GoApp/internal/chat/store"
 	"github.com/dykethecreator/GoApp/internal/realtime"
+	userSvc "github.com/dykethecreator/GoApp/internal/user/service"
+	userStore "github.com/dykethecreator/GoApp/internal/user/store"
+	"github.com/dykethecreator/GoApp/pkg/config"
 	"github.com/dykethecreator/GoApp/pkg/database"
-	appjwt "github.com/dykethecreator/GoApp/pkg/jwt"
-	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
 )
 
 func main() {
-	// Environment loading strategy (same as auth_service)
-	appEnv := os.Getenv("APP_ENV")
-	if appEnv == "" {
-		if os.Getenv("RUNNING_IN_DOCKER") != "" {
-			appEnv = "docker"
-		} else {
-			appEnv = "local"
-		}
+	// Settings come from flags, the environment and an optional --config file
+	// (same as auth_service)
+	cfg, err := config.Load(config.ChatService, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	_ = godotenv.Load(".env." + appEnv)
-	_ = godotenv.Load()
-
 	// Start global hub for realtime messaging (in-process)
+	realtime.ConfigureGlobalHub(cfg.Hub)
 	hub := realtime.GetGlobalHub()
 	go hub.Run()
 	log.Println("Realtime hub started in chat_service")
 
 	// Database connection
-	db, err := database.NewDB(os.Getenv("DATABASE_URL"))
+	db, err := database.NewDB(cfg.Database.URL)
 	if err != nil {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
 	defer db.Close()
 
-	// gRPC port (default 50052)
-	grpcPort := os.Getenv("CHAT_SERVICE_GRPC_PORT")
-	if grpcPort == "" {
-		grpcPort = "50052"
-	}
-	listenAddr := fmt.Sprintf(":%s", grpcPort)
+	listenAddr := fmt.Sprintf(":%d", cfg.GRPC.ChatPort)
 
 	lis, err := net.Listen("tcp", listenAddr)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	// Build TokenManager for interceptor (same secret as auth_service)
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("JWT_SECRET not set")
-	}
-	tm, err := appjwt.NewTokenManager(jwtSecret, 15*time.Minute, 7*24*time.Hour)
+	// Verify-only TokenManager for the interceptor: holds auth_service's public keys
+	tm, err := keysync.NewVerifierFromConfig(context.Background(), cfg.JWT)
 	if err != nil {
 		log.Fatalf("failed to init token manager: %v", err)
 	}
 
+	// Revocation list shared with auth_service through the database
+	revocations := revocation.NewList(authStore.NewRevocationStore(db.DB), cfg.Cache.RevocationTTL, cfg.JWT.AccessTokenTTL)
+
 	// Create gRPC server with auth interceptor
 	s := grpc.NewServer(
-		grpc.UnaryInterceptor(middleware.UnaryAuthInterceptor(tm)),
+		grpc.UnaryInterceptor(middleware.UnaryAuthInterceptor(tm, revocations, middleware.DefaultPolicy)),
 	)
 
+	// Blocks (written by auth_service) are enforced on 1:1 messages
+	blocks := userSvc.NewBlockList(userStore.NewBlockStore(db.DB), cfg.Cache.BlockTTL)
+
 	// DI: ChatStore → ChatService → ChatHandler
+	// No push notifications here: this hub serves no streams, so it cannot tell
+	// online participants from offline ones (see all_in_one).
 	chatStore := store.NewChatStore(db.DB)
-	chatService := service.NewChatService(chatStore)
+	chatService := service.NewChatServiceWithConfig(chatStore, blocks, cfg.Chat)
 	chatHandler := handler.NewChatHandler(chatService)
 
 	// Register handler
 	chatHandler.Register(s)
 
-	log.Printf("chat_service listening on %s (env=%s)", listenAddr, os.Getenv("APP_ENV"))
+	// Every registered method must be declared public, authenticated or admin
+	if err := middleware.DefaultPolicy.Validate(s); err != nil {
+		log.Fatalf("auth policy: %v", err)
+	}
+
+	log.Printf("chat_service listening on %s (env=%s)", listenAddr, cfg.Env)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
@@ -1,79 +1,99 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net"
 	"os"
-	"time"
 
+	"github.com/dykethecreator/GoApp/internal/auth/keysync"
 	"github.com/dykethecreator/GoApp/internal/auth/middleware"
+	"github.com/dykethecreator/GoApp/internal/auth/revocation"
+	authStore "github.com/dykethecreator/GoApp/internal/auth/store"
 	"github.com/dykethecreator/GoApp/internal/realtime"
 	realtimeHandler "github.com/dykethecreator/GoApp/internal/realtime/handler"
-	"github.com/dykethecreator/GoApp/pkg/jwt"
-	"github.com/joho/godotenv"
+	userSvc "github.com/dykethecreator/GoApp/internal/user/service"
+	userStore "github.com/dykethecreator/GoApp/internal/user/store"
+	"github.com/dykethecreator/GoApp/pkg/config"
+	"github.com/dykethecreator/GoApp/pkg/database"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	// Load environment
-	env := os.Getenv("APP_ENV")
-	if env == "" {
-		env = "local"
-	}
-
-	envFile := ".env." + env
-	if env == "local" {
-		envFile = ".env.local"
-	}
-
-	if err := godotenv.Load(envFile); err != nil {
-		log.Printf("Warning: .env file not found (%s), using system environment", envFile)
-	}
-
-	// Get port
-	port := os.Getenv("REALTIME_SERVICE_GRPC_PORT")
-	if port == "" {
-		port = "50053"
-	}
-
-	// Setup JWT for auth interceptor
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("JWT_SECRET not set")
+	// Load configuration (flags, environment, optional --config file)
+	cfg, err := config.Load(config.RealtimeService, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	tokenManager, err := jwt.NewTokenManager(jwtSecret, 15*time.Minute, 7*24*time.Hour)
+	// Setup verify-only JWT for auth interceptor (public keys of auth_service)
+	tokenManager, err := keysync.NewVerifierFromConfig(context.Background(), cfg.JWT)
 	if err != nil {
 		log.Fatalf("Failed to create token manager: %v", err)
 	}
 
+	// Revocation list, user blocks and privacy settings shared with auth_service
+	// through the database. Without DATABASE_URL revoked access tokens are
+	// accepted until they expire and presence goes to everyone.
+	var revoked middleware.RevocationChecker
+	var blocks *userSvc.BlockList
+	var privacy *userSvc.Privacy
+	if dsn := cfg.Database.URL; dsn != "" {
+		db, err := database.NewDB(dsn)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer db.Close()
+		revoked = revocation.NewList(authStore.NewRevocationStore(db.DB), cfg.Cache.RevocationTTL, cfg.JWT.AccessTokenTTL)
+		blocks = userSvc.NewBlockList(userStore.NewBlockStore(db.DB), cfg.Cache.BlockTTL)
+		privacy = userSvc.NewPrivacy(userStore.NewPrivacyStore(db.DB), blocks, cfg.Cache.PrivacyTTL)
+	} else {
+		log.Printf("Warning: DATABASE_URL not set; access-token revocations, blocks and privacy settings are not checked")
+	}
+
 	// Use global hub (shared with other services in-process)
+	realtime.ConfigureGlobalHub(cfg.Hub)
 	hub := realtime.GetGlobalHub()
+	if blocks != nil {
+		hub.SetBlockFilter(blocks)
+		hub.SetPresencePolicy(privacy)
+	}
 
 	// Start hub event loop in background
 	go hub.Run()
 
 	// Setup gRPC server with auth interceptor
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(middleware.UnaryAuthInterceptor(tokenManager)),
-		grpc.StreamInterceptor(middleware.StreamAuthInterceptor(tokenManager)),
+		grpc.UnaryInterceptor(middleware.UnaryAuthInterceptor(tokenManager, revoked, middleware.DefaultPolicy)),
+		grpc.StreamInterceptor(middleware.StreamAuthInterceptor(tokenManager, revoked, middleware.DefaultPolicy)),
 	)
 
 	// Register realtime handler
 	handler := realtimeHandler.NewRealtimeHandler(hub)
+	if revoked != nil {
+		// Sessions revoked through auth_service end their streams here within this time
+		handler.SetRevocationChecker(revoked, cfg.Cache.RevocationTTL)
+	}
 	handler.Register(grpcServer)
 
 	// Enable reflection for grpcurl/Postman
 	reflection.Register(grpcServer)
 
+	// Every registered method must be declared public, authenticated or admin
+	if err := middleware.DefaultPolicy.Validate(grpcServer); err != nil {
+		log.Fatalf("auth policy: %v", err)
+	}
+
 	// Start listening
-	lis, err := net.Listen("tcp", ":"+port)
+	listenAddr := fmt.Sprintf(":%d", cfg.GRPC.RealtimePort)
+	lis, err := net.Listen("tcp", listenAddr)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	log.Printf("realtime_service listening on :%s (env=%s)", port, env)
+	log.Printf("realtime_service listening on %s (env=%s)", listenAddr, cfg.Env)
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
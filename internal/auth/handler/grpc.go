@@ -2,24 +2,40 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"log"
+	"math"
+	"net"
+	"strconv"
+	"time"
 
+	"github.com/dykethecreator/GoApp/internal/auth/middleware"
+	"github.com/dykethecreator/GoApp/internal/auth/otp"
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
 	"github.com/dykethecreator/GoApp/internal/auth/service"
+	"github.com/dykethecreator/GoApp/internal/realtime"
+	"github.com/dykethecreator/GoApp/pkg/domain"
 	appjwt "github.com/dykethecreator/GoApp/pkg/jwt"
+	"github.com/dykethecreator/GoApp/pkg/phone"
 	"github.com/dykethecreator/GoApp/proto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
 type AuthHandler struct {
 	proto.UnimplementedAuthServiceServer
 	service *service.AuthService
+	phones  *phone.Parser
 }
 
-func NewAuthHandler(service *service.AuthService) *AuthHandler {
-	return &AuthHandler{service: service}
+// NewAuthHandler creates the handler; phones normalizes incoming numbers to
+// E.164, reading national numbers as numbers of its default region.
+func NewAuthHandler(service *service.AuthService, phones *phone.Parser) *AuthHandler {
+	return &AuthHandler{service: service, phones: phones}
 }
 
 func (h *AuthHandler) Register(s *grpc.Server) {
@@ -29,10 +45,14 @@ func (h *AuthHandler) Register(s *grpc.Server) {
 func (h *AuthHandler) SendOTP(ctx context.Context, req *proto.SendOTPRequest) (*proto.SendOTPResponse, error) {
 	log.Printf("Received SendOTP request for phone number: %s", req.PhoneNumber)
 
-	status, err := h.service.SendOTP(req.PhoneNumber)
+	phoneNumber, err := h.normalizePhone(req.PhoneNumber)
 	if err != nil {
 		return nil, err
 	}
+	status, err := h.service.SendOTP(ctx, phoneNumber, peerAddr(ctx))
+	if err != nil {
+		return nil, rateLimitOr(ctx, err)
+	}
 
 	return &proto.SendOTPResponse{
 		Message: "OTP sent successfully, status: " + status,
@@ -42,9 +62,27 @@ func (h *AuthHandler) SendOTP(ctx context.Context, req *proto.SendOTPRequest) (*
 func (h *AuthHandler) VerifyOTP(ctx context.Context, req *proto.VerifyOTPRequest) (*proto.VerifyOTPResponse, error) {
 	log.Printf("Received VerifyOTP request for phone number: %s, device_id: %s", req.PhoneNumber, req.DeviceId)
 
-	user, accessToken, refreshToken, err := h.service.VerifyOTP(ctx, req.PhoneNumber, req.OtpCode, req.DeviceId)
+	phoneNumber, err := h.normalizePhone(req.PhoneNumber)
 	if err != nil {
-		// Check for specific error types if needed, otherwise return a general internal error.
+		return nil, err
+	}
+	user, accessToken, refreshToken, err := h.service.VerifyOTP(ctx, phoneNumber, req.OtpCode, req.DeviceId, peerAddr(ctx))
+	var pinErr *service.PINRequiredError
+	if errors.As(err, &pinErr) {
+		return &proto.VerifyOTPResponse{
+			PinRequired:           true,
+			PinChallengeToken:     pinErr.ChallengeToken,
+			PinChallengeExpiresAt: pinErr.ExpiresAt.Format(time.RFC3339),
+		}, nil
+	}
+	if err != nil {
+		var rlErr *service.RateLimitError
+		if errors.As(err, &rlErr) {
+			return nil, rateLimitOr(ctx, err)
+		}
+		if errors.Is(err, otp.ErrInvalidCode) {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired OTP code")
+		}
 		// The service layer already logs the details.
 		return nil, status.Errorf(codes.Internal, "failed to verify OTP: %v", err)
 	}
@@ -64,6 +102,242 @@ func (h *AuthHandler) VerifyOTP(ctx context.Context, req *proto.VerifyOTPRequest
 	}, nil
 }
 
+func (h *AuthHandler) VerifyPIN(ctx context.Context, req *proto.VerifyPINRequest) (*proto.VerifyPINResponse, error) {
+	user, accessToken, refreshToken, err := h.service.VerifyPIN(ctx, req.PinChallengeToken, req.Pin, peerAddr(ctx))
+	if err != nil {
+		return nil, pinError(ctx, err, "failed to verify PIN")
+	}
+	return toLoginResponse(user, accessToken, refreshToken), nil
+}
+
+func (h *AuthHandler) RequestPINReset(ctx context.Context, req *proto.RequestPINResetRequest) (*proto.RequestPINResetResponse, error) {
+	reset, err := h.service.RequestPINReset(ctx, req.PinChallengeToken)
+	if err != nil {
+		return nil, pinError(ctx, err, "failed to start PIN reset")
+	}
+	return &proto.RequestPINResetResponse{
+		EmailSent:        reset.EmailSent,
+		MaskedEmail:      reset.MaskedEmail,
+		ResetAvailableAt: reset.AvailableAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (h *AuthHandler) ResetPIN(ctx context.Context, req *proto.ResetPINRequest) (*proto.VerifyPINResponse, error) {
+	user, accessToken, refreshToken, err := h.service.ResetPIN(ctx, req.PinChallengeToken, req.ResetCode, peerAddr(ctx))
+	if err != nil {
+		return nil, pinError(ctx, err, "failed to reset PIN")
+	}
+	return toLoginResponse(user, accessToken, refreshToken), nil
+}
+
+func (h *AuthHandler) SetPIN(ctx context.Context, req *proto.SetPINRequest) (*proto.SetPINResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "claims not found in context")
+	}
+	st, err := h.service.SetPIN(ctx, claims.Subject, req.Pin, req.RecoveryEmail)
+	if err != nil {
+		return nil, pinError(ctx, err, "failed to set PIN")
+	}
+	return &proto.SetPINResponse{Enabled: st.Enabled, RecoveryEmail: st.RecoveryEmail}, nil
+}
+
+func (h *AuthHandler) DisablePIN(ctx context.Context, req *proto.DisablePINRequest) (*proto.DisablePINResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "claims not found in context")
+	}
+	if err := h.service.DisablePIN(ctx, claims.Subject); err != nil {
+		return nil, pinError(ctx, err, "failed to disable PIN")
+	}
+	return &proto.DisablePINResponse{}, nil
+}
+
+func (h *AuthHandler) RequestNumberChange(ctx context.Context, req *proto.RequestNumberChangeRequest) (*proto.RequestNumberChangeResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "claims not found in context")
+	}
+	newPhone, err := h.normalizePhone(req.NewPhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.RequestNumberChange(ctx, claims.Subject, newPhone, peerAddr(ctx)); err != nil {
+		return nil, numberError(ctx, err, "failed to send codes")
+	}
+	return &proto.RequestNumberChangeResponse{}, nil
+}
+
+func (h *AuthHandler) ChangeNumber(ctx context.Context, req *proto.ChangeNumberRequest) (*proto.ChangeNumberResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "claims not found in context")
+	}
+	newPhone, err := h.normalizePhone(req.NewPhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.service.ChangeNumber(ctx, claims.Subject, newPhone, req.OldOtpCode, req.NewOtpCode, req.NotifyContacts, peerAddr(ctx))
+	if err != nil {
+		return nil, numberError(ctx, err, "failed to change number")
+	}
+	return &proto.ChangeNumberResponse{User: toProtoUser(user)}, nil
+}
+
+func (h *AuthHandler) StartDeviceLink(ctx context.Context, req *proto.StartDeviceLinkRequest) (*proto.StartDeviceLinkResponse, error) {
+	link, err := h.service.StartDeviceLink(ctx, req.DeviceName, req.DeviceType, peerAddr(ctx))
+	if err != nil {
+		return nil, linkError(ctx, err, "failed to start device link")
+	}
+	return &proto.StartDeviceLinkResponse{
+		PairingToken: link.PairingToken,
+		DeviceSecret: link.DeviceSecret,
+		QrPayload:    link.QRPayload,
+		ExpiresAt:    link.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (h *AuthHandler) ApproveDeviceLink(ctx context.Context, req *proto.ApproveDeviceLinkRequest) (*proto.ApproveDeviceLinkResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "claims not found in context")
+	}
+	link, err := h.service.ApproveDeviceLink(ctx, claims.Subject, claims.SessionID, req.PairingToken)
+	if err != nil {
+		return nil, linkError(ctx, err, "failed to approve device link")
+	}
+	return &proto.ApproveDeviceLinkResponse{DeviceName: link.DeviceName, DeviceType: link.DeviceType}, nil
+}
+
+func (h *AuthHandler) CompleteDeviceLink(ctx context.Context, req *proto.CompleteDeviceLinkRequest) (*proto.CompleteDeviceLinkResponse, error) {
+	user, accessToken, refreshToken, err := h.service.CompleteDeviceLink(ctx, req.PairingToken, req.DeviceSecret)
+	if errors.Is(err, service.ErrDeviceLinkPending) {
+		return &proto.CompleteDeviceLinkResponse{Pending: true}, nil
+	}
+	if err != nil {
+		return nil, linkError(ctx, err, "failed to complete device link")
+	}
+	return &proto.CompleteDeviceLinkResponse{
+		User:         toProtoUser(user),
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// normalizePhone returns the E.164 form of a request's phone number.
+func (h *AuthHandler) normalizePhone(raw string) (string, error) {
+	normalized, err := h.phones.Normalize(raw)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return normalized, nil
+}
+
+func toLoginResponse(user *domain.User, accessToken, refreshToken string) *proto.VerifyPINResponse {
+	return &proto.VerifyPINResponse{
+		User:         toProtoUser(user),
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}
+}
+
+func toProtoUser(user *domain.User) *proto.User {
+	return &proto.User{
+		Id:                user.ID.String(),
+		PhoneNumber:       user.PhoneNumber,
+		DisplayName:       user.DisplayName,
+		ProfilePictureUrl: user.ProfilePictureURL,
+		AboutText:         user.AboutText,
+	}
+}
+
+// pinError maps two-step verification errors to gRPC status codes.
+func pinError(ctx context.Context, err error, msg string) error {
+	var rlErr *service.RateLimitError
+	var pendingErr *service.PINResetPendingError
+	switch {
+	case errors.As(err, &rlErr):
+		return rateLimitOr(ctx, err)
+	case errors.As(err, &pendingErr):
+		return status.Errorf(codes.FailedPrecondition, "PIN reset available at %s", pendingErr.AvailableAt.Format(time.RFC3339))
+	case errors.Is(err, service.ErrInvalidPIN), errors.Is(err, service.ErrInvalidPINChallenge), errors.Is(err, service.ErrInvalidResetCode):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, service.ErrInvalidPINFormat), errors.Is(err, service.ErrInvalidRecoveryEmail):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrTwoStepNotConfigured):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// numberError maps phone number change errors to gRPC status codes.
+func numberError(ctx context.Context, err error, msg string) error {
+	var rlErr *service.RateLimitError
+	switch {
+	case errors.As(err, &rlErr):
+		return rateLimitOr(ctx, err)
+	case errors.Is(err, otp.ErrInvalidCode):
+		return status.Error(codes.Unauthenticated, "invalid or expired OTP code")
+	case errors.Is(err, service.ErrInvalidPhone), errors.Is(err, service.ErrSamePhoneNumber):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrPhoneNumberTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repository.ErrPhoneNumberChanged):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, service.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// linkError maps companion device linking errors to gRPC status codes.
+func linkError(ctx context.Context, err error, msg string) error {
+	var rlErr *service.RateLimitError
+	switch {
+	case errors.As(err, &rlErr):
+		return rateLimitOr(ctx, err)
+	case errors.Is(err, service.ErrInvalidDeviceLink):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidDeviceInfo):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrDeviceLinkNotConfigured):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrLinkApproverNotMobile):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// rateLimitOr converts a *service.RateLimitError into codes.ResourceExhausted,
+// putting the wait in seconds into the "retry-after" trailer. Other errors are returned unchanged.
+func rateLimitOr(ctx context.Context, err error) error {
+	var rlErr *service.RateLimitError
+	if !errors.As(err, &rlErr) {
+		return err
+	}
+	seconds := int64(math.Ceil(rlErr.RetryAfter.Seconds()))
+	if terr := grpc.SetTrailer(ctx, metadata.Pairs("retry-after", strconv.FormatInt(seconds, 10))); terr != nil {
+		log.Printf("Warning: failed to set retry-after trailer: %v", terr)
+	}
+	return status.Errorf(codes.ResourceExhausted, "too many attempts, retry after %d seconds", seconds)
+}
+
+// peerAddr returns the caller's host address (without port), or "" if unknown.
+func peerAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
 // Helper to convert *string to string
 func stringPtrToString(s *string) string {
 	if s == nil {
@@ -74,7 +348,7 @@ func stringPtrToString(s *string) string {
 
 func (h *AuthHandler) ValidateToken(ctx context.Context, req *proto.ValidateTokenRequest) (*proto.ValidateTokenResponse, error) {
 	log.Printf("ValidateToken called. Token length: %d, first 50 chars: %s", len(req.AccessToken), truncate(req.AccessToken, 50))
-	valid, userID := h.service.ValidateAccessToken(req.AccessToken)
+	valid, userID := h.service.ValidateAccessToken(ctx, req.AccessToken)
 	log.Printf("ValidateToken result: valid=%v, user_id=%s (len=%d)", valid, userID, len(userID))
 	// Do not treat invalid token as an RPC error; return is_valid=false
 	return &proto.ValidateTokenResponse{
@@ -107,22 +381,128 @@ func (h *AuthHandler) RefreshToken(ctx context.Context, req *proto.RefreshTokenR
 
 // RevokeCurrentDevice revokes the current device session identified by the provided refresh token.
 func (h *AuthHandler) RevokeCurrentDevice(ctx context.Context, req *proto.RevokeCurrentDeviceRequest) (*proto.RevokeResponse, error) {
-	if err := h.service.RevokeByRefreshToken(ctx, req.RefreshToken); err != nil {
+	dev, err := h.service.RevokeByRefreshToken(ctx, req.RefreshToken)
+	if err != nil {
 		if errors.Is(err, appjwt.ErrInvalidToken) {
 			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to revoke device: %v", err)
 	}
+	realtime.GetGlobalHub().DisconnectSession(dev.UserID.String(), dev.FamilyID.String())
 	return &proto.RevokeResponse{Success: true}, nil
 }
 
-// LogoutAllDevices revokes all active device sessions for the user identified by the access token.
+// LogoutAllDevices revokes all active device sessions for the user identified by the bearer
+// token; the deprecated access_token field is ignored.
 func (h *AuthHandler) LogoutAllDevices(ctx context.Context, req *proto.LogoutAllDevicesRequest) (*proto.RevokeResponse, error) {
-	if err := h.service.RevokeAllForAccessToken(ctx, req.AccessToken); err != nil {
+	token, err := middleware.BearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := h.service.RevokeAllForAccessToken(ctx, token)
+	if err != nil {
 		if errors.Is(err, appjwt.ErrInvalidToken) {
 			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to logout all devices: %v", err)
 	}
+	realtime.GetGlobalHub().DisconnectUser(userID)
 	return &proto.RevokeResponse{Success: true}, nil
 }
+
+// ListDevices returns the caller's active device sessions.
+func (h *AuthHandler) ListDevices(ctx context.Context, req *proto.ListDevicesRequest) (*proto.ListDevicesResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "claims not found in context")
+	}
+	devices, err := h.service.ListDevices(ctx, claims.Subject)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list devices: %v", err)
+	}
+	out := make([]*proto.DeviceSession, 0, len(devices))
+	for _, d := range devices {
+		out = append(out, toProtoDevice(d, claims.SessionID))
+	}
+	return &proto.ListDevicesResponse{Devices: out}, nil
+}
+
+// RevokeDevice revokes one of the caller's device sessions and closes its realtime streams.
+func (h *AuthHandler) RevokeDevice(ctx context.Context, req *proto.RevokeDeviceRequest) (*proto.RevokeResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "claims not found in context")
+	}
+	if err := h.service.RevokeDevice(ctx, claims.Subject, req.DeviceId); err != nil {
+		return nil, deviceError(err, "failed to revoke device")
+	}
+	// Only streams in this process are kicked here; realtime_service re-checks
+	// its streams' tokens every REVOCATION_CACHE_TTL and ends revoked ones then.
+	realtime.GetGlobalHub().DisconnectSession(claims.Subject, req.DeviceId)
+	return &proto.RevokeResponse{Success: true}, nil
+}
+
+// UpdateDevice changes the name or type of one of the caller's device sessions.
+func (h *AuthHandler) UpdateDevice(ctx context.Context, req *proto.UpdateDeviceRequest) (*proto.UpdateDeviceResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "claims not found in context")
+	}
+	deviceID := req.DeviceId
+	if deviceID == "" {
+		deviceID = claims.SessionID
+	}
+	dev, err := h.service.UpdateDevice(ctx, claims.Subject, deviceID, req.DeviceName, req.DeviceType)
+	if err != nil {
+		return nil, deviceError(err, "failed to update device")
+	}
+	return &proto.UpdateDeviceResponse{Device: toProtoDevice(dev, claims.SessionID)}, nil
+}
+
+// RegisterPushToken sets the push token of the caller's current device session.
+func (h *AuthHandler) RegisterPushToken(ctx context.Context, req *proto.RegisterPushTokenRequest) (*proto.RegisterPushTokenResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "claims not found in context")
+	}
+	dev, err := h.service.RegisterPushToken(ctx, claims.Subject, claims.SessionID, req.Provider, req.Token)
+	if err != nil {
+		return nil, deviceError(err, "failed to register push token")
+	}
+	return &proto.RegisterPushTokenResponse{Device: toProtoDevice(dev, claims.SessionID)}, nil
+}
+
+// GetPublicKeys returns the JWKS services use to validate access tokens.
+func (h *AuthHandler) GetPublicKeys(ctx context.Context, req *proto.GetPublicKeysRequest) (*proto.GetPublicKeysResponse, error) {
+	data, err := json.Marshal(h.service.PublicKeys())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode public keys: %v", err)
+	}
+	return &proto.GetPublicKeysResponse{JwksJson: string(data)}, nil
+}
+
+// deviceError maps device session errors to gRPC status codes.
+func deviceError(err error, msg string) error {
+	switch {
+	case errors.Is(err, service.ErrDeviceNotFound):
+		return status.Error(codes.NotFound, "device session not found")
+	case errors.Is(err, service.ErrInvalidDeviceInfo):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+func toProtoDevice(d *domain.UserDevice, currentSessionID string) *proto.DeviceSession {
+	out := &proto.DeviceSession{
+		DeviceId:     d.FamilyID.String(),
+		DeviceName:   d.DeviceName,
+		DeviceType:   d.DeviceType,
+		IsCurrent:    currentSessionID != "" && d.FamilyID.String() == currentSessionID,
+		PushProvider: d.PushProvider,
+	}
+	if !d.LastLoginAt.IsZero() {
+		out.LastLoginAt = d.LastLoginAt.Format(time.RFC3339)
+	}
+	return out
+}
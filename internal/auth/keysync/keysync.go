@@ -0,0 +1,93 @@
+// Package keysync keeps a verify-only TokenManager in sync with the public
+// keys published by the auth service's GetPublicKeys RPC.
+package keysync
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dykethecreator/GoApp/pkg/config"
+	"github.com/dykethecreator/GoApp/pkg/jwt"
+	"github.com/dykethecreator/GoApp/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DefaultRefreshInterval is how often keys are re-fetched. It must be well
+// below the grace period of rotated keys so new keys are picked up in time.
+const DefaultRefreshInterval = 5 * time.Minute
+
+// Fetch loads the current JWKS from the auth service and installs it in tm.
+func Fetch(ctx context.Context, client proto.AuthServiceClient, tm *jwt.TokenManager) error {
+	resp, err := client.GetPublicKeys(ctx, &proto.GetPublicKeysRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to fetch public keys: %w", err)
+	}
+	keys, err := jwt.ParseJWKS([]byte(resp.GetJwksJson()))
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("auth service published no public keys")
+	}
+	tm.SetVerificationKeys(keys...)
+	return nil
+}
+
+// Run re-fetches keys every interval until ctx is cancelled. Failures are
+// logged and the previously fetched keys stay in use.
+func Run(ctx context.Context, client proto.AuthServiceClient, tm *jwt.TokenManager, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fetchCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			if err := Fetch(fetchCtx, client, tm); err != nil {
+				log.Printf("Warning: public key refresh failed: %v", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// NewVerifierFromConfig builds a verify-only TokenManager. When
+// AuthServiceAddr is set, keys are fetched from the auth service and refreshed
+// every JWKSRefreshInterval in the background; otherwise
+// jwt.NewVerifierFromConfig is used.
+func NewVerifierFromConfig(ctx context.Context, cfg config.JWTConfig) (*jwt.TokenManager, error) {
+	addr := cfg.AuthServiceAddr
+	if addr == "" {
+		return jwt.NewVerifierFromConfig(cfg)
+	}
+
+	interval := cfg.JWKSRefreshInterval
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to auth service: %w", err)
+	}
+	client := proto.NewAuthServiceClient(conn)
+
+	tm := jwt.NewVerifier()
+	fetchCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := Fetch(fetchCtx, client, tm); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	go func() {
+		defer conn.Close()
+		Run(ctx, client, tm, interval)
+	}()
+	log.Printf("Validating tokens with public keys from %s (refresh every %s)", addr, interval)
+	return tm, nil
+}
@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Message is an email "delivered" by the LocalSender.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+	SentAt  time.Time
+}
+
+// LocalSender logs emails and keeps them in memory, for development and tests.
+type LocalSender struct {
+	mu       sync.RWMutex
+	messages []Message
+}
+
+func NewLocalSender() *LocalSender { return &LocalSender{} }
+
+func (s *LocalSender) Send(ctx context.Context, to, subject, body string) error {
+	s.mu.Lock()
+	s.messages = append(s.messages, Message{To: to, Subject: subject, Body: body, SentAt: time.Now()})
+	s.mu.Unlock()
+	log.Printf("[LOCAL MAIL] To: %s | Subject: %s\n%s", to, subject, body)
+	return nil
+}
+
+// Last returns the most recent email sent to the address.
+func (s *LocalSender) Last(to string) (Message, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		if s.messages[i].To == to {
+			return s.messages[i], true
+		}
+	}
+	return Message{}, false
+}
@@ -0,0 +1,38 @@
+// Package mail sends transactional email, such as two-step verification
+// recovery codes.
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/dykethecreator/GoApp/pkg/config"
+)
+
+// Sender delivers a plain-text email.
+type Sender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// Provider names accepted by mail.provider.
+const (
+	ProviderSMTP  = "smtp"
+	ProviderLocal = "local"
+)
+
+// NewSender builds the sender selected by cfg.Provider. It returns nil when
+// no provider is set, which disables email.
+func NewSender(cfg config.MailConfig) (Sender, error) {
+	switch name := cfg.Provider; name {
+	case "":
+		return nil, nil
+	case ProviderSMTP:
+		return NewSMTPSender(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	case ProviderLocal:
+		log.Printf("[LOCAL MAIL] Emails are logged, not delivered")
+		return NewLocalSender(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail provider %q (expected %q or %q)", name, ProviderSMTP, ProviderLocal)
+	}
+}
@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPSender sends email through an SMTP server, authenticating with PLAIN
+// auth when a username is set.
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPSender(addr, username, password, from string) (*SMTPSender, error) {
+	if addr == "" || from == "" {
+		return nil, errors.New("SMTP_ADDR and MAIL_FROM are required for the smtp mail provider")
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP_ADDR %q: %w", addr, err)
+	}
+	s := &SMTPSender{addr: addr, from: from}
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return s, nil
+}
+
+func (s *SMTPSender) Send(ctx context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return errors.New("mail header contains a line break")
+	}
+	msg := "From: " + s.from + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n\r\n" +
+		body
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{to}, []byte(msg))
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"log"
 	"strings"
 
 	appjwt "github.com/dykethecreator/GoApp/pkg/jwt"
@@ -17,6 +18,16 @@ type userIDKeyType struct{}
 
 var userIDKey = userIDKeyType{}
 
+// sessionIDKeyType is the context key type for the device session ID ('sid' claim).
+type sessionIDKeyType struct{}
+
+var sessionIDKey = sessionIDKeyType{}
+
+// claimsKeyType is the context key type for the validated access token claims.
+type claimsKeyType struct{}
+
+var claimsKey = claimsKeyType{}
+
 // UserIDFromContext returns the authenticated user ID from context, if present.
 func UserIDFromContext(ctx context.Context) (string, bool) {
 	v := ctx.Value(userIDKey)
@@ -27,83 +38,132 @@ func UserIDFromContext(ctx context.Context) (string, bool) {
 	return id, ok
 }
 
-// UnaryAuthInterceptor returns a grpc.UnaryServerInterceptor that validates
-// incoming requests using the provided TokenManager. On success, it injects
-// the user ID into the context for downstream handlers.
-func UnaryAuthInterceptor(tm *appjwt.TokenManager) grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		// Exempt AuthService methods by default (public endpoints)
-		if strings.HasPrefix(info.FullMethod, "/auth.AuthService/") {
-			return handler(ctx, req)
-		}
+// SessionIDFromContext returns the device session ID of the authenticated
+// access token, if present. Tokens issued before sessions were tracked have none.
+func SessionIDFromContext(ctx context.Context) (string, bool) {
+	v := ctx.Value(sessionIDKey)
+	if v == nil {
+		return "", false
+	}
+	id, ok := v.(string)
+	return id, ok && id != ""
+}
 
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "missing metadata")
-		}
+// ClaimsFromContext returns the validated claims of the caller's access token,
+// e.g. for long-lived streams to re-check them against the revocation list.
+func ClaimsFromContext(ctx context.Context) (*appjwt.CustomClaims, bool) {
+	claims, ok := ctx.Value(claimsKey).(*appjwt.CustomClaims)
+	return claims, ok
+}
 
-		authHeaders := md.Get("authorization")
-		if len(authHeaders) == 0 {
-			return nil, status.Error(codes.Unauthenticated, "missing authorization header")
-		}
+// withClaims injects the validated claims and the user and session IDs from
+// them into ctx.
+func withClaims(ctx context.Context, claims *appjwt.CustomClaims) context.Context {
+	ctx = context.WithValue(ctx, claimsKey, claims)
+	ctx = context.WithValue(ctx, userIDKey, claims.Subject)
+	return context.WithValue(ctx, sessionIDKey, claims.SessionID)
+}
 
-		auth := authHeaders[0]
-		if !strings.HasPrefix(strings.ToLower(auth), "bearer ") {
-			return nil, status.Error(codes.Unauthenticated, "invalid authorization header")
-		}
-		token := strings.TrimSpace(auth[len("bearer "):])
+// BearerToken extracts the token from the "authorization: Bearer <token>"
+// metadata header. The returned error is a gRPC Unauthenticated status.
+func BearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing metadata")
+	}
 
-		claims, err := tm.ValidateToken(token)
-		if err != nil {
-			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
-		}
-		if claims.Type != appjwt.TokenTypeAccess {
-			return nil, status.Error(codes.Unauthenticated, "token must be an access token")
-		}
+	authHeaders := md.Get("authorization")
+	if len(authHeaders) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing authorization header")
+	}
 
-		// Inject user ID into context
-		ctx = context.WithValue(ctx, userIDKey, claims.Subject)
-		return handler(ctx, req)
+	auth := authHeaders[0]
+	if !strings.HasPrefix(strings.ToLower(auth), "bearer ") {
+		return "", status.Error(codes.Unauthenticated, "invalid authorization header")
 	}
+	return strings.TrimSpace(auth[len("bearer "):]), nil
 }
 
-// StreamAuthInterceptor returns a grpc.StreamServerInterceptor that validates
-// streaming connections using the provided TokenManager.
-func StreamAuthInterceptor(tm *appjwt.TokenManager) grpc.StreamServerInterceptor {
-	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		ctx := ss.Context()
+// RevocationChecker reports whether a validly signed access token has been
+// revoked (e.g. by logging out all devices). See internal/auth/revocation.
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, claims *appjwt.CustomClaims) (bool, error)
+}
 
-		// Exempt AuthService methods by default (if any streaming methods)
-		if strings.HasPrefix(info.FullMethod, "/auth.AuthService/") {
-			return handler(srv, ss)
+// authenticateToken validates an access token and checks it against the
+// revocation list, if any. Returned errors are gRPC statuses.
+func authenticateToken(ctx context.Context, tm *appjwt.TokenManager, revoked RevocationChecker, token string) (*appjwt.CustomClaims, error) {
+	claims, err := tm.ValidateToken(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	if claims.Type != appjwt.TokenTypeAccess {
+		return nil, status.Error(codes.Unauthenticated, "token must be an access token")
+	}
+	if revoked != nil {
+		isRevoked, err := revoked.IsRevoked(ctx, claims)
+		if err != nil {
+			// Fail closed: a token we cannot check is not accepted
+			log.Printf("Revocation check failed for user %s: %v", claims.Subject, err)
+			return nil, status.Error(codes.Unavailable, "unable to verify token")
 		}
-
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return status.Error(codes.Unauthenticated, "missing metadata")
+		if isRevoked {
+			return nil, status.Error(codes.Unauthenticated, "token has been revoked")
 		}
+	}
+	return claims, nil
+}
 
-		authHeaders := md.Get("authorization")
-		if len(authHeaders) == 0 {
-			return status.Error(codes.Unauthenticated, "missing authorization header")
-		}
+// authorize applies the policy to method. For authenticated and admin methods
+// it returns ctx carrying the caller's user and session IDs. Returned errors
+// are gRPC statuses.
+func authorize(ctx context.Context, tm *appjwt.TokenManager, revoked RevocationChecker, policy Policy, method string) (context.Context, error) {
+	access, ok := policy[method]
+	if !ok {
+		// Fail closed; Policy.Validate catches this at startup
+		return nil, status.Errorf(codes.PermissionDenied, "no auth policy for %s", method)
+	}
+	if access == Public {
+		return ctx, nil
+	}
 
-		auth := authHeaders[0]
-		if !strings.HasPrefix(strings.ToLower(auth), "bearer ") {
-			return status.Error(codes.Unauthenticated, "invalid authorization header")
-		}
-		token := strings.TrimSpace(auth[len("bearer "):])
+	token, err := BearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := authenticateToken(ctx, tm, revoked, token)
+	if err != nil {
+		return nil, err
+	}
+	if access == Admin && !claims.HasScope(AdminScope) {
+		return nil, status.Error(codes.PermissionDenied, "admin scope required")
+	}
+	return withClaims(ctx, claims), nil
+}
 
-		claims, err := tm.ValidateToken(token)
+// UnaryAuthInterceptor returns a grpc.UnaryServerInterceptor that enforces
+// policy on incoming requests using the provided TokenManager and, when
+// revoked is not nil, rejects revoked access tokens. For methods that need a
+// token, it injects the user ID into the context for downstream handlers.
+func UnaryAuthInterceptor(tm *appjwt.TokenManager, revoked RevocationChecker, policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authorize(ctx, tm, revoked, policy, info.FullMethod)
 		if err != nil {
-			return status.Error(codes.Unauthenticated, "invalid or expired token")
-		}
-		if claims.Type != appjwt.TokenTypeAccess {
-			return status.Error(codes.Unauthenticated, "token must be an access token")
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		// Inject user ID into context
-		ctx = context.WithValue(ctx, userIDKey, claims.Subject)
+// StreamAuthInterceptor returns a grpc.StreamServerInterceptor that enforces
+// policy on streaming connections using the provided TokenManager and
+// revocation list.
+func StreamAuthInterceptor(tm *appjwt.TokenManager, revoked RevocationChecker, policy Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), tm, revoked, policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
 
 		// Wrap the stream with new context
 		wrappedStream := &wrappedServerStream{
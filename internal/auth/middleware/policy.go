@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// Access is who may call a gRPC method.
+type Access int
+
+const (
+	// Public methods need no token; they authenticate the caller themselves
+	// (OTP codes, refresh tokens, PIN challenges, device secrets) or serve
+	// public data.
+	Public Access = iota + 1
+	// Authenticated methods need a valid, unrevoked access token.
+	Authenticated
+	// Admin methods need an access token with the admin scope.
+	Admin
+)
+
+// AdminScope is the access token scope Admin methods require.
+const AdminScope = "admin"
+
+func (a Access) String() string {
+	switch a {
+	case Public:
+		return "public"
+	case Authenticated:
+		return "authenticated"
+	case Admin:
+		return "admin"
+	default:
+		return fmt.Sprintf("Access(%d)", int(a))
+	}
+}
+
+// Policy maps full gRPC method names ("/chat.ChatService/SendMessage") to who
+// may call them. Methods missing from the policy are rejected.
+type Policy map[string]Access
+
+// DefaultPolicy declares every RPC served by the cmd binaries. New RPCs must
+// be added here; servers refuse to start with an undeclared method.
+var DefaultPolicy = Policy{
+	"/auth.AuthService/SendOTP":             Public,
+	"/auth.AuthService/VerifyOTP":           Public,
+	"/auth.AuthService/ValidateToken":       Public,
+	"/auth.AuthService/RefreshToken":        Public,
+	"/auth.AuthService/RevokeCurrentDevice": Public,
+	"/auth.AuthService/LogoutAllDevices":    Authenticated,
+	"/auth.AuthService/ListDevices":         Authenticated,
+	"/auth.AuthService/RevokeDevice":        Authenticated,
+	"/auth.AuthService/UpdateDevice":        Authenticated,
+	"/auth.AuthService/RegisterPushToken":   Authenticated,
+	"/auth.AuthService/VerifyPIN":           Public,
+	"/auth.AuthService/RequestPINReset":     Public,
+	"/auth.AuthService/ResetPIN":            Public,
+	"/auth.AuthService/SetPIN":              Authenticated,
+	"/auth.AuthService/DisablePIN":          Authenticated,
+	"/auth.AuthService/RequestNumberChange": Authenticated,
+	"/auth.AuthService/ChangeNumber":        Authenticated,
+	"/auth.AuthService/StartDeviceLink":     Public,
+	"/auth.AuthService/ApproveDeviceLink":   Authenticated,
+	"/auth.AuthService/CompleteDeviceLink":  Public,
+	"/auth.AuthService/GetPublicKeys":       Public,
+
+	"/user.UserService/GetUser":               Authenticated,
+	"/user.UserService/BatchGetUsers":         Authenticated,
+	"/user.UserService/UpdateProfile":         Authenticated,
+	"/user.UserService/SyncContacts":          Authenticated,
+	"/user.UserService/BlockUser":             Authenticated,
+	"/user.UserService/UnblockUser":           Authenticated,
+	"/user.UserService/ListBlocked":           Authenticated,
+	"/user.UserService/GetPrivacySettings":    Authenticated,
+	"/user.UserService/UpdatePrivacySettings": Authenticated,
+	"/user.UserService/DeleteAccount":         Authenticated,
+	"/user.UserService/ExportMyData":          Authenticated,
+
+	"/chat.ChatService/CreateConversation":  Authenticated,
+	"/chat.ChatService/SendMessage":         Authenticated,
+	"/chat.ChatService/ListMessages":        Authenticated,
+	"/chat.ChatService/GetConversations":    Authenticated,
+	"/chat.ChatService/MuteConversation":    Authenticated,
+	"/chat.ChatService/EditMessage":         Authenticated,
+	"/chat.ChatService/DeleteMessage":       Authenticated,
+	"/chat.ChatService/ReactToMessage":      Authenticated,
+	"/chat.ChatService/RemoveReaction":      Authenticated,
+	"/chat.ChatService/AddMembers":          Authenticated,
+	"/chat.ChatService/RemoveMember":        Authenticated,
+	"/chat.ChatService/LeaveGroup":          Authenticated,
+	"/chat.ChatService/PromoteAdmin":        Authenticated,
+	"/chat.ChatService/DemoteAdmin":         Authenticated,
+	"/chat.ChatService/UpdateGroupInfo":     Authenticated,
+	"/chat.ChatService/UpdateGroupSettings": Authenticated,
+	"/chat.ChatService/CreateInvite":        Authenticated,
+	"/chat.ChatService/ListInvites":         Authenticated,
+	"/chat.ChatService/RevokeInvite":        Authenticated,
+	"/chat.ChatService/JoinViaInvite":       Authenticated,
+	"/chat.ChatService/ListJoinRequests":    Authenticated,
+	"/chat.ChatService/ApproveJoinRequest":  Authenticated,
+	"/chat.ChatService/DeclineJoinRequest":  Authenticated,
+
+	"/proto.RealtimeService/Connect": Authenticated,
+
+	// Server reflection, registered by all_in_one and realtime_service
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      Public,
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": Public,
+}
+
+// Validate returns an error listing the methods registered on s that the
+// policy does not declare. Call it after registering all services.
+func (p Policy) Validate(s *grpc.Server) error {
+	var missing []string
+	for service, info := range s.GetServiceInfo() {
+		for _, m := range info.Methods {
+			method := "/" + service + "/" + m.Name
+			if _, ok := p[method]; !ok {
+				missing = append(missing, method)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("no auth policy for %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package otp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+// Message is a code "delivered" by the LocalProvider.
+type Message struct {
+	PhoneNumber string    `json:"phone_number"`
+	Code        string    `json:"code"`
+	SentAt      time.Time `json:"sent_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// Inbox is an in-memory fake SMS sink that tests can read codes from.
+type Inbox struct {
+	mu       sync.RWMutex
+	messages []Message
+}
+
+func (i *Inbox) add(m Message) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.messages = append(i.messages, m)
+}
+
+// Messages returns a copy of every message delivered so far, oldest first.
+func (i *Inbox) Messages() []Message {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	out := make([]Message, len(i.messages))
+	copy(out, i.messages)
+	return out
+}
+
+// Last returns the most recent message delivered to the phone number.
+func (i *Inbox) Last(phoneNumber string) (Message, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	for idx := len(i.messages) - 1; idx >= 0; idx-- {
+		if i.messages[idx].PhoneNumber == phoneNumber {
+			return i.messages[idx], true
+		}
+	}
+	return Message{}, false
+}
+
+type pendingCode struct {
+	code      string
+	expiresAt time.Time
+}
+
+// LocalProvider generates random codes without any outside network. Codes are
+// kept with an expiry, recorded in an in-memory Inbox and, if a sink file is
+// configured, appended to that file one line per message.
+type LocalProvider struct {
+	mu       sync.Mutex
+	pending  map[string]pendingCode // phone -> latest code
+	ttl      time.Duration
+	inbox    *Inbox
+	sinkFile string
+	now      func() time.Time
+}
+
+// NewLocalProvider creates a LocalProvider. sinkFile may be empty to keep codes in memory only.
+func NewLocalProvider(ttl time.Duration, sinkFile string) *LocalProvider {
+	if ttl <= 0 {
+		ttl = DefaultCodeTTL
+	}
+	return &LocalProvider{
+		pending:  make(map[string]pendingCode),
+		ttl:      ttl,
+		inbox:    &Inbox{},
+		sinkFile: sinkFile,
+		now:      time.Now,
+	}
+}
+
+// Inbox returns the in-memory sink holding every code sent by this provider.
+func (p *LocalProvider) Inbox() *Inbox { return p.inbox }
+
+func (p *LocalProvider) Send(ctx context.Context, phoneNumber string) (string, error) {
+	code, err := generateCode()
+	if err != nil {
+		return "", err
+	}
+	now := p.now()
+	msg := Message{
+		PhoneNumber: phoneNumber,
+		Code:        code,
+		SentAt:      now,
+		ExpiresAt:   now.Add(p.ttl),
+	}
+
+	p.mu.Lock()
+	p.pending[phoneNumber] = pendingCode{code: code, expiresAt: msg.ExpiresAt}
+	p.mu.Unlock()
+
+	p.inbox.add(msg)
+	if p.sinkFile != "" {
+		if err := appendToSink(p.sinkFile, msg); err != nil {
+			log.Printf("[LOCAL OTP] Warning: failed to write code to %s: %v", p.sinkFile, err)
+		}
+	} else {
+		log.Printf("[LOCAL OTP] Code for %s: %s (expires %s)", phoneNumber, code, msg.ExpiresAt.Format(time.RFC3339))
+	}
+	return "pending", nil
+}
+
+func (p *LocalProvider) Check(ctx context.Context, phoneNumber, code string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pc, ok := p.pending[phoneNumber]
+	if !ok {
+		return ErrInvalidCode
+	}
+	if p.now().After(pc.expiresAt) {
+		delete(p.pending, phoneNumber)
+		return ErrInvalidCode
+	}
+	if subtle.ConstantTimeCompare([]byte(pc.code), []byte(code)) != 1 {
+		return ErrInvalidCode
+	}
+	// Codes are single use
+	delete(p.pending, phoneNumber)
+	return nil
+}
+
+// generateCode returns a uniformly random 6-digit code.
+func generateCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate OTP code: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+func appendToSink(path string, m Message) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "%s\t%s\t%s\t%s\n", m.SentAt.Format(time.RFC3339), m.PhoneNumber, m.Code, m.ExpiresAt.Format(time.RFC3339))
+	return err
+}
@@ -0,0 +1,71 @@
+package otp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocalProvider_SendAndCheck(t *testing.T) {
+	p := NewLocalProvider(time.Minute, "")
+	ctx := context.Background()
+
+	if _, err := p.Send(ctx, "+905550000001"); err != nil {
+		t.Fatalf("Send error: %v", err)
+	}
+	msg, ok := p.Inbox().Last("+905550000001")
+	if !ok {
+		t.Fatalf("expected code in inbox")
+	}
+	if len(msg.Code) != 6 {
+		t.Fatalf("expected 6-digit code, got %q", msg.Code)
+	}
+
+	if err := p.Check(ctx, "+905550000001", "not-it"); !errors.Is(err, ErrInvalidCode) {
+		t.Fatalf("expected ErrInvalidCode for wrong code, got %v", err)
+	}
+	if err := p.Check(ctx, "+905550000001", msg.Code); err != nil {
+		t.Fatalf("Check error: %v", err)
+	}
+	if err := p.Check(ctx, "+905550000001", msg.Code); !errors.Is(err, ErrInvalidCode) {
+		t.Fatalf("expected code to be single use, got %v", err)
+	}
+}
+
+func TestLocalProvider_Expiry(t *testing.T) {
+	p := NewLocalProvider(time.Minute, "")
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	if _, err := p.Send(context.Background(), "+905550000002"); err != nil {
+		t.Fatalf("Send error: %v", err)
+	}
+	msg, _ := p.Inbox().Last("+905550000002")
+
+	p.now = func() time.Time { return now.Add(2 * time.Minute) }
+	if err := p.Check(context.Background(), "+905550000002", msg.Code); !errors.Is(err, ErrInvalidCode) {
+		t.Fatalf("expected expired code to be rejected, got %v", err)
+	}
+}
+
+func TestLocalProvider_SinkFile(t *testing.T) {
+	sink := filepath.Join(t.TempDir(), "otp.log")
+	p := NewLocalProvider(time.Minute, sink)
+
+	if _, err := p.Send(context.Background(), "+905550000003"); err != nil {
+		t.Fatalf("Send error: %v", err)
+	}
+	msg, _ := p.Inbox().Last("+905550000003")
+
+	data, err := os.ReadFile(sink)
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	if !strings.Contains(string(data), "+905550000003\t"+msg.Code) {
+		t.Fatalf("expected sink file to contain code, got %q", string(data))
+	}
+}
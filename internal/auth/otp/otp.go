@@ -0,0 +1,54 @@
+package otp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dykethecreator/GoApp/pkg/config"
+)
+
+// ErrInvalidCode is returned when a submitted code is wrong, expired or already used.
+var ErrInvalidCode = errors.New("OTP verification failed or code is incorrect")
+
+// OTPProvider sends one-time codes to a phone number and checks the codes
+// submitted back by the client.
+type OTPProvider interface {
+	// Send delivers a new code to the phone number and returns a provider status (e.g. "pending").
+	Send(ctx context.Context, phoneNumber string) (string, error)
+	// Check verifies the code for the phone number. It returns ErrInvalidCode
+	// when the code is not accepted; other errors indicate provider failures.
+	Check(ctx context.Context, phoneNumber, code string) error
+}
+
+// Provider names accepted by otp.provider.
+const (
+	ProviderTwilio = "twilio"
+	ProviderLocal  = "local"
+)
+
+// DefaultCodeTTL is how long a locally generated code stays valid.
+const DefaultCodeTTL = 5 * time.Minute
+
+// NewProvider builds the OTP provider selected by cfg.ProviderName.
+func NewProvider(cfg config.OTPConfig) (OTPProvider, error) {
+	switch name := cfg.ProviderName(); name {
+	case ProviderTwilio:
+		return NewTwilioProvider(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.TwilioVerifyServiceSID)
+	case ProviderLocal:
+		ttl := cfg.LocalTTL
+		if ttl <= 0 {
+			ttl = DefaultCodeTTL
+		}
+		if cfg.LocalSinkFile != "" {
+			log.Printf("[LOCAL OTP] Codes are written to %s", cfg.LocalSinkFile)
+		} else {
+			log.Printf("[LOCAL OTP] Codes are kept in memory and logged; set OTP_LOCAL_SINK_FILE to write them to a file")
+		}
+		return NewLocalProvider(ttl, cfg.LocalSinkFile), nil
+	default:
+		return nil, fmt.Errorf("unknown OTP provider %q (expected %q or %q)", name, ProviderTwilio, ProviderLocal)
+	}
+}
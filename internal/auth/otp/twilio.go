@@ -0,0 +1,65 @@
+package otp
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/twilio/twilio-go"
+	verify "github.com/twilio/twilio-go/rest/verify/v2"
+)
+
+// TwilioProvider sends and checks codes through the Twilio Verify API.
+type TwilioProvider struct {
+	client           *twilio.RestClient
+	verifyServiceSID string
+}
+
+// NewTwilioProvider creates a TwilioProvider from account credentials and a Verify service SID.
+func NewTwilioProvider(accountSID, authToken, verifyServiceSID string) (*TwilioProvider, error) {
+	if accountSID == "" || authToken == "" || verifyServiceSID == "" {
+		return nil, errors.New("Twilio environment variables not set (set OTP_PROVIDER=local to bypass in development)")
+	}
+	client := twilio.NewRestClientWithParams(twilio.ClientParams{
+		Username: accountSID,
+		Password: authToken,
+	})
+	return &TwilioProvider{client: client, verifyServiceSID: verifyServiceSID}, nil
+}
+
+func (p *TwilioProvider) Send(ctx context.Context, phoneNumber string) (string, error) {
+	params := &verify.CreateVerificationParams{}
+	params.SetTo(phoneNumber)
+	params.SetChannel("sms")
+
+	resp, err := p.client.VerifyV2.CreateVerification(p.verifyServiceSID, params)
+	if err != nil {
+		log.Printf("Failed to send OTP via Twilio: %v\n", err)
+		return "", err
+	}
+
+	log.Printf("OTP sent successfully. Status: %s, SID: %s\n", *resp.Status, *resp.Sid)
+	return *resp.Status, nil
+}
+
+func (p *TwilioProvider) Check(ctx context.Context, phoneNumber, code string) error {
+	params := &verify.CreateVerificationCheckParams{}
+	params.SetTo(phoneNumber)
+	params.SetCode(code)
+
+	resp, err := p.client.VerifyV2.CreateVerificationCheck(p.verifyServiceSID, params)
+	if err != nil {
+		log.Printf("Failed to verify OTP via Twilio: %v\n", err)
+		return err
+	}
+
+	if resp.Status == nil || *resp.Status != "approved" {
+		status := "<nil>"
+		if resp.Status != nil {
+			status = *resp.Status
+		}
+		log.Printf("OTP verification failed for %s. Status: %s\n", phoneNumber, status)
+		return ErrInvalidCode
+	}
+	return nil
+}
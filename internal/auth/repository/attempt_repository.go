@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// AttemptRepository stores attempt counters and lockouts for rate-limited auth actions.
+type AttemptRepository interface {
+	GetAttempt(ctx context.Context, key string) (*domain.AuthAttempt, error)
+	// IncrementAttempt bumps the counter for key, starting a new window at now
+	// when the current one is older than window, and returns the updated record.
+	// While the key is locked at now the counter is left as is.
+	IncrementAttempt(ctx context.Context, key string, now time.Time, window time.Duration) (*domain.AuthAttempt, error)
+	// RefundAttempt takes one back from the counter for key, if above zero.
+	RefundAttempt(ctx context.Context, key string) error
+	// LockAttempt locks key until the given time, records the lockout count and clears the counter.
+	LockAttempt(ctx context.Context, key string, until time.Time, lockouts int) error
+	ResetAttempt(ctx context.Context, key string) error
+}
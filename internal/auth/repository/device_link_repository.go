@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// DeviceLinkRepository stores companion devices waiting to be linked.
+type DeviceLinkRepository interface {
+	// CreateLink stores a pending link and drops expired ones.
+	CreateLink(ctx context.Context, l *domain.DeviceLink) error
+	// ApproveLink links the pending, unexpired link with the token hash to the
+	// user and returns it, or nil if there is no such link.
+	ApproveLink(ctx context.Context, tokenHash, userID string, now time.Time) (*domain.DeviceLink, error)
+	// GetLink returns the unexpired link with both hashes, or nil.
+	GetLink(ctx context.Context, tokenHash, secretHash string, now time.Time) (*domain.DeviceLink, error)
+	// ClaimApprovedLink deletes the approved, unexpired link with both hashes
+	// and returns it, or nil; a link can be claimed once.
+	ClaimApprovedLink(ctx context.Context, tokenHash, secretHash string, now time.Time) (*domain.DeviceLink, error)
+}
@@ -10,6 +10,21 @@ import (
 type DeviceRepository interface {
 	UpsertDevice(ctx context.Context, dev *domain.UserDevice) error
 	FindActiveByUserAndHash(ctx context.Context, userID string, hash string) (*domain.UserDevice, error)
+	// FindByUserAndHash returns the session for the hash whether or not it has been revoked.
+	FindByUserAndHash(ctx context.Context, userID string, hash string) (*domain.UserDevice, error)
 	RevokeByID(ctx context.Context, id string) error
+	// RotateDevice atomically revokes the session oldID and stores next. It
+	// returns false when oldID was already revoked, i.e. its token was reused.
+	RotateDevice(ctx context.Context, oldID string, next *domain.UserDevice) (bool, error)
+	// RevokeFamily revokes every session that shares the given family ID.
+	RevokeFamily(ctx context.Context, familyID string) error
 	RevokeAllForUser(ctx context.Context, userID string) error
+
+	// ListActiveForUser returns the active session of every token family of the user.
+	ListActiveForUser(ctx context.Context, userID string) ([]*domain.UserDevice, error)
+	FindActiveByFamily(ctx context.Context, userID string, familyID string) (*domain.UserDevice, error)
+	// UpdateDeviceInfo saves the device name, type and push token of the session with dev.ID.
+	UpdateDeviceInfo(ctx context.Context, dev *domain.UserDevice) error
+	// ClearPushToken removes the push token from every session outside keepFamilyID.
+	ClearPushToken(ctx context.Context, token, keepFamilyID string) error
 }
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// PushTokenRepository reads the push tokens of active device sessions for
+// the push dispatcher.
+type PushTokenRepository interface {
+	// ListPushTokens returns the registered push tokens of the users' active sessions.
+	ListPushTokens(ctx context.Context, userIDs []string) ([]*domain.PushToken, error)
+	// DeletePushToken unregisters a token the push provider no longer accepts.
+	DeletePushToken(ctx context.Context, token string) error
+}
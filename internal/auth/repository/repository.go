@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/dykethecreator/GoApp/pkg/domain"
 )
@@ -12,4 +13,14 @@ type UserRepository interface {
 	FindByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error)
 	CreateUser(ctx context.Context, user *domain.User) (*domain.User, error)
 	FindByID(ctx context.Context, userID string) (*domain.User, error)
+	// ChangePhoneNumber moves the user from oldPhone to newPhone and relinks the
+	// address book entries other users hold for both numbers. It returns
+	// ErrPhoneNumberTaken if newPhone belongs to another user, and
+	// ErrPhoneNumberChanged if the user no longer has oldPhone.
+	ChangePhoneNumber(ctx context.Context, userID, oldPhone, newPhone string) (*domain.User, error)
 }
+
+var (
+	ErrPhoneNumberTaken   = errors.New("phone number is already registered")
+	ErrPhoneNumberChanged = errors.New("phone number changed concurrently")
+)
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// RevocationRepository stores access-token revocation watermarks keyed by
+// user or device session. Access tokens issued at or before a key's watermark
+// are rejected. Watermarks are only needed until every token they cover has
+// expired (expiresAt), after which stores may drop them.
+type RevocationRepository interface {
+	// RevokeBefore sets the watermark for key, keeping the later of the stored and new value.
+	RevokeBefore(ctx context.Context, key string, before, expiresAt time.Time) error
+	// GetWatermarks returns the watermarks of the keys that have one.
+	GetWatermarks(ctx context.Context, keys ...string) (map[string]time.Time, error)
+}
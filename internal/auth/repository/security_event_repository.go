@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// SecurityEventRepository records security-relevant auth events for auditing.
+type SecurityEventRepository interface {
+	RecordEvent(ctx context.Context, event *domain.SecurityEvent) error
+}
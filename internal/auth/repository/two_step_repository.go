@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// TwoStepRepository stores two-step verification PINs and the logins waiting for them.
+type TwoStepRepository interface {
+	// GetPIN returns the user's PIN settings, or nil if two-step verification is off.
+	GetPIN(ctx context.Context, userID string) (*domain.TwoStepPIN, error)
+	// SetPIN creates or replaces the PIN and recovery email and cancels any pending reset.
+	SetPIN(ctx context.Context, userID, pinHash string, recoveryEmail *string) error
+	DeletePIN(ctx context.Context, userID string) error
+	// StartReset starts the reset cooldown at now unless one is already running,
+	// and returns when the running cooldown started.
+	StartReset(ctx context.Context, userID string, now time.Time) (time.Time, error)
+	SetResetCode(ctx context.Context, userID, codeHash string, expiresAt time.Time) error
+	// CancelReset clears the reset cooldown and any emailed reset code.
+	CancelReset(ctx context.Context, userID string) error
+
+	// CreateChallenge stores a challenge and drops expired ones.
+	CreateChallenge(ctx context.Context, c *domain.PINChallenge) error
+	// GetChallenge returns the challenge with the token hash if it has not expired at now, or nil.
+	GetChallenge(ctx context.Context, tokenHash string, now time.Time) (*domain.PINChallenge, error)
+	DeleteChallenge(ctx context.Context, tokenHash string) error
+}
@@ -0,0 +1,157 @@
+// Package revocation implements the access-token revocation list checked by
+// the auth interceptors. Instead of listing every revoked jti, it keeps a
+// "tokens issued before" watermark per user (logout everywhere) and per device
+// session (a single device revoked), which covers every token those actions
+// must invalidate with one row each.
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/pkg/jwt"
+)
+
+// DefaultCacheTTL is how long a lookup is cached. A revocation made by another
+// instance takes effect here within this time.
+const DefaultCacheTTL = 30 * time.Second
+
+type cacheEntry struct {
+	before    time.Time // zero when the key has no watermark
+	fetchedAt time.Time
+}
+
+// List checks access tokens against revocation watermarks stored in a
+// RevocationRepository, caching lookups for ttl. The repository is the shared
+// state; revocations made through this List are visible to it immediately.
+type List struct {
+	repo        repository.RevocationRepository
+	ttl         time.Duration
+	tokenMaxAge time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+	now   func() time.Time
+}
+
+// NewList creates a List. tokenMaxAge is the access token lifetime: watermarks
+// are kept that long, after which every token they cover has expired anyway.
+func NewList(repo repository.RevocationRepository, ttl, tokenMaxAge time.Duration) *List {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &List{
+		repo:        repo,
+		ttl:         ttl,
+		tokenMaxAge: tokenMaxAge,
+		cache:       make(map[string]cacheEntry),
+		now:         time.Now,
+	}
+}
+
+func userKey(userID string) string       { return "user:" + userID }
+func sessionKey(sessionID string) string { return "session:" + sessionID }
+
+// RevokeUser rejects every access token of the user issued up to now.
+func (l *List) RevokeUser(ctx context.Context, userID string) error {
+	return l.revoke(ctx, userKey(userID))
+}
+
+// RevokeSession rejects every access token of the device session issued up to now.
+func (l *List) RevokeSession(ctx context.Context, sessionID string) error {
+	return l.revoke(ctx, sessionKey(sessionID))
+}
+
+func (l *List) revoke(ctx context.Context, key string) error {
+	now := l.now()
+	// Kept at full precision. Tokens carry iat in microseconds (iat_us), so only
+	// tokens issued within a few microseconds after now are also covered.
+	before := now
+	if err := l.repo.RevokeBefore(ctx, key, before, now.Add(l.tokenMaxAge)); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	if e := l.cache[key]; before.After(e.before) {
+		l.cache[key] = cacheEntry{before: before, fetchedAt: now}
+	}
+	l.mu.Unlock()
+	return nil
+}
+
+// IsRevoked reports whether the token was issued at or before the watermark of
+// its user or device session.
+func (l *List) IsRevoked(ctx context.Context, claims *jwt.CustomClaims) (bool, error) {
+	issuedAt := claims.IssuedAtTime()
+	if issuedAt.IsZero() {
+		return false, nil
+	}
+	keys := []string{userKey(claims.Subject)}
+	if claims.SessionID != "" {
+		keys = append(keys, sessionKey(claims.SessionID))
+	}
+
+	watermarks, err := l.lookup(ctx, keys)
+	if err != nil {
+		return false, err
+	}
+	for _, before := range watermarks {
+		if !before.IsZero() && !issuedAt.After(before) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// lookup returns the watermark of each key, from the cache when fresh.
+func (l *List) lookup(ctx context.Context, keys []string) ([]time.Time, error) {
+	now := l.now()
+	out := make([]time.Time, len(keys))
+	var missing []string
+
+	l.mu.Lock()
+	for i, k := range keys {
+		e, ok := l.cache[k]
+		if ok && now.Sub(e.fetchedAt) < l.ttl {
+			out[i] = e.before
+		} else {
+			missing = append(missing, k)
+		}
+	}
+	l.mu.Unlock()
+	if len(missing) == 0 {
+		return out, nil
+	}
+
+	found, err := l.repo.GetWatermarks(ctx, missing...)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pruneLocked(now)
+	for i, k := range keys {
+		if before, ok := found[k]; ok {
+			out[i] = before
+		}
+	}
+	for _, k := range missing {
+		l.cache[k] = cacheEntry{before: found[k], fetchedAt: now}
+	}
+	return out, nil
+}
+
+// pruneLocked drops stale cache entries once the cache has grown. Caller holds l.mu.
+func (l *List) pruneLocked(now time.Time) {
+	if len(l.cache) < 10000 {
+		return
+	}
+	for k, e := range l.cache {
+		if now.Sub(e.fetchedAt) >= l.ttl {
+			delete(l.cache, k)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/store"
+	"github.com/dykethecreator/GoApp/pkg/jwt"
+	gojwt "github.com/golang-jwt/jwt/v5"
+)
+
+func claimsAt(userID, sessionID string, iat time.Time) *jwt.CustomClaims {
+	return &jwt.CustomClaims{
+		Type:             jwt.TokenTypeAccess,
+		SessionID:        sessionID,
+		IssuedAtMicro:    iat.UnixMicro(),
+		RegisteredClaims: gojwt.RegisteredClaims{Subject: userID, IssuedAt: gojwt.NewNumericDate(iat)},
+	}
+}
+
+func TestList_SharedAcrossInstances(t *testing.T) {
+	ctx := context.Background()
+	repo := store.NewMemoryRevocationStore()
+	authInstance := NewList(repo, time.Minute, 15*time.Minute)
+	chatInstance := NewList(repo, time.Minute, 15*time.Minute)
+
+	now := time.Now()
+	chatInstance.now = func() time.Time { return now }
+	issued := now.Add(-5 * time.Minute)
+	old := claimsAt("u1", "s1", issued)
+
+	// Prime the other instance's cache with "not revoked"
+	if revoked, err := chatInstance.IsRevoked(ctx, old); err != nil || revoked {
+		t.Fatalf("expected token to be valid before revocation, revoked=%v err=%v", revoked, err)
+	}
+	if err := authInstance.RevokeUser(ctx, "u1"); err != nil {
+		t.Fatalf("RevokeUser error: %v", err)
+	}
+	if revoked, _ := authInstance.IsRevoked(ctx, old); !revoked {
+		t.Fatalf("expected token to be revoked on the revoking instance")
+	}
+
+	// The other instance picks it up once its cache entry expires
+	if revoked, _ := chatInstance.IsRevoked(ctx, old); revoked {
+		t.Fatalf("expected cached result before TTL")
+	}
+	now = now.Add(2 * time.Minute)
+	if revoked, _ := chatInstance.IsRevoked(ctx, old); !revoked {
+		t.Fatalf("expected token to be revoked after cache TTL")
+	}
+
+	// Tokens issued after the watermark, and other users' tokens, stay valid
+	if revoked, _ := chatInstance.IsRevoked(ctx, claimsAt("u1", "s2", now)); revoked {
+		t.Fatalf("expected token issued after logout to be valid")
+	}
+	if revoked, _ := chatInstance.IsRevoked(ctx, claimsAt("u2", "s3", issued)); revoked {
+		t.Fatalf("expected other user's token to be valid")
+	}
+}
+
+func TestList_RevokeSession(t *testing.T) {
+	ctx := context.Background()
+	l := NewList(store.NewMemoryRevocationStore(), time.Minute, 15*time.Minute)
+	issued := time.Now().Add(-time.Minute)
+
+	if err := l.RevokeSession(ctx, "s1"); err != nil {
+		t.Fatalf("RevokeSession error: %v", err)
+	}
+	if revoked, _ := l.IsRevoked(ctx, claimsAt("u1", "s1", issued)); !revoked {
+		t.Fatalf("expected revoked session's token to be rejected")
+	}
+	if revoked, _ := l.IsRevoked(ctx, claimsAt("u1", "s2", issued)); revoked {
+		t.Fatalf("expected other session of the same user to stay valid")
+	}
+}
+
+func TestList_TokensIssuedLaterInTheSameSecondStayValid(t *testing.T) {
+	ctx := context.Background()
+	l := NewList(store.NewMemoryRevocationStore(), time.Minute, 15*time.Minute)
+	revokedAt := time.Date(2026, 3, 1, 12, 0, 0, int(500*time.Millisecond), time.UTC)
+	l.now = func() time.Time { return revokedAt }
+
+	if err := l.RevokeUser(ctx, "u1"); err != nil {
+		t.Fatalf("RevokeUser error: %v", err)
+	}
+	if revoked, _ := l.IsRevoked(ctx, claimsAt("u1", "s1", revokedAt.Add(-100*time.Millisecond))); !revoked {
+		t.Fatalf("expected token issued earlier in the same second to be revoked")
+	}
+	if revoked, _ := l.IsRevoked(ctx, claimsAt("u1", "s2", revokedAt.Add(100*time.Millisecond))); revoked {
+		t.Fatalf("expected token issued later in the same second (e.g. a new login) to be valid")
+	}
+}
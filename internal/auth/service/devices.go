@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/dykethecreator/GoApp/pkg/jwt"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrDeviceNotFound is returned when a device session does not exist, is revoked or belongs to another user.
+	ErrDeviceNotFound = errors.New("device session not found")
+	// ErrInvalidDeviceInfo is returned when UpdateDevice receives values that do not fit the user_devices schema.
+	ErrInvalidDeviceInfo = errors.New("invalid device info")
+)
+
+// Limits from the user_devices schema.
+const (
+	maxDeviceNameLength = 100
+	maxPushTokenLength  = 4096
+)
+
+var validDeviceTypes = map[string]bool{"mobile": true, "web": true, "desktop": true}
+
+// AuthenticateAccessToken validates an access token and returns its claims.
+// It returns jwt.ErrInvalidToken for invalid, expired, revoked or non-access tokens;
+// other errors mean the revocation list could not be checked.
+func (s *AuthService) AuthenticateAccessToken(ctx context.Context, accessToken string) (*jwt.CustomClaims, error) {
+	claims, err := s.tokenManager.ValidateToken(accessToken)
+	if err != nil {
+		return nil, jwt.ErrInvalidToken
+	}
+	if claims.Type != jwt.TokenTypeAccess {
+		return nil, jwt.ErrInvalidToken
+	}
+	if s.revocations != nil {
+		revoked, err := s.revocations.IsRevoked(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, jwt.ErrInvalidToken
+		}
+	}
+	return claims, nil
+}
+
+// ListDevices returns the user's active device sessions, one per token family.
+func (s *AuthService) ListDevices(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+	if s.deviceRepo == nil {
+		return nil, errors.New("device repository not configured")
+	}
+	return s.deviceRepo.ListActiveForUser(ctx, userID)
+}
+
+// RevokeDevice revokes the user's device session identified by deviceID (its token family ID).
+func (s *AuthService) RevokeDevice(ctx context.Context, userID, deviceID string) error {
+	dev, err := s.findDevice(ctx, userID, deviceID)
+	if err != nil {
+		return err
+	}
+	if err := s.deviceRepo.RevokeFamily(ctx, dev.FamilyID.String()); err != nil {
+		return err
+	}
+	return s.revokeSessionAccessTokens(ctx, dev.FamilyID.String())
+}
+
+// UpdateDevice changes the name and/or type of the user's device session.
+// Nil values are left unchanged. Push tokens are set with RegisterPushToken.
+func (s *AuthService) UpdateDevice(ctx context.Context, userID, deviceID string, name, deviceType *string) (*domain.UserDevice, error) {
+	dev, err := s.findDevice(ctx, userID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != nil {
+		if *name == "" || utf8.RuneCountInString(*name) > maxDeviceNameLength {
+			return nil, fmt.Errorf("%w: device_name must be 1-%d characters", ErrInvalidDeviceInfo, maxDeviceNameLength)
+		}
+		dev.DeviceName = *name
+	}
+	if deviceType != nil {
+		if !validDeviceTypes[*deviceType] {
+			return nil, fmt.Errorf("%w: device_type must be one of mobile, web, desktop", ErrInvalidDeviceInfo)
+		}
+		dev.DeviceType = *deviceType
+	}
+
+	if err := s.deviceRepo.UpdateDeviceInfo(ctx, dev); err != nil {
+		return nil, err
+	}
+	return dev, nil
+}
+
+// RegisterPushToken sets the push token the user's device session receives
+// notifications with. An empty token unregisters the session. The token is
+// removed from every other session, so a reinstalled app or a phone passed
+// on to another account does not receive someone else's notifications.
+func (s *AuthService) RegisterPushToken(ctx context.Context, userID, deviceID, provider, token string) (*domain.UserDevice, error) {
+	dev, err := s.findDevice(ctx, userID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		provider = ""
+	} else {
+		if provider != domain.PushProviderFCM && provider != domain.PushProviderAPNs {
+			return nil, fmt.Errorf("%w: provider must be %s or %s", ErrInvalidDeviceInfo, domain.PushProviderFCM, domain.PushProviderAPNs)
+		}
+		if len(token) > maxPushTokenLength {
+			return nil, fmt.Errorf("%w: token is too long", ErrInvalidDeviceInfo)
+		}
+		if err := s.deviceRepo.ClearPushToken(ctx, token, dev.FamilyID.String()); err != nil {
+			return nil, err
+		}
+	}
+	dev.PushNotificationToken = token
+	dev.PushProvider = provider
+	if err := s.deviceRepo.UpdateDeviceInfo(ctx, dev); err != nil {
+		return nil, err
+	}
+	return dev, nil
+}
+
+// findDevice returns the active session of the user's token family deviceID.
+func (s *AuthService) findDevice(ctx context.Context, userID, deviceID string) (*domain.UserDevice, error) {
+	if s.deviceRepo == nil {
+		return nil, errors.New("device repository not configured")
+	}
+	if _, err := uuid.Parse(deviceID); err != nil {
+		return nil, ErrDeviceNotFound
+	}
+	dev, err := s.deviceRepo.FindActiveByFamily(ctx, userID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if dev == nil {
+		return nil, ErrDeviceNotFound
+	}
+	return dev, nil
+}
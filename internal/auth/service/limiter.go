@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+)
+
+// LimitPolicy describes how many attempts are allowed per window and how long
+// a key is locked once the limit is hit. Each consecutive lockout doubles the
+// lock duration, up to MaxLockout.
+type LimitPolicy struct {
+	Action      string
+	MaxAttempts int
+	Window      time.Duration
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+}
+
+var (
+	// The SendOTP policies count every call, per phone number and per peer address.
+	sendOTPPhonePolicy = LimitPolicy{Action: "otp_send", MaxAttempts: 5, Window: time.Hour, BaseLockout: 15 * time.Minute, MaxLockout: 24 * time.Hour}
+	sendOTPPeerPolicy  = LimitPolicy{Action: "otp_send", MaxAttempts: 20, Window: time.Hour, BaseLockout: 15 * time.Minute, MaxLockout: 24 * time.Hour}
+	// The VerifyOTP policies count failed checks only.
+	verifyOTPPhonePolicy = LimitPolicy{Action: "otp_verify", MaxAttempts: 5, Window: 15 * time.Minute, BaseLockout: 5 * time.Minute, MaxLockout: 24 * time.Hour}
+	verifyOTPPeerPolicy  = LimitPolicy{Action: "otp_verify", MaxAttempts: 20, Window: 15 * time.Minute, BaseLockout: 5 * time.Minute, MaxLockout: 24 * time.Hour}
+	// The PIN policies count wrong PINs and wrong emailed reset codes, per user and per peer.
+	verifyPINUserPolicy = LimitPolicy{Action: "pin_verify", MaxAttempts: 5, Window: time.Hour, BaseLockout: 15 * time.Minute, MaxLockout: 24 * time.Hour}
+	verifyPINPeerPolicy = LimitPolicy{Action: "pin_verify", MaxAttempts: 20, Window: time.Hour, BaseLockout: 15 * time.Minute, MaxLockout: 24 * time.Hour}
+	// Every reset email sent counts, per user.
+	pinResetEmailPolicy = LimitPolicy{Action: "pin_reset_email", MaxAttempts: 3, Window: time.Hour, BaseLockout: time.Hour, MaxLockout: 24 * time.Hour}
+	// Every companion device link started counts, per peer; QR codes are refreshed about once a minute.
+	deviceLinkPeerPolicy = LimitPolicy{Action: "device_link", MaxAttempts: 60, Window: time.Hour, BaseLockout: 15 * time.Minute, MaxLockout: 24 * time.Hour}
+)
+
+// RateLimitError is returned when an action is locked out. RetryAfter tells
+// the client how long to wait before trying again.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("too many attempts, retry after %s", e.RetryAfter.Round(time.Second))
+}
+
+// limitKey pairs a policy with the subject (phone number or peer address) it applies to.
+type limitKey struct {
+	policy  LimitPolicy
+	subject string
+	kind    string // "phone", "peer" or "user"
+}
+
+func (k limitKey) String() string {
+	return k.policy.Action + ":" + k.kind + ":" + k.subject
+}
+
+func phoneKey(p LimitPolicy, phoneNumber string) limitKey {
+	return limitKey{policy: p, subject: phoneNumber, kind: "phone"}
+}
+
+func peerKey(p LimitPolicy, peerAddr string) limitKey {
+	return limitKey{policy: p, subject: peerAddr, kind: "peer"}
+}
+
+func userKey(p LimitPolicy, userID string) limitKey {
+	return limitKey{policy: p, subject: userID, kind: "user"}
+}
+
+// AttemptLimiter enforces LimitPolicy counters stored in an AttemptRepository.
+type AttemptLimiter struct {
+	repo repository.AttemptRepository
+	now  func() time.Time
+}
+
+func NewAttemptLimiter(repo repository.AttemptRepository) *AttemptLimiter {
+	return &AttemptLimiter{repo: repo, now: time.Now}
+}
+
+// Take counts one attempt against each key and returns a *RateLimitError if
+// any key is locked or this attempt is over its limit, locking the key. The
+// count and the check are one repository call, so parallel attempts cannot
+// get past the limit. Keys with an empty subject are ignored.
+func (l *AttemptLimiter) Take(ctx context.Context, keys ...limitKey) error {
+	now := l.now()
+	var retryAfter time.Duration
+	var taken []limitKey
+	for _, k := range keys {
+		if k.subject == "" {
+			continue
+		}
+		a, err := l.repo.IncrementAttempt(ctx, k.String(), now, k.policy.Window)
+		if err != nil {
+			return err
+		}
+		if a.LockedUntil != nil && a.LockedUntil.After(now) {
+			if wait := a.LockedUntil.Sub(now); wait > retryAfter {
+				retryAfter = wait
+			}
+			continue
+		}
+		if a.Count <= k.policy.MaxAttempts {
+			taken = append(taken, k)
+			continue
+		}
+
+		// Forget earlier lockouts once the key has behaved for a full MaxLockout period.
+		lockouts := a.Lockouts
+		if a.LockedUntil != nil && now.Sub(*a.LockedUntil) > k.policy.MaxLockout {
+			lockouts = 0
+		}
+		lock := lockoutDuration(k.policy, lockouts)
+		until := now.Add(lock)
+		if err := l.repo.LockAttempt(ctx, k.String(), until, lockouts+1); err != nil {
+			return err
+		}
+		log.Printf("Rate limit: %s locked until %s (lockout #%d)", k, until.Format(time.RFC3339), lockouts+1)
+		if lock > retryAfter {
+			retryAfter = lock
+		}
+	}
+	if retryAfter > 0 {
+		// A rejected attempt does not count against the keys that allowed it
+		if err := l.Refund(ctx, taken...); err != nil {
+			log.Printf("Warning: failed to refund rejected attempt: %v", err)
+		}
+		return &RateLimitError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// Refund gives back an attempt taken for the keys, for callers that count
+// failures only and learn after Take that the attempt succeeded.
+func (l *AttemptLimiter) Refund(ctx context.Context, keys ...limitKey) error {
+	for _, k := range keys {
+		if k.subject == "" {
+			continue
+		}
+		if err := l.repo.RefundAttempt(ctx, k.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset clears counters and lockouts for the keys.
+func (l *AttemptLimiter) Reset(ctx context.Context, keys ...limitKey) error {
+	for _, k := range keys {
+		if k.subject == "" {
+			continue
+		}
+		if err := l.repo.ResetAttempt(ctx, k.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockoutDuration returns BaseLockout * 2^lockouts, capped at MaxLockout.
+func lockoutDuration(p LimitPolicy, lockouts int) time.Duration {
+	d := p.BaseLockout
+	for i := 0; i < lockouts && d < p.MaxLockout; i++ {
+		d *= 2
+	}
+	if d > p.MaxLockout {
+		d = p.MaxLockout
+	}
+	return d
+}
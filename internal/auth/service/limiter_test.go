@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/store"
+)
+
+func TestAttemptLimiter_ExponentialLockout(t *testing.T) {
+	l := NewAttemptLimiter(store.NewMemoryAttemptStore())
+	now := time.Now()
+	l.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	policy := LimitPolicy{Action: "test", MaxAttempts: 3, Window: time.Hour, BaseLockout: time.Minute, MaxLockout: 10 * time.Minute}
+	key := phoneKey(policy, "+905550000000")
+
+	for i := 0; i < 3; i++ {
+		if err := l.Take(ctx, key); err != nil {
+			t.Fatalf("attempt %d: unexpected Take error: %v", i, err)
+		}
+	}
+
+	var rlErr *RateLimitError
+	if err := l.Take(ctx, key); !errors.As(err, &rlErr) || rlErr.RetryAfter != time.Minute {
+		t.Fatalf("expected 1m lockout, got %v", err)
+	}
+
+	// After the first lockout expires, going over the limit again doubles the lock.
+	now = now.Add(2 * time.Minute)
+	for i := 0; i < 3; i++ {
+		if err := l.Take(ctx, key); err != nil {
+			t.Fatalf("attempt %d after lockout: unexpected Take error: %v", i, err)
+		}
+	}
+	if err := l.Take(ctx, key); !errors.As(err, &rlErr) || rlErr.RetryAfter != 2*time.Minute {
+		t.Fatalf("expected 2m lockout, got %v", err)
+	}
+
+	if err := l.Reset(ctx, key); err != nil {
+		t.Fatalf("Reset error: %v", err)
+	}
+	if err := l.Take(ctx, key); err != nil {
+		t.Fatalf("expected reset key to be allowed, got %v", err)
+	}
+}
+
+func TestAttemptLimiter_ParallelAttemptsStayWithinLimit(t *testing.T) {
+	l := NewAttemptLimiter(store.NewMemoryAttemptStore())
+	ctx := context.Background()
+	policy := LimitPolicy{Action: "test", MaxAttempts: 5, Window: time.Hour, BaseLockout: time.Minute, MaxLockout: 10 * time.Minute}
+	key := phoneKey(policy, "+905550000000")
+
+	var wg sync.WaitGroup
+	var allowed atomic.Int32
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if l.Take(ctx, key) == nil {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := allowed.Load(); n != int32(policy.MaxAttempts) {
+		t.Fatalf("%d parallel attempts allowed, want %d", n, policy.MaxAttempts)
+	}
+
+	// A refunded attempt, e.g. a correct code, does not count
+	other := peerKey(policy, "10.0.0.1")
+	for i := 0; i < 10; i++ {
+		if err := l.Take(ctx, other); err != nil {
+			t.Fatalf("attempt %d: unexpected Take error: %v", i, err)
+		}
+		if err := l.Refund(ctx, other); err != nil {
+			t.Fatalf("Refund error: %v", err)
+		}
+	}
+}
+
+func TestVerifyOTP_LocksOutAfterFailures(t *testing.T) {
+	s := &AuthService{
+		otpProvider: otpStub{},
+		limiter:     NewAttemptLimiter(store.NewMemoryAttemptStore()),
+		userRepo:    newFakeUserRepo(),
+	}
+	ctx := context.Background()
+
+	for i := 0; i < verifyOTPPhonePolicy.MaxAttempts; i++ {
+		if _, _, _, err := s.VerifyOTP(ctx, "+905550000000", "000000", "", "10.0.0.1"); err == nil {
+			t.Fatalf("expected wrong code to fail")
+		}
+	}
+
+	var rlErr *RateLimitError
+	if _, _, _, err := s.VerifyOTP(ctx, "+905550000000", "000000", "", "10.0.0.1"); !errors.As(err, &rlErr) {
+		t.Fatalf("expected RateLimitError after %d failures, got %v", verifyOTPPhonePolicy.MaxAttempts, err)
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+var (
+	ErrDeviceLinkNotConfigured = errors.New("device linking is not configured")
+	ErrInvalidDeviceLink       = errors.New("device link is invalid or expired")
+	// ErrDeviceLinkPending is returned by CompleteDeviceLink until a logged-in
+	// device approves the link.
+	ErrDeviceLinkPending = errors.New("device link is waiting for approval")
+	// ErrLinkApproverNotMobile is returned by ApproveDeviceLink when the caller's
+	// session is not a phone; a companion device cannot link further devices.
+	ErrLinkApproverNotMobile = errors.New("device links must be approved from the phone")
+)
+
+const (
+	// DeviceLinkTTL is how long a QR code can be approved and its tokens
+	// collected; clients show a new code once it expires.
+	DeviceLinkTTL = 2 * time.Minute
+	// DeviceLinkQRPrefix starts the QR payload; the pairing token follows.
+	DeviceLinkQRPrefix = "goapp-link:"
+)
+
+// DeviceLinkRequest is what a new companion device gets when it starts
+// linking: the pairing token to show as a QR code, and the secret it keeps to
+// collect its tokens after approval.
+type DeviceLinkRequest struct {
+	PairingToken string
+	DeviceSecret string
+	QRPayload    string
+	ExpiresAt    time.Time
+}
+
+// StartDeviceLink registers a companion device (web or desktop) waiting to be
+// approved. Calls are rate limited per peer.
+func (s *AuthService) StartDeviceLink(ctx context.Context, deviceName, deviceType, peerAddr string) (*DeviceLinkRequest, error) {
+	if s.links == nil {
+		return nil, ErrDeviceLinkNotConfigured
+	}
+	if deviceName == "" || utf8.RuneCountInString(deviceName) > maxDeviceNameLength {
+		return nil, fmt.Errorf("%w: device_name must be 1-%d characters", ErrInvalidDeviceInfo, maxDeviceNameLength)
+	}
+	if deviceType != "web" && deviceType != "desktop" {
+		return nil, fmt.Errorf("%w: device_type must be web or desktop", ErrInvalidDeviceInfo)
+	}
+	if s.limiter != nil {
+		key := peerKey(deviceLinkPeerPolicy, peerAddr)
+		if err := s.limiter.Take(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	l := &domain.DeviceLink{
+		TokenHash:  hashRefreshToken(token),
+		SecretHash: hashRefreshToken(secret),
+		DeviceName: deviceName,
+		DeviceType: deviceType,
+		ExpiresAt:  time.Now().Add(DeviceLinkTTL),
+	}
+	if err := s.links.CreateLink(ctx, l); err != nil {
+		return nil, err
+	}
+	return &DeviceLinkRequest{
+		PairingToken: token,
+		DeviceSecret: secret,
+		QRPayload:    DeviceLinkQRPrefix + token,
+		ExpiresAt:    l.ExpiresAt,
+	}, nil
+}
+
+// ApproveDeviceLink links the companion device showing pairingToken (or the
+// whole QR payload) to the caller's account. sessionID is the caller's device
+// session ('sid' claim), which must be an active mobile session.
+func (s *AuthService) ApproveDeviceLink(ctx context.Context, userID, sessionID, pairingToken string) (*domain.DeviceLink, error) {
+	if s.links == nil {
+		return nil, ErrDeviceLinkNotConfigured
+	}
+	token := strings.TrimPrefix(strings.TrimSpace(pairingToken), DeviceLinkQRPrefix)
+	if token == "" {
+		return nil, ErrInvalidDeviceLink
+	}
+	if sessionID == "" {
+		return nil, ErrLinkApproverNotMobile
+	}
+	approver, err := s.deviceRepo.FindActiveByFamily(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if approver == nil || approver.DeviceType != "mobile" {
+		return nil, ErrLinkApproverNotMobile
+	}
+	l, err := s.links.ApproveLink(ctx, hashRefreshToken(token), userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if l == nil {
+		return nil, ErrInvalidDeviceLink
+	}
+	log.Printf("Security: user %s approved linking %s device %q", userID, l.DeviceType, l.DeviceName)
+	if s.eventRepo != nil {
+		event := &domain.SecurityEvent{UserID: *l.UserID, EventType: domain.DeviceLinkedEvent, Details: fmt.Sprintf("linked %s device %q", l.DeviceType, l.DeviceName)}
+		if err := s.eventRepo.RecordEvent(ctx, event); err != nil {
+			log.Printf("Warning: failed to record security event for user %s: %v", userID, err)
+		}
+	}
+	return l, nil
+}
+
+// CompleteDeviceLink starts the companion device's session once its link is
+// approved. It returns ErrDeviceLinkPending until then; clients poll it while
+// showing the QR code.
+func (s *AuthService) CompleteDeviceLink(ctx context.Context, pairingToken, deviceSecret string) (*domain.User, string, string, error) {
+	if s.links == nil {
+		return nil, "", "", ErrDeviceLinkNotConfigured
+	}
+	if pairingToken == "" || deviceSecret == "" {
+		return nil, "", "", ErrInvalidDeviceLink
+	}
+	tokenHash, secretHash := hashRefreshToken(pairingToken), hashRefreshToken(deviceSecret)
+	now := time.Now()
+	l, err := s.links.ClaimApprovedLink(ctx, tokenHash, secretHash, now)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if l == nil {
+		pending, err := s.links.GetLink(ctx, tokenHash, secretHash, now)
+		if err != nil {
+			return nil, "", "", err
+		}
+		if pending != nil {
+			return nil, "", "", ErrDeviceLinkPending
+		}
+		return nil, "", "", ErrInvalidDeviceLink
+	}
+
+	user, err := s.userRepo.FindByID(ctx, l.UserID.String())
+	if err != nil {
+		return nil, "", "", err
+	}
+	if user == nil {
+		return nil, "", "", ErrInvalidDeviceLink
+	}
+	access, refresh, err := s.startSession(ctx, user, l.DeviceName, l.DeviceType)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return user, access, refresh, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dykethecreator/GoApp/internal/auth/store"
+)
+
+func TestDeviceLink_ApproveThenComplete(t *testing.T) {
+	s, provider, _, _ := newPINTestService(t)
+	s.links = store.NewMemoryDeviceLinkStore()
+	ctx := context.Background()
+
+	user, err := login(t, s, provider, "+905551112266")
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if _, err := s.StartDeviceLink(ctx, "Chrome", "mobile", ""); !errors.Is(err, ErrInvalidDeviceInfo) {
+		t.Fatalf("expected ErrInvalidDeviceInfo for a mobile companion, got %v", err)
+	}
+	link, err := s.StartDeviceLink(ctx, "Chrome on macOS", "web", "")
+	if err != nil {
+		t.Fatalf("StartDeviceLink error: %v", err)
+	}
+
+	if _, _, _, err := s.CompleteDeviceLink(ctx, link.PairingToken, link.DeviceSecret); !errors.Is(err, ErrDeviceLinkPending) {
+		t.Fatalf("expected ErrDeviceLinkPending before approval, got %v", err)
+	}
+	phone := sessionOfType(t, s, user.ID.String(), "mobile")
+	if _, err := s.ApproveDeviceLink(ctx, user.ID.String(), "", link.QRPayload); !errors.Is(err, ErrLinkApproverNotMobile) {
+		t.Fatalf("expected ErrLinkApproverNotMobile without a session, got %v", err)
+	}
+	if _, err := s.ApproveDeviceLink(ctx, user.ID.String(), phone, link.QRPayload); err != nil {
+		t.Fatalf("ApproveDeviceLink error: %v", err)
+	}
+	if _, err := s.ApproveDeviceLink(ctx, user.ID.String(), phone, link.PairingToken); !errors.Is(err, ErrInvalidDeviceLink) {
+		t.Fatalf("expected a second approval to fail, got %v", err)
+	}
+	if _, _, _, err := s.CompleteDeviceLink(ctx, link.PairingToken, "wrong-secret"); !errors.Is(err, ErrInvalidDeviceLink) {
+		t.Fatalf("expected ErrInvalidDeviceLink for a wrong secret, got %v", err)
+	}
+
+	got, access, refresh, err := s.CompleteDeviceLink(ctx, link.PairingToken, link.DeviceSecret)
+	if err != nil {
+		t.Fatalf("CompleteDeviceLink error: %v", err)
+	}
+	if got.ID != user.ID || access == "" || refresh == "" {
+		t.Fatalf("expected tokens for %s, got user=%+v", user.ID, got)
+	}
+	devices, err := s.ListDevices(ctx, user.ID.String())
+	if err != nil {
+		t.Fatalf("ListDevices error: %v", err)
+	}
+	var web int
+	for _, d := range devices {
+		if d.DeviceType == "web" && d.DeviceName == "Chrome on macOS" {
+			web++
+		}
+	}
+	if len(devices) != 2 || web != 1 {
+		t.Fatalf("expected the phone and one web session, got %+v", devices)
+	}
+	if _, _, _, err := s.CompleteDeviceLink(ctx, link.PairingToken, link.DeviceSecret); !errors.Is(err, ErrInvalidDeviceLink) {
+		t.Fatalf("expected the link to be usable once, got %v", err)
+	}
+
+	// A linked companion cannot approve further links itself
+	next, err := s.StartDeviceLink(ctx, "Firefox", "web", "")
+	if err != nil {
+		t.Fatalf("StartDeviceLink error: %v", err)
+	}
+	companion := sessionOfType(t, s, user.ID.String(), "web")
+	if _, err := s.ApproveDeviceLink(ctx, user.ID.String(), companion, next.PairingToken); !errors.Is(err, ErrLinkApproverNotMobile) {
+		t.Fatalf("expected ErrLinkApproverNotMobile from a web session, got %v", err)
+	}
+}
+
+// sessionOfType returns the session ID of the user's active device of the given type.
+func sessionOfType(t *testing.T, s *AuthService, userID, deviceType string) string {
+	t.Helper()
+	devices, err := s.ListDevices(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("ListDevices error: %v", err)
+	}
+	for _, d := range devices {
+		if d.DeviceType == deviceType {
+			return d.FamilyID.String()
+		}
+	}
+	t.Fatalf("no %s session among %+v", deviceType, devices)
+	return ""
+}
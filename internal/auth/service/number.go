@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrSamePhoneNumber  = errors.New("new phone number is the current one")
+	ErrInvalidPhone     = errors.New("new phone number is required")
+	ErrPhoneNumberTaken = repository.ErrPhoneNumberTaken
+)
+
+// NumberChangeNotifier tells the participants of a user's conversations that
+// the user changed their phone number.
+type NumberChangeNotifier interface {
+	NotifyNumberChanged(ctx context.Context, userID string) error
+}
+
+// SetNumberChangeNotifier enables ChangeNumber's notify option; without a
+// notifier the option is ignored.
+func (s *AuthService) SetNumberChangeNotifier(n NumberChangeNotifier) {
+	s.numberChanges = n
+}
+
+// RequestNumberChange sends codes to the user's current number and to
+// newPhone. Both sends are rate limited like SendOTP.
+func (s *AuthService) RequestNumberChange(ctx context.Context, userID, newPhone, peerAddr string) error {
+	user, err := s.numberChangeUser(ctx, userID, newPhone)
+	if err != nil {
+		return err
+	}
+	if _, err := s.SendOTP(ctx, user.PhoneNumber, peerAddr); err != nil {
+		return err
+	}
+	_, err = s.SendOTP(ctx, newPhone, peerAddr)
+	return err
+}
+
+// ChangeNumber moves the user's account to newPhone once the codes sent by
+// RequestNumberChange to both numbers are verified. Sessions, chats and
+// contacts stay with the account; other users' address book entries follow
+// the number. With notify set, a system message is posted in the user's
+// conversations.
+func (s *AuthService) ChangeNumber(ctx context.Context, userID, newPhone, oldCode, newCode string, notify bool, peerAddr string) (*domain.User, error) {
+	user, err := s.numberChangeUser(ctx, userID, newPhone)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOTP(ctx, user.PhoneNumber, oldCode, peerAddr); err != nil {
+		return nil, err
+	}
+	if err := s.checkOTP(ctx, newPhone, newCode, peerAddr); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.userRepo.ChangePhoneNumber(ctx, userID, user.PhoneNumber, newPhone)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Security: user %s changed phone number from %s to %s", userID, user.PhoneNumber, newPhone)
+	if s.eventRepo != nil {
+		event := &domain.SecurityEvent{UserID: user.ID, EventType: domain.PhoneNumberChangedEvent, Details: "phone number changed from " + user.PhoneNumber}
+		if err := s.eventRepo.RecordEvent(ctx, event); err != nil {
+			log.Printf("Warning: failed to record security event for user %s: %v", userID, err)
+		}
+	}
+	if notify && s.numberChanges != nil {
+		// The number has moved; a failed notification must not fail the change
+		if err := s.numberChanges.NotifyNumberChanged(ctx, userID); err != nil {
+			log.Printf("Warning: failed to notify conversations of user %s about the number change: %v", userID, err)
+		}
+	}
+	return updated, nil
+}
+
+// numberChangeUser loads the user and checks that newPhone can be moved to.
+func (s *AuthService) numberChangeUser(ctx context.Context, userID, newPhone string) (*domain.User, error) {
+	if strings.TrimSpace(newPhone) == "" {
+		return nil, ErrInvalidPhone
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if user.PhoneNumber == newPhone {
+		return nil, ErrSamePhoneNumber
+	}
+	existing, err := s.userRepo.FindByPhoneNumber(ctx, newPhone)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrPhoneNumberTaken
+	}
+	return user, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dykethecreator/GoApp/internal/auth/otp"
+)
+
+type notifierStub struct{ notified []string }
+
+func (n *notifierStub) NotifyNumberChanged(ctx context.Context, userID string) error {
+	n.notified = append(n.notified, userID)
+	return nil
+}
+
+func TestChangeNumber_RequiresBothCodes(t *testing.T) {
+	s, provider, _, _ := newPINTestService(t)
+	notifier := &notifierStub{}
+	s.SetNumberChangeNotifier(notifier)
+	ctx := context.Background()
+	oldPhone, newPhone, otherPhone := "+905551110001", "+905551110002", "+905551110003"
+
+	user, err := login(t, s, provider, oldPhone)
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if _, err := login(t, s, provider, otherPhone); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	userID := user.ID.String()
+
+	if err := s.RequestNumberChange(ctx, userID, oldPhone, ""); !errors.Is(err, ErrSamePhoneNumber) {
+		t.Fatalf("expected ErrSamePhoneNumber, got %v", err)
+	}
+	if err := s.RequestNumberChange(ctx, userID, otherPhone, ""); !errors.Is(err, ErrPhoneNumberTaken) {
+		t.Fatalf("expected ErrPhoneNumberTaken, got %v", err)
+	}
+	if err := s.RequestNumberChange(ctx, userID, newPhone, ""); err != nil {
+		t.Fatalf("RequestNumberChange error: %v", err)
+	}
+	oldMsg, ok := provider.Inbox().Last(oldPhone)
+	if !ok {
+		t.Fatalf("expected code sent to the current number")
+	}
+	newMsg, ok := provider.Inbox().Last(newPhone)
+	if !ok {
+		t.Fatalf("expected code sent to the new number")
+	}
+
+	if _, err := s.ChangeNumber(ctx, userID, newPhone, oldMsg.Code, "000000", true, ""); !errors.Is(err, otp.ErrInvalidCode) {
+		t.Fatalf("expected ErrInvalidCode for a wrong new-number code, got %v", err)
+	}
+	if err := s.RequestNumberChange(ctx, userID, newPhone, ""); err != nil {
+		t.Fatalf("RequestNumberChange error: %v", err)
+	}
+	oldMsg, _ = provider.Inbox().Last(oldPhone)
+	newMsg, _ = provider.Inbox().Last(newPhone)
+	updated, err := s.ChangeNumber(ctx, userID, newPhone, oldMsg.Code, newMsg.Code, true, "")
+	if err != nil {
+		t.Fatalf("ChangeNumber error: %v", err)
+	}
+	if updated.ID != user.ID || updated.PhoneNumber != newPhone {
+		t.Fatalf("expected account %s moved to %s, got %+v", user.ID, newPhone, updated)
+	}
+	if len(notifier.notified) != 1 || notifier.notified[0] != userID {
+		t.Fatalf("expected conversations notified once, got %v", notifier.notified)
+	}
+
+	// Logging in with the new number reaches the same account
+	again, err := login(t, s, provider, newPhone)
+	if err != nil || again.ID != user.ID {
+		t.Fatalf("expected login with new number to find %s, got %+v (err %v)", user.ID, again, err)
+	}
+}
@@ -0,0 +1,423 @@
+package service
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+var (
+	ErrTwoStepNotConfigured = errors.New("two-step verification is not configured")
+	ErrInvalidPINFormat     = errors.New("PIN must be 6 digits")
+	ErrInvalidRecoveryEmail = errors.New("invalid recovery email")
+	ErrInvalidPIN           = errors.New("incorrect PIN")
+	ErrInvalidPINChallenge  = errors.New("PIN challenge is invalid or expired")
+	ErrInvalidResetCode     = errors.New("PIN reset code is incorrect or expired")
+)
+
+const (
+	// PINChallengeTTL is how long a login may wait for the PIN after the SMS code.
+	PINChallengeTTL = 10 * time.Minute
+	// PINResetCooldown is how long a user without access to their recovery
+	// email waits before a reset turns the PIN off.
+	PINResetCooldown = 7 * 24 * time.Hour
+	// PINResetCodeTTL is how long an emailed reset code is valid.
+	PINResetCodeTTL = 15 * time.Minute
+)
+
+// pinHashIterations is the PBKDF2 work factor for new PIN hashes. Existing
+// hashes keep the count they were created with.
+var pinHashIterations = 600000
+
+var pinPattern = regexp.MustCompile(`^[0-9]{6}$`)
+
+// PINRequiredError is returned by VerifyOTP when the user has two-step
+// verification on. The client finishes the login with VerifyPIN (or
+// RequestPINReset/ResetPIN) using ChallengeToken.
+type PINRequiredError struct {
+	ChallengeToken string
+	ExpiresAt      time.Time
+}
+
+func (e *PINRequiredError) Error() string { return "two-step verification PIN required" }
+
+// PINResetPendingError is returned by ResetPIN without a code while the reset
+// cooldown is still running.
+type PINResetPendingError struct {
+	AvailableAt time.Time
+}
+
+func (e *PINResetPendingError) Error() string {
+	return fmt.Sprintf("PIN reset available at %s", e.AvailableAt.Format(time.RFC3339))
+}
+
+// PINReset describes a started reset: whether a code was emailed, and when the
+// cooldown reset becomes available.
+type PINReset struct {
+	EmailSent   bool
+	MaskedEmail string
+	AvailableAt time.Time
+}
+
+// TwoStepStatus is what the owner sees of their two-step verification settings.
+type TwoStepStatus struct {
+	Enabled       bool
+	RecoveryEmail string
+}
+
+// requirePIN returns a *PINRequiredError with a new challenge when the user
+// has two-step verification on, and nil otherwise.
+func (s *AuthService) requirePIN(ctx context.Context, user *domain.User, deviceID string) error {
+	if s.twoStep == nil {
+		return nil
+	}
+	pin, err := s.twoStep.GetPIN(ctx, user.ID.String())
+	if err != nil || pin == nil {
+		return err
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return err
+	}
+	c := &domain.PINChallenge{
+		TokenHash: hashRefreshToken(token),
+		UserID:    user.ID,
+		DeviceID:  deviceID,
+		ExpiresAt: time.Now().Add(PINChallengeTTL),
+	}
+	if err := s.twoStep.CreateChallenge(ctx, c); err != nil {
+		return err
+	}
+	log.Printf("Two-step verification required for user %s", user.ID)
+	return &PINRequiredError{ChallengeToken: token, ExpiresAt: c.ExpiresAt}
+}
+
+// VerifyPIN finishes a login that VerifyOTP answered with a PIN challenge.
+// Wrong PINs are rate limited per user and per peer; like checkOTP, each
+// check takes an attempt up front and only wrong PINs keep it.
+func (s *AuthService) VerifyPIN(ctx context.Context, challengeToken, pin, peerAddr string) (*domain.User, string, string, error) {
+	c, err := s.pinChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, "", "", err
+	}
+	userID := c.UserID.String()
+
+	var limitKeys []limitKey
+	if s.limiter != nil {
+		limitKeys = []limitKey{userKey(verifyPINUserPolicy, userID), peerKey(verifyPINPeerPolicy, peerAddr)}
+		if err := s.limiter.Take(ctx, limitKeys...); err != nil {
+			return nil, "", "", err
+		}
+	}
+	stored, err := s.twoStep.GetPIN(ctx, userID)
+	if err != nil {
+		s.refundPINAttempt(ctx, limitKeys)
+		return nil, "", "", err
+	}
+	// A PIN turned off since the challenge was issued has nothing left to check
+	if stored != nil {
+		if !checkPIN(stored.PINHash, pin) {
+			return nil, "", "", ErrInvalidPIN
+		}
+		// Whoever remembers the PIN cancels a reset someone else may have started
+		if stored.ResetRequestedAt != nil || stored.ResetCodeHash != nil {
+			if err := s.twoStep.CancelReset(ctx, userID); err != nil {
+				log.Printf("Warning: failed to cancel PIN reset for user %s: %v", userID, err)
+			}
+		}
+	}
+	if s.limiter != nil {
+		if err := s.limiter.Reset(ctx, limitKeys[0]); err != nil {
+			log.Printf("Warning: failed to reset PIN attempts for user %s: %v", userID, err)
+		}
+		s.refundPINAttempt(ctx, limitKeys[1:])
+	}
+	return s.completePINLogin(ctx, c)
+}
+
+// RequestPINReset starts resetting a forgotten PIN during a PIN challenge. A
+// code is emailed when a recovery email is set; independently, the reset
+// cooldown starts, after which ResetPIN without a code turns the PIN off.
+func (s *AuthService) RequestPINReset(ctx context.Context, challengeToken string) (*PINReset, error) {
+	c, err := s.pinChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	userID := c.UserID.String()
+	stored, err := s.twoStep.GetPIN(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return &PINReset{AvailableAt: time.Now()}, nil
+	}
+
+	startedAt, err := s.twoStep.StartReset(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	out := &PINReset{AvailableAt: startedAt.Add(PINResetCooldown)}
+	if stored.RecoveryEmail == nil || s.mailer == nil {
+		return out, nil
+	}
+
+	if s.limiter != nil {
+		key := userKey(pinResetEmailPolicy, userID)
+		if err := s.limiter.Take(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+	code, err := randomDigits(8)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoStep.SetResetCode(ctx, userID, hashRefreshToken(code), time.Now().Add(PINResetCodeTTL)); err != nil {
+		return nil, err
+	}
+	body := fmt.Sprintf("Your two-step verification reset code is %s.\n\nIt expires in %d minutes. If you did not try to log in, someone else knows your phone number's SMS codes; log in and your PIN stays in place.\n",
+		code, int(PINResetCodeTTL.Minutes()))
+	if err := s.mailer.Send(ctx, *stored.RecoveryEmail, "Two-step verification reset", body); err != nil {
+		return nil, fmt.Errorf("send reset email: %w", err)
+	}
+	out.EmailSent = true
+	out.MaskedEmail = maskEmail(*stored.RecoveryEmail)
+	return out, nil
+}
+
+// ResetPIN turns two-step verification off and finishes the login. With a
+// code, the code emailed by RequestPINReset must match; without one, the
+// reset cooldown must have passed (*PINResetPendingError otherwise).
+func (s *AuthService) ResetPIN(ctx context.Context, challengeToken, code, peerAddr string) (*domain.User, string, string, error) {
+	c, err := s.pinChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, "", "", err
+	}
+	userID := c.UserID.String()
+	stored, err := s.twoStep.GetPIN(ctx, userID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if stored == nil {
+		return s.completePINLogin(ctx, c)
+	}
+
+	var how string
+	if code != "" {
+		var limitKeys []limitKey
+		if s.limiter != nil {
+			limitKeys = []limitKey{userKey(verifyPINUserPolicy, userID), peerKey(verifyPINPeerPolicy, peerAddr)}
+			if err := s.limiter.Take(ctx, limitKeys...); err != nil {
+				return nil, "", "", err
+			}
+		}
+		if stored.ResetCodeHash == nil || stored.ResetCodeExpiresAt == nil || !time.Now().Before(*stored.ResetCodeExpiresAt) ||
+			subtle.ConstantTimeCompare([]byte(*stored.ResetCodeHash), []byte(hashRefreshToken(code))) != 1 {
+			return nil, "", "", ErrInvalidResetCode
+		}
+		s.refundPINAttempt(ctx, limitKeys)
+		how = "recovery email"
+	} else {
+		if stored.ResetRequestedAt == nil {
+			return nil, "", "", &PINResetPendingError{AvailableAt: time.Now().Add(PINResetCooldown)}
+		}
+		if availableAt := stored.ResetRequestedAt.Add(PINResetCooldown); time.Now().Before(availableAt) {
+			return nil, "", "", &PINResetPendingError{AvailableAt: availableAt}
+		}
+		how = "cooldown"
+	}
+
+	if err := s.twoStep.DeletePIN(ctx, userID); err != nil {
+		return nil, "", "", err
+	}
+	log.Printf("Security: two-step PIN of user %s reset by %s", userID, how)
+	if s.eventRepo != nil {
+		event := &domain.SecurityEvent{UserID: c.UserID, EventType: domain.PINResetEvent, Details: "two-step PIN turned off at login by " + how}
+		if err := s.eventRepo.RecordEvent(ctx, event); err != nil {
+			log.Printf("Warning: failed to record security event for user %s: %v", userID, err)
+		}
+	}
+	return s.completePINLogin(ctx, c)
+}
+
+// SetPIN turns two-step verification on or changes the PIN. recoveryEmail nil
+// keeps the current email; an empty one removes it.
+func (s *AuthService) SetPIN(ctx context.Context, userID, pin string, recoveryEmail *string) (*TwoStepStatus, error) {
+	if s.twoStep == nil {
+		return nil, ErrTwoStepNotConfigured
+	}
+	if !pinPattern.MatchString(pin) {
+		return nil, ErrInvalidPINFormat
+	}
+	current, err := s.twoStep.GetPIN(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	email := recoveryEmail
+	if email == nil && current != nil {
+		email = current.RecoveryEmail
+	} else if email != nil {
+		trimmed := strings.TrimSpace(*email)
+		if trimmed == "" {
+			email = nil
+		} else if addr, err := mail.ParseAddress(trimmed); err != nil || addr.Address != trimmed || len(trimmed) > 254 {
+			return nil, ErrInvalidRecoveryEmail
+		} else {
+			email = &trimmed
+		}
+	}
+
+	hash, err := hashPIN(pin)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoStep.SetPIN(ctx, userID, hash, email); err != nil {
+		return nil, err
+	}
+	out := &TwoStepStatus{Enabled: true}
+	if email != nil {
+		out.RecoveryEmail = *email
+	}
+	return out, nil
+}
+
+// DisablePIN turns two-step verification off.
+func (s *AuthService) DisablePIN(ctx context.Context, userID string) error {
+	if s.twoStep == nil {
+		return ErrTwoStepNotConfigured
+	}
+	return s.twoStep.DeletePIN(ctx, userID)
+}
+
+// pinChallenge looks up an unexpired challenge by its token.
+func (s *AuthService) pinChallenge(ctx context.Context, token string) (*domain.PINChallenge, error) {
+	if s.twoStep == nil {
+		return nil, ErrTwoStepNotConfigured
+	}
+	if token == "" {
+		return nil, ErrInvalidPINChallenge
+	}
+	c, err := s.twoStep.GetChallenge(ctx, hashRefreshToken(token), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrInvalidPINChallenge
+	}
+	return c, nil
+}
+
+// completePINLogin consumes the challenge and starts the device session it was issued for.
+func (s *AuthService) completePINLogin(ctx context.Context, c *domain.PINChallenge) (*domain.User, string, string, error) {
+	if err := s.twoStep.DeleteChallenge(ctx, c.TokenHash); err != nil {
+		return nil, "", "", err
+	}
+	user, err := s.userRepo.FindByID(ctx, c.UserID.String())
+	if err != nil {
+		return nil, "", "", err
+	}
+	if user == nil {
+		return nil, "", "", ErrInvalidPINChallenge
+	}
+	access, refresh, err := s.startSession(ctx, user, c.DeviceID, "mobile")
+	if err != nil {
+		return nil, "", "", err
+	}
+	return user, access, refresh, nil
+}
+
+// refundPINAttempt gives back the attempt taken for a check that was not a
+// wrong PIN or reset code.
+func (s *AuthService) refundPINAttempt(ctx context.Context, keys []limitKey) {
+	if s.limiter == nil {
+		return
+	}
+	if err := s.limiter.Refund(ctx, keys...); err != nil {
+		log.Printf("Warning: failed to refund PIN attempt: %v", err)
+	}
+}
+
+// hashPIN returns "pbkdf2-sha256$<iterations>$<salt>$<hash>" with a random salt.
+func hashPIN(pin string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := pbkdf2.Key(sha256.New, pin, salt, pinHashIterations, 32)
+	if err != nil {
+		return "", err
+	}
+	enc := base64.RawStdEncoding
+	return fmt.Sprintf("pbkdf2-sha256$%d$%s$%s", pinHashIterations, enc.EncodeToString(salt), enc.EncodeToString(key)), nil
+}
+
+// checkPIN reports whether pin matches a hash produced by hashPIN.
+func checkPIN(encoded, pin string) bool {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 4 || parts[0] != "pbkdf2-sha256" {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+	enc := base64.RawStdEncoding
+	salt, err := enc.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	want, err := enc.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+	got, err := pbkdf2.Key(sha256.New, pin, salt, iterations, len(want))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
+
+// randomToken returns 32 random bytes, hex encoded.
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func randomDigits(n int) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		d, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(byte('0' + d.Int64()))
+	}
+	return sb.String(), nil
+}
+
+// maskEmail shows the first letter of the local part and the domain: "a***@example.com".
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return "***"
+	}
+	return email[:1] + "***" + email[at:]
+}
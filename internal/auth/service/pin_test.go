@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/mail"
+	"github.com/dykethecreator/GoApp/internal/auth/otp"
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/internal/auth/store"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	appjwt "github.com/dykethecreator/GoApp/pkg/jwt"
+)
+
+func newPINTestService(t *testing.T) (*AuthService, *otp.LocalProvider, *mail.LocalSender, repository.TwoStepRepository) {
+	t.Helper()
+	old := pinHashIterations
+	pinHashIterations = 1000
+	t.Cleanup(func() { pinHashIterations = old })
+
+	tm, err := appjwt.NewTokenManager("0123456789abcdefghijklmnopqrstuvwxyz!@#%$^&*()", 15*time.Minute, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("token manager: %v", err)
+	}
+	provider := otp.NewLocalProvider(time.Minute, "")
+	mailer := mail.NewLocalSender()
+	twoStep := store.NewMemoryTwoStepStore()
+	s := &AuthService{
+		otpProvider:  provider,
+		userRepo:     newFakeUserRepo(),
+		deviceRepo:   newFakeDeviceRepo(),
+		eventRepo:    &fakeEventRepo{},
+		tokenManager: tm,
+		twoStep:      twoStep,
+		mailer:       mailer,
+	}
+	return s, provider, mailer, twoStep
+}
+
+// login runs SendOTP and VerifyOTP for the phone.
+func login(t *testing.T, s *AuthService, provider *otp.LocalProvider, phone string) (*domain.User, error) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := s.SendOTP(ctx, phone, ""); err != nil {
+		t.Fatalf("SendOTP error: %v", err)
+	}
+	msg, _ := provider.Inbox().Last(phone)
+	user, _, _, err := s.VerifyOTP(ctx, phone, msg.Code, "device-1", "")
+	return user, err
+}
+
+func TestVerifyPIN_RequiredAfterOTP(t *testing.T) {
+	s, provider, _, _ := newPINTestService(t)
+	ctx := context.Background()
+	phone := "+905551112255"
+
+	user, err := login(t, s, provider, phone)
+	if err != nil {
+		t.Fatalf("first login: %v", err)
+	}
+	if _, err := s.SetPIN(ctx, user.ID.String(), "12ab", nil); !errors.Is(err, ErrInvalidPINFormat) {
+		t.Fatalf("expected ErrInvalidPINFormat, got %v", err)
+	}
+	if _, err := s.SetPIN(ctx, user.ID.String(), "123456", nil); err != nil {
+		t.Fatalf("SetPIN error: %v", err)
+	}
+
+	_, err = login(t, s, provider, phone)
+	var pinErr *PINRequiredError
+	if !errors.As(err, &pinErr) || pinErr.ChallengeToken == "" {
+		t.Fatalf("expected PINRequiredError, got %v", err)
+	}
+	if _, _, _, err := s.VerifyPIN(ctx, pinErr.ChallengeToken, "654321", ""); !errors.Is(err, ErrInvalidPIN) {
+		t.Fatalf("expected ErrInvalidPIN, got %v", err)
+	}
+	got, access, refresh, err := s.VerifyPIN(ctx, pinErr.ChallengeToken, "123456", "")
+	if err != nil {
+		t.Fatalf("VerifyPIN error: %v", err)
+	}
+	if got.ID != user.ID || access == "" || refresh == "" {
+		t.Fatalf("expected tokens for %s, got user=%+v", user.ID, got)
+	}
+	if _, _, _, err := s.VerifyPIN(ctx, pinErr.ChallengeToken, "123456", ""); !errors.Is(err, ErrInvalidPINChallenge) {
+		t.Fatalf("expected used challenge to be rejected, got %v", err)
+	}
+}
+
+func TestResetPIN_EmailAndCooldown(t *testing.T) {
+	s, provider, mailer, twoStep := newPINTestService(t)
+	ctx := context.Background()
+	phone := "+905551112266"
+	email := "owner@example.com"
+
+	user, err := login(t, s, provider, phone)
+	if err != nil {
+		t.Fatalf("first login: %v", err)
+	}
+	if _, err := s.SetPIN(ctx, user.ID.String(), "123456", &email); err != nil {
+		t.Fatalf("SetPIN error: %v", err)
+	}
+
+	// Reset through the recovery email
+	_, err = login(t, s, provider, phone)
+	var pinErr *PINRequiredError
+	if !errors.As(err, &pinErr) {
+		t.Fatalf("expected PINRequiredError, got %v", err)
+	}
+	reset, err := s.RequestPINReset(ctx, pinErr.ChallengeToken)
+	if err != nil || !reset.EmailSent {
+		t.Fatalf("RequestPINReset: reset=%+v err=%v", reset, err)
+	}
+	msg, ok := mailer.Last(email)
+	if !ok {
+		t.Fatalf("expected reset email")
+	}
+	code := regexp.MustCompile(`[0-9]{8}`).FindString(msg.Body)
+	if _, _, _, err := s.ResetPIN(ctx, pinErr.ChallengeToken, "00000000", ""); !errors.Is(err, ErrInvalidResetCode) {
+		t.Fatalf("expected ErrInvalidResetCode, got %v", err)
+	}
+	if _, _, _, err := s.ResetPIN(ctx, pinErr.ChallengeToken, code, ""); err != nil {
+		t.Fatalf("ResetPIN with code: %v", err)
+	}
+	if _, err := login(t, s, provider, phone); err != nil {
+		t.Fatalf("expected login without PIN after reset, got %v", err)
+	}
+
+	// Reset after the cooldown, without email
+	if _, err := s.SetPIN(ctx, user.ID.String(), "123456", nil); err != nil {
+		t.Fatalf("SetPIN error: %v", err)
+	}
+	_, err = login(t, s, provider, phone)
+	if !errors.As(err, &pinErr) {
+		t.Fatalf("expected PINRequiredError, got %v", err)
+	}
+	var pending *PINResetPendingError
+	if _, _, _, err := s.ResetPIN(ctx, pinErr.ChallengeToken, "", ""); !errors.As(err, &pending) {
+		t.Fatalf("expected PINResetPendingError before the cooldown, got %v", err)
+	}
+	if _, err := twoStep.StartReset(ctx, user.ID.String(), time.Now().Add(-PINResetCooldown-time.Minute)); err != nil {
+		t.Fatalf("StartReset: %v", err)
+	}
+	if _, _, _, err := s.ResetPIN(ctx, pinErr.ChallengeToken, "", ""); err != nil {
+		t.Fatalf("ResetPIN after cooldown: %v", err)
+	}
+}
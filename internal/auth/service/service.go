@@ -3,112 +3,95 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
-	"os"
 	"time"
 
 	"crypto/sha256"
 	"encoding/hex"
 
+	"github.com/dykethecreator/GoApp/internal/auth/mail"
+	"github.com/dykethecreator/GoApp/internal/auth/otp"
 	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/internal/auth/revocation"
 	"github.com/dykethecreator/GoApp/pkg/domain"
 	"github.com/dykethecreator/GoApp/pkg/jwt"
-	"github.com/twilio/twilio-go"
-	verify "github.com/twilio/twilio-go/rest/verify/v2"
+	"github.com/google/uuid"
 )
 
+// ErrRefreshTokenReused is returned when a refresh token that was already rotated
+// or revoked is presented again. It wraps jwt.ErrInvalidToken.
+var ErrRefreshTokenReused = fmt.Errorf("refresh token reuse detected: %w", jwt.ErrInvalidToken)
+
 type AuthService struct {
-	twilioClient     *twilio.RestClient
-	verifyServiceSID string
-	userRepo         repository.UserRepository
-	deviceRepo       repository.DeviceRepository
-	tokenManager     *jwt.TokenManager
-	devMode          bool
+	otpProvider  otp.OTPProvider
+	limiter      *AttemptLimiter
+	userRepo     repository.UserRepository
+	deviceRepo   repository.DeviceRepository
+	eventRepo    repository.SecurityEventRepository
+	tokenManager *jwt.TokenManager
+	revocations  *revocation.List                // access-token revocation list; nil disables it
+	twoStep      repository.TwoStepRepository    // two-step verification PINs; nil disables them
+	mailer       mail.Sender                     // recovery emails; nil disables PIN reset by email
+	links        repository.DeviceLinkRepository // companion devices waiting for approval; nil disables linking
+
+	numberChanges NumberChangeNotifier // nil: number changes are not announced
 }
 
-func NewAuthService(userRepo repository.UserRepository, deviceRepo repository.DeviceRepository) *AuthService {
-	accountSid := os.Getenv("TWILIO_ACCOUNT_SID")
-	authToken := os.Getenv("TWILIO_AUTH_TOKEN")
-	verifyServiceSID := os.Getenv("TWILIO_VERIFY_SERVICE_SID")
-	devMode := os.Getenv("AUTH_DEV_MODE") == "true" || os.Getenv("AUTH_DEV_MODE") == "1"
-
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("JWT_SECRET environment variable not set")
+func NewAuthService(userRepo repository.UserRepository, deviceRepo repository.DeviceRepository, otpProvider otp.OTPProvider, attemptRepo repository.AttemptRepository, eventRepo repository.SecurityEventRepository, tokenManager *jwt.TokenManager, revocations *revocation.List, twoStepRepo repository.TwoStepRepository, mailer mail.Sender, linkRepo repository.DeviceLinkRepository) *AuthService {
+	if tokenManager == nil {
+		log.Fatal("token manager not configured")
 	}
 
-	tokenManager, err := jwt.NewTokenManager(jwtSecret, 15*time.Minute, 7*24*time.Hour)
-	if err != nil {
-		log.Fatalf("Failed to create token manager: %v", err)
+	if otpProvider == nil {
+		log.Fatal("OTP provider not configured")
 	}
 
-	var client *twilio.RestClient
-	if !devMode {
-		if accountSid == "" || authToken == "" || verifyServiceSID == "" {
-			log.Fatal("Twilio environment variables not set (set AUTH_DEV_MODE=true to bypass in development)")
-		}
-		client = twilio.NewRestClientWithParams(twilio.ClientParams{
-			Username: accountSid,
-			Password: authToken,
-		})
+	var limiter *AttemptLimiter
+	if attemptRepo != nil {
+		limiter = NewAttemptLimiter(attemptRepo)
 	} else {
-		log.Printf("[DEV MODE] Twilio OTP is bypassed. Use OTP code 123456 for verification.")
+		log.Printf("Warning: no attempt repository configured; SendOTP/VerifyOTP are not rate limited")
 	}
-
-	return &AuthService{
-		twilioClient:     client,
-		verifyServiceSID: verifyServiceSID,
-		userRepo:         userRepo,
-		deviceRepo:       deviceRepo,
-		tokenManager:     tokenManager,
-		devMode:          devMode,
+	if revocations == nil {
+		log.Printf("Warning: no revocation list configured; access tokens stay valid until they expire after logout")
 	}
-}
-
-func (s *AuthService) SendOTP(phoneNumber string) (string, error) {
-	if s.devMode {
-		log.Printf("[DEV MODE] SendOTP bypassed for %s. Returning 'sent'.", phoneNumber)
-		return "sent", nil
+	if twoStepRepo != nil && mailer == nil {
+		log.Printf("Warning: no mail sender configured; two-step PINs can only be reset after the cooldown")
 	}
-	params := &verify.CreateVerificationParams{}
-	params.SetTo(phoneNumber)
-	params.SetChannel("sms")
 
-	resp, err := s.twilioClient.VerifyV2.CreateVerification(s.verifyServiceSID, params)
-	if err != nil {
-		log.Printf("Failed to send OTP via Twilio: %v\n", err)
-		return "", err
+	return &AuthService{
+		otpProvider:  otpProvider,
+		limiter:      limiter,
+		userRepo:     userRepo,
+		deviceRepo:   deviceRepo,
+		eventRepo:    eventRepo,
+		tokenManager: tokenManager,
+		revocations:  revocations,
+		twoStep:      twoStepRepo,
+		mailer:       mailer,
+		links:        linkRepo,
 	}
-
-	log.Printf("OTP sent successfully. Status: %s, SID: %s\n", *resp.Status, *resp.Sid)
-	return *resp.Status, nil
 }
 
-func (s *AuthService) VerifyOTP(ctx context.Context, phoneNumber, code, deviceID string) (*domain.User, string, string, error) {
-	// 1. Verify code (Twilio or Dev)
-	if s.devMode {
-		if code != "123456" {
-			return nil, "", "", errors.New("invalid OTP code in dev mode; expected 123456")
-		}
-		log.Printf("[DEV MODE] OTP accepted for %s", phoneNumber)
-	} else {
-		checkParams := &verify.CreateVerificationCheckParams{}
-		checkParams.SetTo(phoneNumber)
-		checkParams.SetCode(code)
-
-		resp, err := s.twilioClient.VerifyV2.CreateVerificationCheck(s.verifyServiceSID, checkParams)
-		if err != nil {
-			log.Printf("Failed to verify OTP via Twilio: %v\n", err)
-			return nil, "", "", err
-		}
-
-		if resp.Status == nil || *resp.Status != "approved" {
-			log.Printf("OTP verification failed for %s. Status: %s\n", phoneNumber, *resp.Status)
-			return nil, "", "", errors.New("OTP verification failed or code is incorrect")
+// SendOTP sends a code to the phone number. peerAddr is the caller's network
+// address and may be empty; both are rate limited independently.
+func (s *AuthService) SendOTP(ctx context.Context, phoneNumber, peerAddr string) (string, error) {
+	if s.limiter != nil {
+		keys := []limitKey{phoneKey(sendOTPPhonePolicy, phoneNumber), peerKey(sendOTPPeerPolicy, peerAddr)}
+		if err := s.limiter.Take(ctx, keys...); err != nil {
+			return "", err
 		}
+	}
+	return s.otpProvider.Send(ctx, phoneNumber)
+}
 
-		log.Printf("OTP verification successful for %s\n", phoneNumber)
+func (s *AuthService) VerifyOTP(ctx context.Context, phoneNumber, code, deviceID, peerAddr string) (*domain.User, string, string, error) {
+	// 1. Verify code with the configured OTP provider, counting failures per phone and peer
+	if err := s.checkOTP(ctx, phoneNumber, code, peerAddr); err != nil {
+		return nil, "", "", err
 	}
+	log.Printf("OTP verification successful for %s\n", phoneNumber)
 
 	// 2. Check if user exists in the database
 	user, err := s.userRepo.FindByPhoneNumber(ctx, phoneNumber)
@@ -133,12 +116,62 @@ func (s *AuthService) VerifyOTP(ctx context.Context, phoneNumber, code, deviceID
 		log.Printf("Found existing user with ID: %s", user.ID)
 	}
 
-	// 4. Generate tokens for the user
-	accessToken, refreshToken, err := s.tokenManager.GenerateTokens(user.ID.String())
+	// 4. With two-step verification on, the PIN must be checked before any tokens are issued
+	if err := s.requirePIN(ctx, user, deviceID); err != nil {
+		return nil, "", "", err
+	}
+
+	// 5. Start the device session
+	accessToken, refreshToken, err := s.startSession(ctx, user, deviceID, "mobile")
 	if err != nil {
-		log.Printf("Error generating tokens for user %s: %v", user.ID, err)
 		return nil, "", "", err
 	}
+	return user, accessToken, refreshToken, nil
+}
+
+// checkOTP verifies a code with the configured OTP provider, counting failures
+// per phone and peer. Each check takes an attempt up front, so parallel wrong
+// guesses cannot get past the limit; checks that did not fail on the code get
+// it back.
+func (s *AuthService) checkOTP(ctx context.Context, phoneNumber, code, peerAddr string) error {
+	var limitKeys []limitKey
+	if s.limiter != nil {
+		limitKeys = []limitKey{phoneKey(verifyOTPPhonePolicy, phoneNumber), peerKey(verifyOTPPeerPolicy, peerAddr)}
+		if err := s.limiter.Take(ctx, limitKeys...); err != nil {
+			return err
+		}
+	}
+	if err := s.otpProvider.Check(ctx, phoneNumber, code); err != nil {
+		if s.limiter != nil && !errors.Is(err, otp.ErrInvalidCode) {
+			if rerr := s.limiter.Refund(ctx, limitKeys...); rerr != nil {
+				log.Printf("Warning: failed to refund OTP attempt for %s: %v", phoneNumber, rerr)
+			}
+		}
+		return err
+	}
+	if s.limiter != nil {
+		// Only the phone counter is cleared; a peer guessing across many numbers stays counted.
+		if rerr := s.limiter.Reset(ctx, limitKeys[0]); rerr != nil {
+			log.Printf("Warning: failed to reset OTP attempts for %s: %v", phoneNumber, rerr)
+		}
+		if rerr := s.limiter.Refund(ctx, limitKeys[1]); rerr != nil {
+			log.Printf("Warning: failed to refund OTP attempt for %s: %v", phoneNumber, rerr)
+		}
+	}
+	return nil
+}
+
+// startSession issues tokens for a completed login; every login starts a new
+// device session (token family) recorded in user_devices. SMS logins are
+// phones; companion devices bring their own type.
+func (s *AuthService) startSession(ctx context.Context, user *domain.User, deviceID, deviceType string) (string, string, error) {
+	// Generate tokens for the user
+	familyID := uuid.New()
+	accessToken, refreshToken, err := s.tokenManager.GenerateSessionTokens(user.ID.String(), familyID.String())
+	if err != nil {
+		log.Printf("Error generating tokens for user %s: %v", user.ID, err)
+		return "", "", err
+	}
 
 	// Debug: Validate and log token types to confirm mapping
 	if claimsA, errA := s.tokenManager.ValidateToken(accessToken); errA == nil {
@@ -154,7 +187,7 @@ func (s *AuthService) VerifyOTP(ctx context.Context, phoneNumber, code, deviceID
 
 	log.Printf("Generated tokens for user %s (access len=%d, refresh len=%d)", user.ID, len(accessToken), len(refreshToken))
 
-	// 5. Persist refresh token hash for revocation checks
+	// Persist refresh token hash for revocation checks
 	// Use device_id from request for better tracking
 	if s.deviceRepo != nil {
 		hash := hashRefreshToken(refreshToken)
@@ -164,9 +197,10 @@ func (s *AuthService) VerifyOTP(ctx context.Context, phoneNumber, code, deviceID
 		}
 		dev := &domain.UserDevice{
 			UserID:           user.ID,
+			FamilyID:         familyID,
 			RefreshTokenHash: hash,
 			DeviceName:       deviceName,
-			DeviceType:       "mobile", // Could be enhanced with actual device type
+			DeviceType:       deviceType,
 			LastLoginAt:      time.Now(),
 		}
 		if err := s.deviceRepo.UpsertDevice(ctx, dev); err != nil {
@@ -176,7 +210,7 @@ func (s *AuthService) VerifyOTP(ctx context.Context, phoneNumber, code, deviceID
 		}
 	}
 
-	return user, accessToken, refreshToken, nil
+	return accessToken, refreshToken, nil
 }
 
 // RefreshToken validates a refresh token and issues a new pair of access and refresh tokens.
@@ -192,17 +226,22 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshTokenString strin
 		return "", "", errors.New("provided token is not a refresh token")
 	}
 
-	// 3. Verify refresh token hash exists and is not revoked; capture device for rotation
+	// 3. Verify refresh token hash exists and is not revoked; capture device for rotation.
+	// A known but revoked hash means the token was replayed: revoke its whole family.
 	var currentDev *domain.UserDevice
 	if s.deviceRepo != nil {
 		hash := hashRefreshToken(refreshTokenString)
-		dev, derr := s.deviceRepo.FindActiveByUserAndHash(ctx, claims.Subject, hash)
+		dev, derr := s.deviceRepo.FindByUserAndHash(ctx, claims.Subject, hash)
 		if derr != nil {
 			return "", "", derr
 		}
 		if dev == nil {
 			return "", "", jwt.ErrInvalidToken
 		}
+		if dev.RevokedAt != nil {
+			s.revokeReusedFamily(ctx, dev)
+			return "", "", ErrRefreshTokenReused
+		}
 		currentDev = dev
 	}
 
@@ -218,54 +257,79 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshTokenString strin
 	}
 
 	// 5. Issue new access and refresh tokens (rotation)
-	newAccessToken, newRefreshToken, err := s.tokenManager.GenerateTokens(userID)
+	sessionID := claims.SessionID
+	if currentDev != nil {
+		sessionID = currentDev.FamilyID.String()
+	}
+	newAccessToken, newRefreshToken, err := s.tokenManager.GenerateSessionTokens(userID, sessionID)
 	if err != nil {
 		log.Printf("Error generating new tokens for user %s: %v", userID, err)
 		return "", "", err
 	}
 
-	// 6. Persist the new refresh token hash and revoke the old one
-	if s.deviceRepo != nil {
-		// Revoke old device session to prevent reuse (rotate)
-		if currentDev != nil {
-			if rerr := s.deviceRepo.RevokeByID(ctx, currentDev.ID.String()); rerr != nil {
-				log.Printf("Warning: failed to revoke old device %s for user %s: %v", currentDev.ID, userID, rerr)
-			}
-		}
-		// Create a new device session record, copy metadata if available
+	// 6. Revoke the old session and persist the new one in one step. A
+	// concurrent refresh with the same token loses the race and is handled as
+	// reuse, so only one of them gets a session.
+	if currentDev != nil {
 		newDev := &domain.UserDevice{
-			UserID:           user.ID,
-			RefreshTokenHash: hashRefreshToken(newRefreshToken),
-			DeviceName:       "unknown",
-			DeviceType:       "unknown",
-			LastLoginAt:      time.Now(),
+			UserID:                user.ID,
+			FamilyID:              currentDev.FamilyID,
+			RefreshTokenHash:      hashRefreshToken(newRefreshToken),
+			DeviceName:            currentDev.DeviceName,
+			DeviceType:            currentDev.DeviceType,
+			PushNotificationToken: currentDev.PushNotificationToken,
+			PushProvider:          currentDev.PushProvider,
+			LastLoginAt:           time.Now(),
 		}
-		if currentDev != nil {
-			newDev.DeviceName = currentDev.DeviceName
-			newDev.DeviceType = currentDev.DeviceType
-			newDev.PushNotificationToken = currentDev.PushNotificationToken
+		rotated, rerr := s.deviceRepo.RotateDevice(ctx, currentDev.ID.String(), newDev)
+		if rerr != nil {
+			log.Printf("Error rotating device session %s for user %s: %v", currentDev.ID, userID, rerr)
+			return "", "", rerr
 		}
-		if uerr := s.deviceRepo.UpsertDevice(ctx, newDev); uerr != nil {
-			log.Printf("Warning: failed to upsert new device for user %s: %v", userID, uerr)
+		if !rotated {
+			now := time.Now()
+			currentDev.RevokedAt = &now
+			s.revokeReusedFamily(ctx, currentDev)
+			return "", "", ErrRefreshTokenReused
 		}
 	}
 
 	return newAccessToken, newRefreshToken, nil
 }
 
-// ValidateAccessToken validates an access token and returns whether it's valid and the associated user ID.
-// It does not return an error for invalid tokens; instead, it returns (false, ""). Errors are only for unexpected conditions.
-func (s *AuthService) ValidateAccessToken(accessToken string) (bool, string) {
-	claims, err := s.tokenManager.ValidateToken(accessToken)
-	if err != nil {
-		return false, ""
+// revokeReusedFamily revokes every session in the device's token family and
+// records a security event. Failures are logged; the caller rejects the token regardless.
+func (s *AuthService) revokeReusedFamily(ctx context.Context, dev *domain.UserDevice) {
+	log.Printf("Security: revoked refresh token reused for user %s (family %s); revoking family", dev.UserID, dev.FamilyID)
+	if err := s.deviceRepo.RevokeFamily(ctx, dev.FamilyID.String()); err != nil {
+		log.Printf("Warning: failed to revoke token family %s for user %s: %v", dev.FamilyID, dev.UserID, err)
+	}
+	if err := s.revokeSessionAccessTokens(ctx, dev.FamilyID.String()); err != nil {
+		log.Printf("Warning: failed to revoke access tokens of family %s for user %s: %v", dev.FamilyID, dev.UserID, err)
+	}
+	if s.eventRepo == nil {
+		return
 	}
+	familyID := dev.FamilyID
+	event := &domain.SecurityEvent{
+		UserID:    dev.UserID,
+		EventType: domain.RefreshTokenReuseEvent,
+		FamilyID:  &familyID,
+		Details:   fmt.Sprintf("revoked refresh token for device %q presented; session %s revoked at %s", dev.DeviceName, dev.ID, dev.RevokedAt.Format(time.RFC3339)),
+	}
+	if err := s.eventRepo.RecordEvent(ctx, event); err != nil {
+		log.Printf("Warning: failed to record security event for user %s: %v", dev.UserID, err)
+	}
+}
 
-	// Ensure it's an access token
-	if claims.Type != jwt.TokenTypeAccess {
+// ValidateAccessToken validates an access token and returns whether it's valid and the associated user ID.
+// It does not return an error for invalid tokens; instead, it returns (false, "").
+// Revoked tokens, and tokens whose revocation status cannot be checked, are reported as invalid.
+func (s *AuthService) ValidateAccessToken(ctx context.Context, accessToken string) (bool, string) {
+	claims, err := s.AuthenticateAccessToken(ctx, accessToken)
+	if err != nil {
 		return false, ""
 	}
-
 	return true, claims.Subject
 }
 
@@ -275,42 +339,71 @@ func hashRefreshToken(token string) string {
 	return hex.EncodeToString(sum[:])
 }
 
-// RevokeByRefreshToken revokes the specific device session identified by the provided refresh token.
+// RevokeByRefreshToken revokes the specific device session identified by the provided refresh token
+// and returns the revoked session.
 // If the token is invalid or not found, it returns ErrInvalidToken for security (no enumeration).
-func (s *AuthService) RevokeByRefreshToken(ctx context.Context, refreshToken string) error {
+func (s *AuthService) RevokeByRefreshToken(ctx context.Context, refreshToken string) (*domain.UserDevice, error) {
 	if s.deviceRepo == nil {
-		return errors.New("device repository not configured")
+		return nil, errors.New("device repository not configured")
 	}
 	claims, err := s.tokenManager.ValidateToken(refreshToken)
 	if err != nil {
-		return jwt.ErrInvalidToken
+		return nil, jwt.ErrInvalidToken
 	}
 	if claims.Type != jwt.TokenTypeRefresh {
-		return jwt.ErrInvalidToken
+		return nil, jwt.ErrInvalidToken
 	}
 	hash := hashRefreshToken(refreshToken)
 	dev, err := s.deviceRepo.FindActiveByUserAndHash(ctx, claims.Subject, hash)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if dev == nil {
-		return jwt.ErrInvalidToken
+		return nil, jwt.ErrInvalidToken
+	}
+	if err := s.deviceRepo.RevokeByID(ctx, dev.ID.String()); err != nil {
+		return nil, err
+	}
+	if err := s.revokeSessionAccessTokens(ctx, dev.FamilyID.String()); err != nil {
+		return nil, err
 	}
-	return s.deviceRepo.RevokeByID(ctx, dev.ID.String())
+	return dev, nil
 }
 
-// RevokeAllForAccessToken revokes all active device sessions for the user extracted from the access token.
+// RevokeAllForAccessToken revokes all active device sessions for the user extracted from the access token
+// and returns that user's ID.
 // Returns ErrInvalidToken when the token is invalid.
-func (s *AuthService) RevokeAllForAccessToken(ctx context.Context, accessToken string) error {
+func (s *AuthService) RevokeAllForAccessToken(ctx context.Context, accessToken string) (string, error) {
 	if s.deviceRepo == nil {
-		return errors.New("device repository not configured")
+		return "", errors.New("device repository not configured")
 	}
 	claims, err := s.tokenManager.ValidateToken(accessToken)
 	if err != nil {
-		return jwt.ErrInvalidToken
+		return "", jwt.ErrInvalidToken
 	}
 	if claims.Type != jwt.TokenTypeAccess {
-		return jwt.ErrInvalidToken
+		return "", jwt.ErrInvalidToken
 	}
-	return s.deviceRepo.RevokeAllForUser(ctx, claims.Subject)
+	if err := s.deviceRepo.RevokeAllForUser(ctx, claims.Subject); err != nil {
+		return "", err
+	}
+	if s.revocations != nil {
+		if err := s.revocations.RevokeUser(ctx, claims.Subject); err != nil {
+			return "", err
+		}
+	}
+	return claims.Subject, nil
+}
+
+// revokeSessionAccessTokens puts the device session's access tokens on the revocation list.
+func (s *AuthService) revokeSessionAccessTokens(ctx context.Context, familyID string) error {
+	if s.revocations == nil {
+		return nil
+	}
+	return s.revocations.RevokeSession(ctx, familyID)
+}
+
+// PublicKeys returns the JWKS other services use to validate access tokens.
+func (s *AuthService) PublicKeys() jwt.JWKS {
+	return s.tokenManager.JWKS()
 }
@@ -2,9 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/dykethecreator/GoApp/internal/auth/otp"
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/internal/auth/revocation"
+	"github.com/dykethecreator/GoApp/internal/auth/store"
 	"github.com/dykethecreator/GoApp/pkg/domain"
 	appjwt "github.com/dykethecreator/GoApp/pkg/jwt"
 	"github.com/google/uuid"
@@ -12,19 +17,54 @@ import (
 
 // --- Fakes ---
 
-type fakeUserRepo struct{}
+// fakeUserRepo keeps the users it creates so that repeated logins find them.
+type fakeUserRepo struct {
+	byPhone map[string]*domain.User
+}
+
+func newFakeUserRepo() *fakeUserRepo { return &fakeUserRepo{byPhone: map[string]*domain.User{}} }
 
 func (f *fakeUserRepo) FindByPhoneNumber(ctx context.Context, phone string) (*domain.User, error) {
-	return nil, nil
+	return f.byPhone[phone], nil
 }
+
 func (f *fakeUserRepo) CreateUser(ctx context.Context, u *domain.User) (*domain.User, error) {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	f.byPhone[u.PhoneNumber] = u
 	return u, nil
 }
+
 func (f *fakeUserRepo) FindByID(ctx context.Context, userID string) (*domain.User, error) {
-	id, _ := uuid.Parse(userID)
-	return &domain.User{ID: id, PhoneNumber: "+900000000000"}, nil
+	for _, u := range f.byPhone {
+		if u.ID.String() == userID {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeUserRepo) ChangePhoneNumber(ctx context.Context, userID, oldPhone, newPhone string) (*domain.User, error) {
+	u := f.byPhone[oldPhone]
+	if u == nil || u.ID.String() != userID {
+		return nil, repository.ErrPhoneNumberChanged
+	}
+	if f.byPhone[newPhone] != nil {
+		return nil, repository.ErrPhoneNumberTaken
+	}
+	delete(f.byPhone, oldPhone)
+	u.PhoneNumber = newPhone
+	f.byPhone[newPhone] = u
+	return u, nil
 }
 
+// otpStub rejects every code.
+type otpStub struct{}
+
+func (otpStub) Send(ctx context.Context, phone string) (string, error) { return "pending", nil }
+func (otpStub) Check(ctx context.Context, phone, code string) error    { return otp.ErrInvalidCode }
+
 type deviceRecord struct {
 	dev *domain.UserDevice
 }
@@ -32,6 +72,9 @@ type deviceRecord struct {
 type fakeDeviceRepo struct {
 	// key: userID|hash
 	store map[string]deviceRecord
+	// beforeRotate, when set, runs at the start of RotateDevice to simulate a
+	// concurrent refresh.
+	beforeRotate func()
 }
 
 func newFakeDeviceRepo() *fakeDeviceRepo { return &fakeDeviceRepo{store: map[string]deviceRecord{}} }
@@ -59,6 +102,25 @@ func (f *fakeDeviceRepo) FindActiveByUserAndHash(ctx context.Context, userID str
 	return rec.dev, nil
 }
 
+func (f *fakeDeviceRepo) FindByUserAndHash(ctx context.Context, userID string, hash string) (*domain.UserDevice, error) {
+	rec, ok := f.store[f.key(userID, hash)]
+	if !ok {
+		return nil, nil
+	}
+	return rec.dev, nil
+}
+
+func (f *fakeDeviceRepo) RevokeFamily(ctx context.Context, familyID string) error {
+	for k, rec := range f.store {
+		if rec.dev.FamilyID.String() == familyID && rec.dev.RevokedAt == nil {
+			now := time.Now()
+			rec.dev.RevokedAt = &now
+			f.store[k] = rec
+		}
+	}
+	return nil
+}
+
 func (f *fakeDeviceRepo) RevokeByID(ctx context.Context, id string) error {
 	// linear scan fine for test
 	for k, rec := range f.store {
@@ -72,6 +134,21 @@ func (f *fakeDeviceRepo) RevokeByID(ctx context.Context, id string) error {
 	return nil
 }
 
+func (f *fakeDeviceRepo) RotateDevice(ctx context.Context, oldID string, next *domain.UserDevice) (bool, error) {
+	if f.beforeRotate != nil {
+		f.beforeRotate()
+	}
+	for k, rec := range f.store {
+		if rec.dev.ID.String() == oldID && rec.dev.RevokedAt == nil {
+			now := time.Now()
+			rec.dev.RevokedAt = &now
+			f.store[k] = rec
+			return true, f.UpsertDevice(ctx, next)
+		}
+	}
+	return false, nil
+}
+
 func (f *fakeDeviceRepo) RevokeAllForUser(ctx context.Context, userID string) error {
 	for k, rec := range f.store {
 		if rec.dev.UserID.String() == userID && rec.dev.RevokedAt == nil {
@@ -83,6 +160,47 @@ func (f *fakeDeviceRepo) RevokeAllForUser(ctx context.Context, userID string) er
 	return nil
 }
 
+func (f *fakeDeviceRepo) ListActiveForUser(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+	out := []*domain.UserDevice{}
+	for _, rec := range f.store {
+		if rec.dev.UserID.String() == userID && rec.dev.RevokedAt == nil {
+			out = append(out, rec.dev)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeDeviceRepo) FindActiveByFamily(ctx context.Context, userID string, familyID string) (*domain.UserDevice, error) {
+	for _, rec := range f.store {
+		if rec.dev.UserID.String() == userID && rec.dev.FamilyID.String() == familyID && rec.dev.RevokedAt == nil {
+			return rec.dev, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeDeviceRepo) UpdateDeviceInfo(ctx context.Context, dev *domain.UserDevice) error {
+	return nil
+}
+
+func (f *fakeDeviceRepo) ClearPushToken(ctx context.Context, token, keepFamilyID string) error {
+	for _, rec := range f.store {
+		if rec.dev.PushNotificationToken == token && rec.dev.FamilyID.String() != keepFamilyID {
+			rec.dev.PushNotificationToken, rec.dev.PushProvider = "", ""
+		}
+	}
+	return nil
+}
+
+type fakeEventRepo struct {
+	events []*domain.SecurityEvent
+}
+
+func (f *fakeEventRepo) RecordEvent(ctx context.Context, e *domain.SecurityEvent) error {
+	f.events = append(f.events, e)
+	return nil
+}
+
 // --- Tests ---
 
 func TestRefreshToken_RotationAndRevocation(t *testing.T) {
@@ -93,12 +211,13 @@ func TestRefreshToken_RotationAndRevocation(t *testing.T) {
 
 	// fabricate service instance without calling NewAuthService (to avoid Twilio deps)
 	s := &AuthService{
-		userRepo:     &fakeUserRepo{},
+		userRepo:     newFakeUserRepo(),
 		deviceRepo:   newFakeDeviceRepo(),
 		tokenManager: tm,
 	}
 
-	userID := uuid.NewString()
+	user, _ := s.userRepo.CreateUser(context.Background(), &domain.User{PhoneNumber: "+905550000001"})
+	userID := user.ID.String()
 	_, refresh, err := tm.GenerateTokens(userID)
 	if err != nil {
 		t.Fatalf("GenerateTokens: %v", err)
@@ -106,7 +225,7 @@ func TestRefreshToken_RotationAndRevocation(t *testing.T) {
 
 	// simulate VerifyOTP persistence of device
 	hash := hashRefreshToken(refresh)
-	dev := &domain.UserDevice{UserID: uuid.MustParse(userID), RefreshTokenHash: hash, DeviceName: "test", DeviceType: "test", LastLoginAt: time.Now()}
+	dev := &domain.UserDevice{UserID: user.ID, RefreshTokenHash: hash, DeviceName: "test", DeviceType: "test", LastLoginAt: time.Now()}
 	if err := s.deviceRepo.UpsertDevice(context.Background(), dev); err != nil {
 		t.Fatalf("UpsertDevice: %v", err)
 	}
@@ -134,3 +253,212 @@ func TestRefreshToken_RotationAndRevocation(t *testing.T) {
 		t.Fatalf("expected new device session to be active")
 	}
 }
+
+func TestSendAndVerifyOTP_LocalProvider(t *testing.T) {
+	tm, err := appjwt.NewTokenManager("0123456789abcdefghijklmnopqrstuvwxyz!@#%$^&*()", 15*time.Minute, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("token manager: %v", err)
+	}
+
+	provider := otp.NewLocalProvider(time.Minute, "")
+	s := &AuthService{
+		otpProvider:  provider,
+		userRepo:     newFakeUserRepo(),
+		deviceRepo:   newFakeDeviceRepo(),
+		tokenManager: tm,
+	}
+
+	ctx := context.Background()
+	phone := "+905551112233"
+	if _, err := s.SendOTP(ctx, phone, ""); err != nil {
+		t.Fatalf("SendOTP error: %v", err)
+	}
+
+	msg, ok := provider.Inbox().Last(phone)
+	if !ok {
+		t.Fatalf("expected OTP in local inbox")
+	}
+
+	if _, _, _, err := s.VerifyOTP(ctx, phone, "not-the-code", "device-1", ""); !errors.Is(err, otp.ErrInvalidCode) {
+		t.Fatalf("expected ErrInvalidCode for wrong code, got %v", err)
+	}
+
+	user, access, refresh, err := s.VerifyOTP(ctx, phone, msg.Code, "device-1", "")
+	if err != nil {
+		t.Fatalf("VerifyOTP error: %v", err)
+	}
+	if user == nil || user.PhoneNumber != phone {
+		t.Fatalf("expected user for %s, got %+v", phone, user)
+	}
+	if access == "" || refresh == "" {
+		t.Fatalf("expected tokens returned")
+	}
+
+	if _, _, _, err := s.VerifyOTP(ctx, phone, msg.Code, "device-1", ""); !errors.Is(err, otp.ErrInvalidCode) {
+		t.Fatalf("expected reused code to be rejected, got %v", err)
+	}
+}
+
+func TestRefreshToken_ReuseRevokesFamily(t *testing.T) {
+	tm, err := appjwt.NewTokenManager("0123456789abcdefghijklmnopqrstuvwxyz!@#%$^&*()", 15*time.Minute, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("token manager: %v", err)
+	}
+
+	events := &fakeEventRepo{}
+	s := &AuthService{
+		userRepo:     newFakeUserRepo(),
+		deviceRepo:   newFakeDeviceRepo(),
+		eventRepo:    events,
+		tokenManager: tm,
+	}
+	ctx := context.Background()
+
+	user, _ := s.userRepo.CreateUser(ctx, &domain.User{PhoneNumber: "+905550000001"})
+	userID := user.ID.String()
+	_, stolen, err := tm.GenerateTokens(userID)
+	if err != nil {
+		t.Fatalf("GenerateTokens: %v", err)
+	}
+	familyID := uuid.New()
+	dev := &domain.UserDevice{UserID: user.ID, FamilyID: familyID, RefreshTokenHash: hashRefreshToken(stolen), DeviceName: "test", LastLoginAt: time.Now()}
+	if err := s.deviceRepo.UpsertDevice(ctx, dev); err != nil {
+		t.Fatalf("UpsertDevice: %v", err)
+	}
+
+	// Attacker rotates the stolen token first
+	_, attackerRefresh, err := s.RefreshToken(ctx, stolen)
+	if err != nil {
+		t.Fatalf("RefreshToken error: %v", err)
+	}
+	if d, _ := s.deviceRepo.FindActiveByUserAndHash(ctx, userID, hashRefreshToken(attackerRefresh)); d == nil || d.FamilyID != familyID {
+		t.Fatalf("expected rotated session to stay in family %s, got %+v", familyID, d)
+	}
+
+	// Legitimate client replays the old token: the whole family must be revoked
+	if _, _, err := s.RefreshToken(ctx, stolen); !errors.Is(err, ErrRefreshTokenReused) || !errors.Is(err, appjwt.ErrInvalidToken) {
+		t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+	}
+	if d, _ := s.deviceRepo.FindActiveByUserAndHash(ctx, userID, hashRefreshToken(attackerRefresh)); d != nil {
+		t.Fatalf("expected attacker's session to be revoked with its family")
+	}
+	if _, _, err := s.RefreshToken(ctx, attackerRefresh); err == nil {
+		t.Fatalf("expected attacker's refresh token to be rejected")
+	}
+
+	if len(events.events) == 0 || events.events[0].EventType != domain.RefreshTokenReuseEvent {
+		t.Fatalf("expected refresh_token_reuse security event, got %+v", events.events)
+	}
+}
+
+func TestRefreshToken_ConcurrentReuseRevokesFamily(t *testing.T) {
+	tm, err := appjwt.NewTokenManager("0123456789abcdefghijklmnopqrstuvwxyz!@#%$^&*()", 15*time.Minute, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("token manager: %v", err)
+	}
+
+	devices := newFakeDeviceRepo()
+	events := &fakeEventRepo{}
+	s := &AuthService{
+		userRepo:     newFakeUserRepo(),
+		deviceRepo:   devices,
+		eventRepo:    events,
+		tokenManager: tm,
+	}
+	ctx := context.Background()
+
+	user, _ := s.userRepo.CreateUser(ctx, &domain.User{PhoneNumber: "+905550000001"})
+	userID := user.ID.String()
+	_, refresh, err := tm.GenerateTokens(userID)
+	if err != nil {
+		t.Fatalf("GenerateTokens: %v", err)
+	}
+	dev := &domain.UserDevice{UserID: user.ID, FamilyID: uuid.New(), RefreshTokenHash: hashRefreshToken(refresh), DeviceName: "test", LastLoginAt: time.Now()}
+	if err := devices.UpsertDevice(ctx, dev); err != nil {
+		t.Fatalf("UpsertDevice: %v", err)
+	}
+
+	// Another refresh with the same token rotates it after this one looked it
+	// up but before it could revoke it.
+	devices.beforeRotate = func() {
+		devices.beforeRotate = nil
+		if _, err := devices.RotateDevice(ctx, dev.ID.String(), &domain.UserDevice{UserID: dev.UserID, FamilyID: dev.FamilyID, RefreshTokenHash: "winner"}); err != nil {
+			t.Fatalf("RotateDevice: %v", err)
+		}
+	}
+	if _, _, err := s.RefreshToken(ctx, refresh); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("expected ErrRefreshTokenReused for the losing refresh, got %v", err)
+	}
+	if d, _ := devices.FindActiveByUserAndHash(ctx, userID, "winner"); d != nil {
+		t.Fatalf("expected the winning session to be revoked with its family")
+	}
+	if len(events.events) == 0 || events.events[0].EventType != domain.RefreshTokenReuseEvent {
+		t.Fatalf("expected refresh_token_reuse security event, got %+v", events.events)
+	}
+}
+
+func TestDeviceSessions_ListUpdateRevoke(t *testing.T) {
+	tm, err := appjwt.NewTokenManager("0123456789abcdefghijklmnopqrstuvwxyz!@#%$^&*()", 15*time.Minute, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("token manager: %v", err)
+	}
+	provider := otp.NewLocalProvider(time.Minute, "")
+	s := &AuthService{
+		otpProvider:  provider,
+		userRepo:     newFakeUserRepo(),
+		deviceRepo:   newFakeDeviceRepo(),
+		tokenManager: tm,
+		revocations:  revocation.NewList(store.NewMemoryRevocationStore(), time.Minute, 15*time.Minute),
+	}
+	ctx := context.Background()
+	phone := "+905551112244"
+
+	if _, err := s.SendOTP(ctx, phone, ""); err != nil {
+		t.Fatalf("SendOTP error: %v", err)
+	}
+	msg, _ := provider.Inbox().Last(phone)
+	user, access, refresh, err := s.VerifyOTP(ctx, phone, msg.Code, "pixel", "")
+	if err != nil {
+		t.Fatalf("VerifyOTP error: %v", err)
+	}
+	claims, err := s.AuthenticateAccessToken(ctx, access)
+	if err != nil {
+		t.Fatalf("AuthenticateAccessToken error: %v", err)
+	}
+
+	devices, err := s.ListDevices(ctx, user.ID.String())
+	if err != nil {
+		t.Fatalf("ListDevices error: %v", err)
+	}
+	if len(devices) != 1 || devices[0].FamilyID.String() != claims.SessionID {
+		t.Fatalf("expected one device for session %s, got %+v", claims.SessionID, devices)
+	}
+
+	name := "Work phone"
+	if dev, err := s.UpdateDevice(ctx, user.ID.String(), claims.SessionID, &name, nil); err != nil || dev.DeviceName != name {
+		t.Fatalf("UpdateDevice: dev=%+v err=%v", dev, err)
+	}
+	badType := "toaster"
+	if _, err := s.UpdateDevice(ctx, user.ID.String(), claims.SessionID, nil, &badType); !errors.Is(err, ErrInvalidDeviceInfo) {
+		t.Fatalf("expected ErrInvalidDeviceInfo, got %v", err)
+	}
+	if _, err := s.RegisterPushToken(ctx, user.ID.String(), claims.SessionID, "pager", "tok"); !errors.Is(err, ErrInvalidDeviceInfo) {
+		t.Fatalf("expected unknown push provider to fail with ErrInvalidDeviceInfo, got %v", err)
+	}
+	if dev, err := s.RegisterPushToken(ctx, user.ID.String(), claims.SessionID, domain.PushProviderFCM, "tok"); err != nil || dev.PushProvider != domain.PushProviderFCM || dev.PushNotificationToken != "tok" {
+		t.Fatalf("RegisterPushToken: dev=%+v err=%v", dev, err)
+	}
+	if err := s.RevokeDevice(ctx, uuid.NewString(), claims.SessionID); !errors.Is(err, ErrDeviceNotFound) {
+		t.Fatalf("expected another user's revoke to fail with ErrDeviceNotFound, got %v", err)
+	}
+
+	if err := s.RevokeDevice(ctx, user.ID.String(), claims.SessionID); err != nil {
+		t.Fatalf("RevokeDevice error: %v", err)
+	}
+	if _, _, err := s.RefreshToken(ctx, refresh); err == nil {
+		t.Fatalf("expected refresh token of revoked device to be rejected")
+	}
+	if _, err := s.AuthenticateAccessToken(ctx, access); !errors.Is(err, appjwt.ErrInvalidToken) {
+		t.Fatalf("expected access token of revoked device to be rejected, got %v", err)
+	}
+}
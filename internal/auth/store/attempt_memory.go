@@ -0,0 +1,83 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// MemoryAttemptStore implements AttemptRepository in memory.
+// Counters are lost on restart; use AttemptStore in production.
+type MemoryAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]domain.AuthAttempt
+}
+
+func NewMemoryAttemptStore() repository.AttemptRepository {
+	return &MemoryAttemptStore{attempts: make(map[string]domain.AuthAttempt)}
+}
+
+func (s *MemoryAttemptStore) GetAttempt(ctx context.Context, key string) (*domain.AuthAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+	return &a, nil
+}
+
+func (s *MemoryAttemptStore) IncrementAttempt(ctx context.Context, key string, now time.Time, window time.Duration) (*domain.AuthAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.attempts[key]
+	if !ok {
+		a = domain.AuthAttempt{Key: key, WindowStart: now}
+	}
+	if a.LockedUntil != nil && a.LockedUntil.After(now) {
+		return &a, nil
+	}
+	if !a.WindowStart.After(now.Add(-window)) {
+		a.Count = 0
+		a.WindowStart = now
+	}
+	a.Count++
+	a.UpdatedAt = now
+	s.attempts[key] = a
+	return &a, nil
+}
+
+func (s *MemoryAttemptStore) RefundAttempt(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.attempts[key]; ok && a.Count > 0 {
+		a.Count--
+		s.attempts[key] = a
+	}
+	return nil
+}
+
+func (s *MemoryAttemptStore) LockAttempt(ctx context.Context, key string, until time.Time, lockouts int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.attempts[key]
+	if !ok {
+		a = domain.AuthAttempt{Key: key}
+	}
+	a.LockedUntil = &until
+	a.Lockouts = lockouts
+	a.Count = 0
+	a.UpdatedAt = time.Now()
+	s.attempts[key] = a
+	return nil
+}
+
+func (s *MemoryAttemptStore) ResetAttempt(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.attempts, key)
+	return nil
+}
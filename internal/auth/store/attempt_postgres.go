@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// AttemptStore implements AttemptRepository for PostgreSQL so lockouts survive restarts.
+type AttemptStore struct {
+	db *sql.DB
+}
+
+func NewAttemptStore(db *sql.DB) repository.AttemptRepository {
+	return &AttemptStore{db: db}
+}
+
+func (s *AttemptStore) GetAttempt(ctx context.Context, key string) (*domain.AuthAttempt, error) {
+	q := `SELECT key, count, window_start, lockouts, locked_until, updated_at FROM auth_attempts WHERE key = $1`
+	var a domain.AuthAttempt
+	if err := s.db.QueryRowContext(ctx, q, key).Scan(&a.Key, &a.Count, &a.WindowStart, &a.Lockouts, &a.LockedUntil, &a.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (s *AttemptStore) IncrementAttempt(ctx context.Context, key string, now time.Time, window time.Duration) (*domain.AuthAttempt, error) {
+	q := `
+	INSERT INTO auth_attempts (key, count, window_start, lockouts, updated_at)
+	VALUES ($1, 1, $2, 0, $2)
+	ON CONFLICT (key) DO UPDATE SET
+		count = CASE
+			WHEN auth_attempts.locked_until > $2 THEN auth_attempts.count
+			WHEN auth_attempts.window_start <= $3 THEN 1
+			ELSE auth_attempts.count + 1 END,
+		window_start = CASE
+			WHEN auth_attempts.locked_until > $2 THEN auth_attempts.window_start
+			WHEN auth_attempts.window_start <= $3 THEN $2
+			ELSE auth_attempts.window_start END,
+		updated_at = $2
+	RETURNING key, count, window_start, lockouts, locked_until, updated_at
+	`
+	var a domain.AuthAttempt
+	err := s.db.QueryRowContext(ctx, q, key, now, now.Add(-window)).
+		Scan(&a.Key, &a.Count, &a.WindowStart, &a.Lockouts, &a.LockedUntil, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (s *AttemptStore) RefundAttempt(ctx context.Context, key string) error {
+	q := `UPDATE auth_attempts SET count = count - 1, updated_at = NOW() WHERE key = $1 AND count > 0`
+	_, err := s.db.ExecContext(ctx, q, key)
+	return err
+}
+
+func (s *AttemptStore) LockAttempt(ctx context.Context, key string, until time.Time, lockouts int) error {
+	q := `UPDATE auth_attempts SET locked_until = $2, lockouts = $3, count = 0, updated_at = NOW() WHERE key = $1`
+	_, err := s.db.ExecContext(ctx, q, key, until, lockouts)
+	return err
+}
+
+func (s *AttemptStore) ResetAttempt(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM auth_attempts WHERE key = $1`, key)
+	return err
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+// MemoryDeviceLinkStore implements DeviceLinkRepository in memory, for tests
+// and single-process development.
+type MemoryDeviceLinkStore struct {
+	mu    sync.Mutex
+	links map[string]domain.DeviceLink
+}
+
+func NewMemoryDeviceLinkStore() repository.DeviceLinkRepository {
+	return &MemoryDeviceLinkStore{links: make(map[string]domain.DeviceLink)}
+}
+
+func (s *MemoryDeviceLinkStore) CreateLink(ctx context.Context, l *domain.DeviceLink) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = time.Now()
+	}
+	for k, old := range s.links {
+		if old.ExpiresAt.Before(l.CreatedAt) {
+			delete(s.links, k)
+		}
+	}
+	s.links[l.TokenHash] = *l
+	return nil
+}
+
+func (s *MemoryDeviceLinkStore) ApproveLink(ctx context.Context, tokenHash, userID string, now time.Time) (*domain.DeviceLink, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.links[tokenHash]
+	if !ok || l.UserID != nil || !l.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	l.UserID, l.ApprovedAt = &id, &now
+	s.links[tokenHash] = l
+	return &l, nil
+}
+
+func (s *MemoryDeviceLinkStore) GetLink(ctx context.Context, tokenHash, secretHash string, now time.Time) (*domain.DeviceLink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.links[tokenHash]
+	if !ok || l.SecretHash != secretHash || !l.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	return &l, nil
+}
+
+func (s *MemoryDeviceLinkStore) ClaimApprovedLink(ctx context.Context, tokenHash, secretHash string, now time.Time) (*domain.DeviceLink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.links[tokenHash]
+	if !ok || l.SecretHash != secretHash || l.UserID == nil || !l.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	delete(s.links, tokenHash)
+	return &l, nil
+}
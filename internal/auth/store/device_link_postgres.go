@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+// DeviceLinkStore implements DeviceLinkRepository for PostgreSQL.
+type DeviceLinkStore struct {
+	db *sql.DB
+}
+
+func NewDeviceLinkStore(db *sql.DB) repository.DeviceLinkRepository {
+	return &DeviceLinkStore{db: db}
+}
+
+const deviceLinkColumns = `token_hash, secret_hash, device_name, device_type, user_id, approved_at, expires_at, created_at`
+
+func (s *DeviceLinkStore) CreateLink(ctx context.Context, l *domain.DeviceLink) error {
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = time.Now()
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM device_links WHERE expires_at < $1`, l.CreatedAt); err != nil {
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT INTO device_links (token_hash, secret_hash, device_name, device_type, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		l.TokenHash, l.SecretHash, l.DeviceName, l.DeviceType, l.ExpiresAt, l.CreatedAt)
+	return err
+}
+
+func (s *DeviceLinkStore) ApproveLink(ctx context.Context, tokenHash, userID string, now time.Time) (*domain.DeviceLink, error) {
+	return scanDeviceLink(s.db.QueryRowContext(ctx, `
+		UPDATE device_links SET user_id = $2, approved_at = $3
+		WHERE token_hash = $1 AND user_id IS NULL AND expires_at > $3
+		RETURNING `+deviceLinkColumns, tokenHash, userID, now))
+}
+
+func (s *DeviceLinkStore) GetLink(ctx context.Context, tokenHash, secretHash string, now time.Time) (*domain.DeviceLink, error) {
+	return scanDeviceLink(s.db.QueryRowContext(ctx, `
+		SELECT `+deviceLinkColumns+` FROM device_links
+		WHERE token_hash = $1 AND secret_hash = $2 AND expires_at > $3`, tokenHash, secretHash, now))
+}
+
+func (s *DeviceLinkStore) ClaimApprovedLink(ctx context.Context, tokenHash, secretHash string, now time.Time) (*domain.DeviceLink, error) {
+	return scanDeviceLink(s.db.QueryRowContext(ctx, `
+		DELETE FROM device_links
+		WHERE token_hash = $1 AND secret_hash = $2 AND user_id IS NOT NULL AND expires_at > $3
+		RETURNING `+deviceLinkColumns, tokenHash, secretHash, now))
+}
+
+func scanDeviceLink(row *sql.Row) (*domain.DeviceLink, error) {
+	var (
+		l          domain.DeviceLink
+		userID     uuid.NullUUID
+		approvedAt sql.NullTime
+	)
+	err := row.Scan(&l.TokenHash, &l.SecretHash, &l.DeviceName, &l.DeviceType, &userID, &approvedAt, &l.ExpiresAt, &l.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if userID.Valid {
+		l.UserID = &userID.UUID
+	}
+	if approvedAt.Valid {
+		l.ApprovedAt = &approvedAt.Time
+	}
+	return &l, nil
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/dykethecreator/GoApp/internal/auth/repository"
 	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/dykethecreator/GoApp/pkg/phone"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // UserStore implements the UserRepository interface for PostgreSQL.
@@ -21,12 +23,17 @@ func NewUserStore(db *sql.DB) repository.UserRepository {
 	return &UserStore{db: db}
 }
 
-// FindByPhoneNumber finds a user by their phone number.
+// FindByPhoneNumber finds a user by their phone number, given in
+// international format; it is compared in E.164 form.
 func (s *UserStore) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error) {
+	phoneNumber, err := phone.Canonical(phoneNumber)
+	if err != nil {
+		return nil, err
+	}
 	query := `SELECT id, phone_number, display_name, profile_picture_url, about_text, last_seen_at, created_at, updated_at FROM users WHERE phone_number = $1`
 
 	user := &domain.User{}
-	err := s.db.QueryRowContext(ctx, query, phoneNumber).Scan(
+	err = s.db.QueryRowContext(ctx, query, phoneNumber).Scan(
 		&user.ID,
 		&user.PhoneNumber,
 		&user.DisplayName,
@@ -49,8 +56,15 @@ func (s *UserStore) FindByPhoneNumber(ctx context.Context, phoneNumber string) (
 	return user, nil
 }
 
-// CreateUser creates a new user in the database.
+// CreateUser creates a new user in the database. The phone number is stored
+// in E.164 form.
 func (s *UserStore) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	phoneNumber, err := phone.Canonical(user.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	user.PhoneNumber = phoneNumber
+
 	query := `
 		INSERT INTO users (id, phone_number, display_name, profile_picture_url, about_text, last_seen_at, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
@@ -68,7 +82,7 @@ func (s *UserStore) CreateUser(ctx context.Context, user *domain.User) (*domain.
 		user.DisplayName = "New User" // Or some other default
 	}
 
-	err := s.db.QueryRowContext(ctx, query,
+	err = s.db.QueryRowContext(ctx, query,
 		user.ID,
 		user.PhoneNumber,
 		user.DisplayName,
@@ -111,3 +125,52 @@ func (s *UserStore) FindByID(ctx context.Context, userID string) (*domain.User,
 
 	return user, nil
 }
+
+// ChangePhoneNumber moves the user to newPhone in one transaction. Address book
+// entries of other users follow the numbers: entries for oldPhone are unlinked
+// and entries for newPhone are linked to the user.
+func (s *UserStore) ChangePhoneNumber(ctx context.Context, userID, oldPhone, newPhone string) (*domain.User, error) {
+	newPhone, err := phone.Canonical(newPhone)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var taken bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE phone_number = $1 AND id <> $2)`, newPhone, userID).Scan(&taken); err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, repository.ErrPhoneNumberTaken
+	}
+
+	user := &domain.User{}
+	err = tx.QueryRowContext(ctx, `
+		UPDATE users SET phone_number = $3, updated_at = NOW()
+		WHERE id = $1 AND phone_number = $2
+		RETURNING id, phone_number, display_name, profile_picture_url, about_text, last_seen_at, created_at, updated_at`,
+		userID, oldPhone, newPhone,
+	).Scan(&user.ID, &user.PhoneNumber, &user.DisplayName, &user.ProfilePictureURL, &user.AboutText, &user.LastSeenAt, &user.CreatedAt, &user.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrPhoneNumberChanged
+	}
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		// Registered by someone else since the check above
+		return nil, repository.ErrPhoneNumberTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE contacts SET contact_user_id = NULL WHERE contact_user_id = $1 AND contact_phone_number = $2`, userID, oldPhone); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE contacts SET contact_user_id = $1 WHERE contact_phone_number = $2 AND user_id <> $1`, userID, newPhone); err != nil {
+		return nil, err
+	}
+	return user, tx.Commit()
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/lib/pq"
+)
+
+// PushTokenStore implements PushTokenRepository on the user_devices table.
+type PushTokenStore struct {
+	db *sql.DB
+}
+
+func NewPushTokenStore(db *sql.DB) repository.PushTokenRepository {
+	return &PushTokenStore{db: db}
+}
+
+func (s *PushTokenStore) ListPushTokens(ctx context.Context, userIDs []string) ([]*domain.PushToken, error) {
+	q := `SELECT user_id, family_id, push_provider, push_notification_token
+	FROM user_devices
+	WHERE user_id = ANY($1::uuid[]) AND revoked_at IS NULL
+	  AND push_notification_token IS NOT NULL AND push_provider IS NOT NULL`
+	rows, err := s.db.QueryContext(ctx, q, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []*domain.PushToken{}
+	for rows.Next() {
+		var t domain.PushToken
+		if err := rows.Scan(&t.UserID, &t.FamilyID, &t.Provider, &t.Token); err != nil {
+			return nil, err
+		}
+		out = append(out, &t)
+	}
+	return out, rows.Err()
+}
+
+func (s *PushTokenStore) DeletePushToken(ctx context.Context, token string) error {
+	q := `UPDATE user_devices SET push_notification_token = NULL, push_provider = NULL WHERE push_notification_token = $1`
+	_, err := s.db.ExecContext(ctx, q, token)
+	return err
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+)
+
+// MemoryRevocationStore implements RevocationRepository in memory.
+// Revocations are not shared between processes; use RevocationStore in production.
+type MemoryRevocationStore struct {
+	mu         sync.Mutex
+	watermarks map[string]memoryWatermark
+}
+
+type memoryWatermark struct {
+	before    time.Time
+	expiresAt time.Time
+}
+
+func NewMemoryRevocationStore() repository.RevocationRepository {
+	return &MemoryRevocationStore{watermarks: make(map[string]memoryWatermark)}
+}
+
+func (s *MemoryRevocationStore) RevokeBefore(ctx context.Context, key string, before, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := s.watermarks[key]
+	if before.After(w.before) {
+		w.before = before
+	}
+	if expiresAt.After(w.expiresAt) {
+		w.expiresAt = expiresAt
+	}
+	s.watermarks[key] = w
+	return nil
+}
+
+func (s *MemoryRevocationStore) GetWatermarks(ctx context.Context, keys ...string) (map[string]time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	out := make(map[string]time.Time, len(keys))
+	for _, k := range keys {
+		if w, ok := s.watermarks[k]; ok && !now.After(w.expiresAt) {
+			out[k] = w.before
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/lib/pq"
+)
+
+// RevocationStore implements RevocationRepository for PostgreSQL, so every
+// service instance sharing the database sees the same revocations.
+type RevocationStore struct {
+	db *sql.DB
+}
+
+func NewRevocationStore(db *sql.DB) repository.RevocationRepository {
+	return &RevocationStore{db: db}
+}
+
+func (s *RevocationStore) RevokeBefore(ctx context.Context, key string, before, expiresAt time.Time) error {
+	q := `INSERT INTO access_token_revocations (key, revoked_before, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE SET
+			revoked_before = GREATEST(access_token_revocations.revoked_before, EXCLUDED.revoked_before),
+			expires_at = GREATEST(access_token_revocations.expires_at, EXCLUDED.expires_at)`
+	if _, err := s.db.ExecContext(ctx, q, key, before, expiresAt); err != nil {
+		return err
+	}
+	// Revocations are rare; drop watermarks whose tokens have all expired while we're here.
+	_, err := s.db.ExecContext(ctx, `DELETE FROM access_token_revocations WHERE expires_at < NOW()`)
+	return err
+}
+
+func (s *RevocationStore) GetWatermarks(ctx context.Context, keys ...string) (map[string]time.Time, error) {
+	q := `SELECT key, revoked_before FROM access_token_revocations WHERE key = ANY($1) AND expires_at >= NOW()`
+	rows, err := s.db.QueryContext(ctx, q, pq.Array(keys))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[string]time.Time, len(keys))
+	for rows.Next() {
+		var key string
+		var before time.Time
+		if err := rows.Scan(&key, &before); err != nil {
+			return nil, err
+		}
+		out[key] = before
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+// SecurityEventStore implements SecurityEventRepository for PostgreSQL.
+type SecurityEventStore struct {
+	db *sql.DB
+}
+
+func NewSecurityEventStore(db *sql.DB) repository.SecurityEventRepository {
+	return &SecurityEventStore{db: db}
+}
+
+func (s *SecurityEventStore) RecordEvent(ctx context.Context, e *domain.SecurityEvent) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	q := `INSERT INTO security_events (id, user_id, event_type, family_id, details, created_at) VALUES ($1,$2,$3,$4,$5,$6)`
+	_, err := s.db.ExecContext(ctx, q, e.ID, e.UserID, e.EventType, e.FamilyID, e.Details, e.CreatedAt)
+	return err
+}
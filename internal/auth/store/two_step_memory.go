@@ -0,0 +1,127 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+// MemoryTwoStepStore implements TwoStepRepository in memory, for tests and
+// single-process development.
+type MemoryTwoStepStore struct {
+	mu         sync.Mutex
+	pins       map[string]domain.TwoStepPIN
+	challenges map[string]domain.PINChallenge
+}
+
+func NewMemoryTwoStepStore() repository.TwoStepRepository {
+	return &MemoryTwoStepStore{pins: make(map[string]domain.TwoStepPIN), challenges: make(map[string]domain.PINChallenge)}
+}
+
+func (s *MemoryTwoStepStore) GetPIN(ctx context.Context, userID string) (*domain.TwoStepPIN, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.pins[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &p, nil
+}
+
+func (s *MemoryTwoStepStore) SetPIN(ctx context.Context, userID, pinHash string, recoveryEmail *string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	p, ok := s.pins[userID]
+	if !ok {
+		p = domain.TwoStepPIN{UserID: id, CreatedAt: now}
+	}
+	p.PINHash = pinHash
+	p.RecoveryEmail = recoveryEmail
+	p.ResetRequestedAt, p.ResetCodeHash, p.ResetCodeExpiresAt = nil, nil, nil
+	p.UpdatedAt = now
+	s.pins[userID] = p
+	return nil
+}
+
+func (s *MemoryTwoStepStore) DeletePIN(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pins, userID)
+	return nil
+}
+
+func (s *MemoryTwoStepStore) StartReset(ctx context.Context, userID string, now time.Time) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.pins[userID]
+	if !ok {
+		return time.Time{}, errors.New("two-step PIN not set")
+	}
+	if p.ResetRequestedAt == nil {
+		p.ResetRequestedAt = &now
+		s.pins[userID] = p
+	}
+	return *p.ResetRequestedAt, nil
+}
+
+func (s *MemoryTwoStepStore) SetResetCode(ctx context.Context, userID, codeHash string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.pins[userID]; ok {
+		p.ResetCodeHash, p.ResetCodeExpiresAt = &codeHash, &expiresAt
+		s.pins[userID] = p
+	}
+	return nil
+}
+
+func (s *MemoryTwoStepStore) CancelReset(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.pins[userID]; ok {
+		p.ResetRequestedAt, p.ResetCodeHash, p.ResetCodeExpiresAt = nil, nil, nil
+		s.pins[userID] = p
+	}
+	return nil
+}
+
+func (s *MemoryTwoStepStore) CreateChallenge(ctx context.Context, c *domain.PINChallenge) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now()
+	}
+	for k, old := range s.challenges {
+		if old.ExpiresAt.Before(c.CreatedAt) {
+			delete(s.challenges, k)
+		}
+	}
+	s.challenges[c.TokenHash] = *c
+	return nil
+}
+
+func (s *MemoryTwoStepStore) GetChallenge(ctx context.Context, tokenHash string, now time.Time) (*domain.PINChallenge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.challenges[tokenHash]
+	if !ok || !c.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	return &c, nil
+}
+
+func (s *MemoryTwoStepStore) DeleteChallenge(ctx context.Context, tokenHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.challenges, tokenHash)
+	return nil
+}
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// TwoStepStore implements TwoStepRepository for PostgreSQL.
+type TwoStepStore struct {
+	db *sql.DB
+}
+
+func NewTwoStepStore(db *sql.DB) repository.TwoStepRepository {
+	return &TwoStepStore{db: db}
+}
+
+func (s *TwoStepStore) GetPIN(ctx context.Context, userID string) (*domain.TwoStepPIN, error) {
+	q := `SELECT user_id, pin_hash, recovery_email, reset_requested_at, reset_code_hash, reset_code_expires_at, created_at, updated_at
+		FROM two_step_pins WHERE user_id = $1`
+	var p domain.TwoStepPIN
+	var email, codeHash sql.NullString
+	var requestedAt, codeExpiresAt sql.NullTime
+	err := s.db.QueryRowContext(ctx, q, userID).Scan(&p.UserID, &p.PINHash, &email, &requestedAt, &codeHash, &codeExpiresAt, &p.CreatedAt, &p.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if email.Valid {
+		p.RecoveryEmail = &email.String
+	}
+	if requestedAt.Valid {
+		p.ResetRequestedAt = &requestedAt.Time
+	}
+	if codeHash.Valid {
+		p.ResetCodeHash = &codeHash.String
+	}
+	if codeExpiresAt.Valid {
+		p.ResetCodeExpiresAt = &codeExpiresAt.Time
+	}
+	return &p, nil
+}
+
+func (s *TwoStepStore) SetPIN(ctx context.Context, userID, pinHash string, recoveryEmail *string) error {
+	q := `INSERT INTO two_step_pins (user_id, pin_hash, recovery_email, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			pin_hash = EXCLUDED.pin_hash,
+			recovery_email = EXCLUDED.recovery_email,
+			reset_requested_at = NULL,
+			reset_code_hash = NULL,
+			reset_code_expires_at = NULL,
+			updated_at = NOW()`
+	_, err := s.db.ExecContext(ctx, q, userID, pinHash, recoveryEmail)
+	return err
+}
+
+func (s *TwoStepStore) DeletePIN(ctx context.Context, userID string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM two_step_pins WHERE user_id = $1`, userID)
+	return err
+}
+
+func (s *TwoStepStore) StartReset(ctx context.Context, userID string, now time.Time) (time.Time, error) {
+	q := `UPDATE two_step_pins SET reset_requested_at = COALESCE(reset_requested_at, $2), updated_at = NOW()
+		WHERE user_id = $1 RETURNING reset_requested_at`
+	var startedAt time.Time
+	err := s.db.QueryRowContext(ctx, q, userID, now).Scan(&startedAt)
+	return startedAt, err
+}
+
+func (s *TwoStepStore) SetResetCode(ctx context.Context, userID, codeHash string, expiresAt time.Time) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE two_step_pins SET reset_code_hash = $2, reset_code_expires_at = $3, updated_at = NOW() WHERE user_id = $1`,
+		userID, codeHash, expiresAt)
+	return err
+}
+
+func (s *TwoStepStore) CancelReset(ctx context.Context, userID string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE two_step_pins SET reset_requested_at = NULL, reset_code_hash = NULL, reset_code_expires_at = NULL, updated_at = NOW()
+		WHERE user_id = $1 AND (reset_requested_at IS NOT NULL OR reset_code_hash IS NOT NULL)`, userID)
+	return err
+}
+
+func (s *TwoStepStore) CreateChallenge(ctx context.Context, c *domain.PINChallenge) error {
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now()
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM pin_challenges WHERE expires_at < $1`, c.CreatedAt); err != nil {
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT INTO pin_challenges (token_hash, user_id, device_id, expires_at, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		c.TokenHash, c.UserID, c.DeviceID, c.ExpiresAt, c.CreatedAt)
+	return err
+}
+
+func (s *TwoStepStore) GetChallenge(ctx context.Context, tokenHash string, now time.Time) (*domain.PINChallenge, error) {
+	var c domain.PINChallenge
+	err := s.db.QueryRowContext(ctx, `SELECT token_hash, user_id, device_id, expires_at, created_at FROM pin_challenges WHERE token_hash = $1 AND expires_at > $2`,
+		tokenHash, now).Scan(&c.TokenHash, &c.UserID, &c.DeviceID, &c.ExpiresAt, &c.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (s *TwoStepStore) DeleteChallenge(ctx context.Context, tokenHash string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM pin_challenges WHERE token_hash = $1`, tokenHash)
+	return err
+}
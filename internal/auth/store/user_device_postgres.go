@@ -21,25 +21,66 @@ func NewUserDeviceStore(db *sql.DB) repository.DeviceRepository {
 }
 
 func (s *UserDeviceStore) UpsertDevice(ctx context.Context, dev *domain.UserDevice) error {
+	return insertDevice(ctx, s.db, dev)
+}
+
+// RotateDevice revokes the session oldID and inserts next in one transaction.
+// It reports false, without inserting, when oldID was already revoked, which
+// means its refresh token was used concurrently.
+func (s *UserDeviceStore) RotateDevice(ctx context.Context, oldID string, next *domain.UserDevice) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE user_devices SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, oldID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if n == 0 {
+		return false, nil
+	}
+	if err := insertDevice(ctx, tx, next); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// execer is satisfied by *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertDevice(ctx context.Context, db execer, dev *domain.UserDevice) error {
 	if dev.ID == uuid.Nil {
 		dev.ID = uuid.New()
 	}
+	if dev.FamilyID == uuid.Nil {
+		dev.FamilyID = uuid.New()
+	}
 	if dev.CreatedAt.IsZero() {
 		dev.CreatedAt = time.Now()
 	}
 	q := `
-	INSERT INTO user_devices (id, user_id, refresh_token_hash, device_name, device_type, push_notification_token, last_login_at, created_at)
-	VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+	INSERT INTO user_devices (id, user_id, family_id, refresh_token_hash, device_name, device_type, push_notification_token, push_provider, last_login_at, created_at)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
 	ON CONFLICT (user_id, refresh_token_hash)
 	DO UPDATE SET last_login_at = EXCLUDED.last_login_at
 	`
-	_, err := s.db.ExecContext(ctx, q,
+	_, err := db.ExecContext(ctx, q,
 		dev.ID,
 		dev.UserID,
+		dev.FamilyID,
 		dev.RefreshTokenHash,
 		dev.DeviceName,
 		dev.DeviceType,
-		dev.PushNotificationToken,
+		nullString(dev.PushNotificationToken),
+		nullString(dev.PushProvider),
 		dev.LastLoginAt,
 		dev.CreatedAt,
 	)
@@ -47,16 +88,89 @@ func (s *UserDeviceStore) UpsertDevice(ctx context.Context, dev *domain.UserDevi
 }
 
 func (s *UserDeviceStore) FindActiveByUserAndHash(ctx context.Context, userID string, hash string) (*domain.UserDevice, error) {
-	q := `SELECT id, user_id, refresh_token_hash, device_name, device_type, push_notification_token, last_login_at, created_at, revoked_at
+	q := `SELECT ` + deviceColumns + `
 	FROM user_devices WHERE user_id = $1 AND refresh_token_hash = $2 AND revoked_at IS NULL LIMIT 1`
-	row := s.db.QueryRowContext(ctx, q, userID, hash)
+	return scanDevice(s.db.QueryRowContext(ctx, q, userID, hash))
+}
+
+func (s *UserDeviceStore) FindByUserAndHash(ctx context.Context, userID string, hash string) (*domain.UserDevice, error) {
+	q := `SELECT ` + deviceColumns + `
+	FROM user_devices WHERE user_id = $1 AND refresh_token_hash = $2 LIMIT 1`
+	return scanDevice(s.db.QueryRowContext(ctx, q, userID, hash))
+}
+
+func (s *UserDeviceStore) FindActiveByFamily(ctx context.Context, userID string, familyID string) (*domain.UserDevice, error) {
+	q := `SELECT ` + deviceColumns + `
+	FROM user_devices WHERE user_id = $1 AND family_id = $2 AND revoked_at IS NULL
+	ORDER BY created_at DESC LIMIT 1`
+	return scanDevice(s.db.QueryRowContext(ctx, q, userID, familyID))
+}
+
+func (s *UserDeviceStore) ListActiveForUser(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+	q := `SELECT ` + deviceColumns + `
+	FROM user_devices WHERE user_id = $1 AND revoked_at IS NULL
+	ORDER BY last_login_at DESC NULLS LAST`
+	rows, err := s.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []*domain.UserDevice{}
+	for rows.Next() {
+		d, err := scanDevice(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+func (s *UserDeviceStore) UpdateDeviceInfo(ctx context.Context, dev *domain.UserDevice) error {
+	q := `UPDATE user_devices SET device_name = $2, device_type = $3, push_notification_token = $4, push_provider = $5 WHERE id = $1`
+	_, err := s.db.ExecContext(ctx, q, dev.ID, dev.DeviceName, dev.DeviceType, nullString(dev.PushNotificationToken), nullString(dev.PushProvider))
+	return err
+}
+
+// ClearPushToken removes token from every device session except those of
+// keepFamilyID, so a token moved to another account stops receiving
+// notifications of the previous one.
+func (s *UserDeviceStore) ClearPushToken(ctx context.Context, token, keepFamilyID string) error {
+	q := `UPDATE user_devices SET push_notification_token = NULL, push_provider = NULL
+	WHERE push_notification_token = $1 AND family_id::text <> $2`
+	_, err := s.db.ExecContext(ctx, q, token, keepFamilyID)
+	return err
+}
+
+// nullString stores empty strings as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+const deviceColumns = `id, user_id, family_id, refresh_token_hash, device_name, device_type, push_notification_token, push_provider, last_login_at, created_at, revoked_at`
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanDevice scans a row selected with deviceColumns; it returns nil, nil when there is no row.
+func scanDevice(row rowScanner) (*domain.UserDevice, error) {
 	var d domain.UserDevice
-	if err := row.Scan(&d.ID, &d.UserID, &d.RefreshTokenHash, &d.DeviceName, &d.DeviceType, &d.PushNotificationToken, &d.LastLoginAt, &d.CreatedAt, &d.RevokedAt); err != nil {
+	var name, typ, push, provider sql.NullString
+	var lastLogin sql.NullTime
+	if err := row.Scan(&d.ID, &d.UserID, &d.FamilyID, &d.RefreshTokenHash, &name, &typ, &push, &provider, &lastLogin, &d.CreatedAt, &d.RevokedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
+	d.DeviceName = name.String
+	d.DeviceType = typ.String
+	d.PushNotificationToken = push.String
+	d.PushProvider = provider.String
+	d.LastLoginAt = lastLogin.Time
 	return &d, nil
 }
 
@@ -66,6 +180,12 @@ func (s *UserDeviceStore) RevokeByID(ctx context.Context, id string) error {
 	return err
 }
 
+func (s *UserDeviceStore) RevokeFamily(ctx context.Context, familyID string) error {
+	q := `UPDATE user_devices SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL`
+	_, err := s.db.ExecContext(ctx, q, familyID)
+	return err
+}
+
 func (s *UserDeviceStore) RevokeAllForUser(ctx context.Context, userID string) error {
 	q := `UPDATE user_devices SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`
 	_, err := s.db.ExecContext(ctx, q, userID)
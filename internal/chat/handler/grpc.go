@@ -2,6 +2,10 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/dykethecreator/GoApp/internal/auth/middleware"
 	"github.com/dykethecreator/GoApp/internal/chat/service"
@@ -24,18 +28,12 @@ func NewChatHandler(s *service.ChatService) *ChatHandler { return &ChatHandler{s
 func (h *ChatHandler) Register(s *grpc.Server) { proto.RegisterChatServiceServer(s, h) }
 
 func (h *ChatHandler) CreateConversation(ctx context.Context, req *proto.CreateConversationRequest) (*proto.CreateConversationResponse, error) {
-	id, err := h.svc.CreateConversation(ctx, req.ParticipantIds, req.IsGroup, req.GroupName)
+	userID, _ := middleware.UserIDFromContext(ctx)
+	conv, err := h.svc.CreateConversation(ctx, userID, req.ParticipantIds, req.IsGroup, req.GroupName)
 	if err != nil {
-		return nil, err
+		return nil, chatError(err)
 	}
-	return &proto.CreateConversationResponse{
-		Conversation: &proto.Conversation{
-			Id:             id,
-			ParticipantIds: req.ParticipantIds,
-			IsGroup:        req.IsGroup,
-			GroupName:      req.GroupName,
-		},
-	}, nil
+	return &proto.CreateConversationResponse{Conversation: toProtoConversation(conv)}, nil
 }
 
 func (h *ChatHandler) SendMessage(ctx context.Context, req *proto.SendMessageRequest) (*proto.SendMessageResponse, error) {
@@ -61,10 +59,17 @@ func (h *ChatHandler) SendMessage(ctx context.Context, req *proto.SendMessageReq
 	if req.ClientMessageId != "" {
 		msg.ClientMessageID = &req.ClientMessageId
 	}
+	if req.ReplyToMessageId != "" {
+		replyTo, err := uuid.Parse(req.ReplyToMessageId)
+		if err != nil {
+			return nil, chatError(service.ErrInvalidReply)
+		}
+		msg.ReplyToMessageID = &replyTo
+	}
 
 	m, err := h.svc.SendMessage(ctx, msg)
 	if err != nil {
-		return nil, err
+		return nil, chatError(err)
 	}
 
 	// Broadcast to realtime service (best-effort, non-blocking)
@@ -103,32 +108,60 @@ func (h *ChatHandler) SendMessage(ctx context.Context, req *proto.SendMessageReq
 					MediaUrl:       safeStringPtr(m.MediaURL),
 					MediaType:      safeStringPtr(m.MediaType),
 					CreatedAt:      m.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+					IsSystem:       m.IsSystem,
+					ReplyTo:        toProtoQuote(m.ReplyTo),
 				})
 				break
 			}
 		}
 	}()
 
-	return &proto.SendMessageResponse{Message: &proto.Message{
-		Id:             m.ID.String(),
-		ConversationId: m.ConversationID.String(),
-		SenderId:       m.SenderID.String(),
-		Content:        m.Content,
-	}}, nil
+	return &proto.SendMessageResponse{Message: toProtoMessage(m)}, nil
 }
 
 func (h *ChatHandler) ListMessages(ctx context.Context, req *proto.ListMessagesRequest) (*proto.ListMessagesResponse, error) {
-	items, err := h.svc.ListMessages(ctx, req.ConversationId, req.BeforeMessageId, int(req.Limit))
+	userID, _ := middleware.UserIDFromContext(ctx)
+	items, err := h.svc.ListMessages(ctx, userID, req.ConversationId, req.BeforeMessageId, int(req.Limit))
 	if err != nil {
-		return nil, err
+		return nil, chatError(err)
 	}
 	out := make([]*proto.Message, 0, len(items))
 	for _, m := range items {
-		out = append(out, &proto.Message{Id: m.ID.String(), ConversationId: m.ConversationID.String(), Content: m.Content})
+		out = append(out, toProtoMessage(m))
 	}
 	return &proto.ListMessagesResponse{Messages: out}, nil
 }
 
+// EditMessage replaces the content of one of the caller's messages and sends
+// the new content to the conversation's participants.
+func (h *ChatHandler) EditMessage(ctx context.Context, req *proto.EditMessageRequest) (*proto.EditMessageResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	m, err := h.svc.EditMessage(ctx, userID, req.MessageId, req.Content)
+	if err != nil {
+		return nil, chatError(err)
+	}
+	out := toProtoMessage(m)
+
+	go func() {
+		participantIDs, err := h.svc.Participants(context.Background(), out.ConversationId)
+		if err != nil {
+			log.Printf("[Chat] Failed to load participants of %s for edit of %s: %v", out.ConversationId, out.Id, err)
+			return
+		}
+		realtime.GetGlobalHub().BroadcastMessageEdited(participantIDs, &proto.MessageEdited{
+			MessageId:      out.Id,
+			ConversationId: out.ConversationId,
+			SenderId:       out.SenderId,
+			Content:        out.Content,
+			EditedAt:       out.EditedAt,
+		})
+	}()
+	return &proto.EditMessageResponse{Message: out}, nil
+}
+
 func (h *ChatHandler) GetConversations(ctx context.Context, req *proto.GetConversationsRequest) (*proto.GetConversationsResponse, error) {
 	// Extract user_id from interceptor context
 	userIDStr, ok := middleware.UserIDFromContext(ctx)
@@ -150,26 +183,422 @@ func (h *ChatHandler) GetConversations(ctx context.Context, req *proto.GetConver
 	// Convert to proto
 	out := make([]*proto.Conversation, 0, len(conversations))
 	for _, conv := range conversations {
-		participantIDs := make([]string, len(conv.ParticipantIDs))
-		for i, pid := range conv.ParticipantIDs {
-			participantIDs[i] = pid.String()
+		out = append(out, toProtoConversation(conv))
+	}
+
+	return &proto.GetConversationsResponse{Conversations: out}, nil
+}
+
+// MuteConversation mutes or unmutes push notifications of a conversation for the caller.
+func (h *ChatHandler) MuteConversation(ctx context.Context, req *proto.MuteConversationRequest) (*proto.MuteConversationResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	until, err := h.svc.MuteConversation(ctx, userID, req.ConversationId, time.Duration(req.DurationSeconds)*time.Second, req.Forever)
+	if err != nil {
+		return nil, chatError(err)
+	}
+	return &proto.MuteConversationResponse{MutedUntil: mutedUntil(until)}, nil
+}
+
+// DeleteMessage deletes a message for the caller or for everyone. The
+// deletion is sent to the participants, or to the caller's other devices
+// when it only applies to the caller.
+func (h *ChatHandler) DeleteMessage(ctx context.Context, req *proto.DeleteMessageRequest) (*proto.DeleteMessageResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	m, err := h.svc.DeleteMessage(ctx, userID, req.MessageId, req.ForEveryone)
+	if err != nil {
+		return nil, chatError(err)
+	}
+	deletion := &proto.MessageDeleted{
+		MessageId:      m.ID.String(),
+		ConversationId: m.ConversationID.String(),
+		DeletedBy:      userID,
+		ForEveryone:    req.ForEveryone,
+	}
+
+	go func() {
+		recipientIDs := []string{userID}
+		if req.ForEveryone {
+			var err error
+			if recipientIDs, err = h.svc.Participants(context.Background(), deletion.ConversationId); err != nil {
+				log.Printf("[Chat] Failed to load participants of %s for deletion of %s: %v", deletion.ConversationId, deletion.MessageId, err)
+				return
+			}
 		}
+		realtime.GetGlobalHub().BroadcastMessageDeleted(recipientIDs, deletion)
+	}()
+	return &proto.DeleteMessageResponse{}, nil
+}
+
+// ReactToMessage sets the caller's reaction to a message and sends the change
+// to the conversation's participants.
+func (h *ChatHandler) ReactToMessage(ctx context.Context, req *proto.ReactToMessageRequest) (*proto.ReactToMessageResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	m, err := h.svc.ReactToMessage(ctx, userID, req.MessageId, req.Emoji)
+	if err != nil {
+		return nil, chatError(err)
+	}
+	h.broadcastReaction(m, userID, req.Emoji)
+	return &proto.ReactToMessageResponse{Reactions: toProtoReactions(m.Reactions)}, nil
+}
 
-		groupName := ""
-		if conv.GroupName != nil {
-			groupName = *conv.GroupName
+// RemoveReaction removes the caller's reaction to a message and sends the
+// change to the conversation's participants.
+func (h *ChatHandler) RemoveReaction(ctx context.Context, req *proto.RemoveReactionRequest) (*proto.RemoveReactionResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	m, err := h.svc.RemoveReaction(ctx, userID, req.MessageId)
+	if err != nil {
+		return nil, chatError(err)
+	}
+	h.broadcastReaction(m, userID, "")
+	return &proto.RemoveReactionResponse{Reactions: toProtoReactions(m.Reactions)}, nil
+}
+
+// AddMembers adds users to a group the caller administers and announces each
+// of them in the group.
+func (h *ChatHandler) AddMembers(ctx context.Context, req *proto.AddMembersRequest) (*proto.AddMembersResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	added, err := h.svc.AddMembers(ctx, userID, req.ConversationId, req.UserIds)
+	if err != nil {
+		return nil, chatError(err)
+	}
+	for _, id := range added {
+		h.announce(ctx, req.ConversationId, id, "was added")
+	}
+	return &proto.AddMembersResponse{AddedUserIds: added}, nil
+}
+
+// RemoveMember removes a user from a group the caller administers, or the
+// caller from any group.
+func (h *ChatHandler) RemoveMember(ctx context.Context, req *proto.RemoveMemberRequest) (*proto.RemoveMemberResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	if req.UserId == userID {
+		promoted, err := h.leave(ctx, userID, req.ConversationId)
+		if err != nil {
+			return nil, err
 		}
+		return &proto.RemoveMemberResponse{PromotedUserId: promoted}, nil
+	}
+	if _, err := h.svc.RemoveMember(ctx, userID, req.ConversationId, req.UserId); err != nil {
+		return nil, chatError(err)
+	}
+	h.announce(ctx, req.ConversationId, req.UserId, "was removed", req.UserId)
+	return &proto.RemoveMemberResponse{}, nil
+}
+
+// LeaveGroup removes the caller from a group.
+func (h *ChatHandler) LeaveGroup(ctx context.Context, req *proto.LeaveGroupRequest) (*proto.LeaveGroupResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	promoted, err := h.leave(ctx, userID, req.ConversationId)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.LeaveGroupResponse{PromotedUserId: promoted}, nil
+}
+
+func (h *ChatHandler) leave(ctx context.Context, userID, conversationID string) (string, error) {
+	promoted, err := h.svc.LeaveGroup(ctx, userID, conversationID)
+	if err != nil {
+		return "", chatError(err)
+	}
+	if _, err := h.svc.Participants(ctx, conversationID); errors.Is(err, service.ErrConversationNotFound) {
+		return "", nil // the group is gone with its last member
+	}
+	h.announce(ctx, conversationID, userID, "left", userID)
+	if promoted != "" {
+		h.announce(ctx, conversationID, promoted, "is now an admin")
+	}
+	return promoted, nil
+}
+
+// PromoteAdmin makes a member of a group the caller administers an admin.
+func (h *ChatHandler) PromoteAdmin(ctx context.Context, req *proto.PromoteAdminRequest) (*proto.PromoteAdminResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	if err := h.svc.PromoteAdmin(ctx, userID, req.ConversationId, req.UserId); err != nil {
+		return nil, chatError(err)
+	}
+	h.announce(ctx, req.ConversationId, req.UserId, "is now an admin")
+	return &proto.PromoteAdminResponse{}, nil
+}
+
+// DemoteAdmin makes an admin of a group the caller administers a plain member.
+func (h *ChatHandler) DemoteAdmin(ctx context.Context, req *proto.DemoteAdminRequest) (*proto.DemoteAdminResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	if err := h.svc.DemoteAdmin(ctx, userID, req.ConversationId, req.UserId); err != nil {
+		return nil, chatError(err)
+	}
+	h.announce(ctx, req.ConversationId, req.UserId, "is no longer an admin")
+	return &proto.DemoteAdminResponse{}, nil
+}
+
+// UpdateGroupInfo changes the name, description or icon of a group and
+// announces each change in the group.
+func (h *ChatHandler) UpdateGroupInfo(ctx context.Context, req *proto.UpdateGroupInfoRequest) (*proto.UpdateGroupInfoResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	conv, changed, err := h.svc.UpdateGroupInfo(ctx, userID, req.ConversationId, service.GroupInfo{
+		Name:        req.GroupName,
+		Description: req.GroupDescription,
+		IconURL:     req.GroupIconUrl,
+	})
+	if err != nil {
+		return nil, chatError(err)
+	}
+	if changed.Name != nil {
+		h.announce(ctx, req.ConversationId, userID, fmt.Sprintf("changed the group name to %q", *changed.Name))
+	}
+	if changed.Description != nil {
+		h.announce(ctx, req.ConversationId, userID, changedOrRemoved(*changed.Description, "the group description"))
+	}
+	if changed.IconURL != nil {
+		h.announce(ctx, req.ConversationId, userID, changedOrRemoved(*changed.IconURL, "the group icon"))
+	}
+	return &proto.UpdateGroupInfoResponse{Conversation: toProtoConversation(conv)}, nil
+}
+
+// UpdateGroupSettings changes the settings of a group the caller administers
+// and announces each change in the group.
+func (h *ChatHandler) UpdateGroupSettings(ctx context.Context, req *proto.UpdateGroupSettingsRequest) (*proto.UpdateGroupSettingsResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	conv, changed, err := h.svc.UpdateGroupSettings(ctx, userID, req.ConversationId, service.GroupSettings{
+		OnlyAdminsEditInfo: req.OnlyAdminsEditInfo,
+		AnnouncementMode:   req.AnnouncementMode,
+	})
+	if err != nil {
+		return nil, chatError(err)
+	}
+	if changed.OnlyAdminsEditInfo != nil {
+		h.announce(ctx, req.ConversationId, userID, onlyAdminsOrAll(*changed.OnlyAdminsEditInfo, "edit the group info"))
+	}
+	if changed.AnnouncementMode != nil {
+		h.announce(ctx, req.ConversationId, userID, onlyAdminsOrAll(*changed.AnnouncementMode, "send messages"))
+	}
+	return &proto.UpdateGroupSettingsResponse{Conversation: toProtoConversation(conv)}, nil
+}
+
+// CreateInvite creates an invite link to a group the caller administers.
+func (h *ChatHandler) CreateInvite(ctx context.Context, req *proto.CreateInviteRequest) (*proto.CreateInviteResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	inv, err := h.svc.CreateInvite(ctx, userID, req.ConversationId, time.Duration(req.ExpiresInSeconds)*time.Second, int(req.MaxUses), req.RequiresApproval)
+	if err != nil {
+		return nil, chatError(err)
+	}
+	return &proto.CreateInviteResponse{Invite: toProtoInvite(inv)}, nil
+}
+
+// ListInvites lists the invite links of a group the caller administers.
+func (h *ChatHandler) ListInvites(ctx context.Context, req *proto.ListInvitesRequest) (*proto.ListInvitesResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	invites, err := h.svc.ListInvites(ctx, userID, req.ConversationId)
+	if err != nil {
+		return nil, chatError(err)
+	}
+	out := make([]*proto.GroupInvite, 0, len(invites))
+	for _, inv := range invites {
+		out = append(out, toProtoInvite(inv))
+	}
+	return &proto.ListInvitesResponse{Invites: out}, nil
+}
+
+// RevokeInvite revokes an invite link of a group the caller administers.
+func (h *ChatHandler) RevokeInvite(ctx context.Context, req *proto.RevokeInviteRequest) (*proto.RevokeInviteResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	if err := h.svc.RevokeInvite(ctx, userID, req.ConversationId, req.Token); err != nil {
+		return nil, chatError(err)
+	}
+	return &proto.RevokeInviteResponse{}, nil
+}
+
+// JoinViaInvite adds the caller to the group of an invite link and announces
+// them in the group, or files a join request.
+func (h *ChatHandler) JoinViaInvite(ctx context.Context, req *proto.JoinViaInviteRequest) (*proto.JoinViaInviteResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	conv, joined, pending, err := h.svc.JoinViaInvite(ctx, userID, req.Token)
+	if err != nil {
+		return nil, chatError(err)
+	}
+	if joined {
+		h.announce(ctx, conv.ID.String(), userID, "joined using an invite link")
+	}
+	return &proto.JoinViaInviteResponse{Conversation: toProtoConversation(conv), Pending: pending}, nil
+}
+
+// ListJoinRequests lists the pending join requests of a group the caller administers.
+func (h *ChatHandler) ListJoinRequests(ctx context.Context, req *proto.ListJoinRequestsRequest) (*proto.ListJoinRequestsResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	requests, err := h.svc.ListJoinRequests(ctx, userID, req.ConversationId)
+	if err != nil {
+		return nil, chatError(err)
+	}
+	out := make([]*proto.JoinRequest, 0, len(requests))
+	for _, r := range requests {
+		out = append(out, &proto.JoinRequest{UserId: r.UserID.String(), RequestedAt: r.RequestedAt.Format(time.RFC3339)})
+	}
+	return &proto.ListJoinRequestsResponse{Requests: out}, nil
+}
 
-		out = append(out, &proto.Conversation{
-			Id:             conv.ID.String(),
-			ParticipantIds: participantIDs,
-			IsGroup:        conv.IsGroup,
-			GroupName:      groupName,
-			CreatedAt:      conv.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+// ApproveJoinRequest adds a user who requested to join a group the caller
+// administers and announces them in the group.
+func (h *ChatHandler) ApproveJoinRequest(ctx context.Context, req *proto.ApproveJoinRequestRequest) (*proto.ApproveJoinRequestResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	if err := h.svc.ApproveJoinRequest(ctx, userID, req.ConversationId, req.UserId); err != nil {
+		return nil, chatError(err)
+	}
+	h.announce(ctx, req.ConversationId, req.UserId, "joined using an invite link")
+	return &proto.ApproveJoinRequestResponse{}, nil
+}
+
+// DeclineJoinRequest drops a request to join a group the caller administers.
+func (h *ChatHandler) DeclineJoinRequest(ctx context.Context, req *proto.DeclineJoinRequestRequest) (*proto.DeclineJoinRequestResponse, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user_id not found in context")
+	}
+	if err := h.svc.DeclineJoinRequest(ctx, userID, req.ConversationId, req.UserId); err != nil {
+		return nil, chatError(err)
+	}
+	return &proto.DeclineJoinRequestResponse{}, nil
+}
+
+func changedOrRemoved(value, what string) string {
+	if value == "" {
+		return "removed " + what
+	}
+	return "changed " + what
+}
+
+func onlyAdminsOrAll(onlyAdmins bool, what string) string {
+	if onlyAdmins {
+		return "allowed only admins to " + what
+	}
+	return "allowed all members to " + what
+}
+
+// announce posts a system message about userID to a group and sends it to the
+// participants and to alsoTo, e.g. a member who was just removed. The change
+// it announces is already made, so failures are only logged.
+func (h *ChatHandler) announce(ctx context.Context, conversationID, userID, content string, alsoTo ...string) {
+	m, err := h.svc.PostSystemMessage(ctx, conversationID, userID, content)
+	if err != nil {
+		log.Printf("[Chat] Failed to post %q about %s in %s: %v", content, userID, conversationID, err)
+		return
+	}
+
+	go func() {
+		participantIDs, err := h.svc.Participants(context.Background(), conversationID)
+		if err != nil {
+			log.Printf("[Chat] Failed to load participants of %s for system message %s: %v", conversationID, m.ID, err)
+			return
+		}
+		realtime.GetGlobalHub().BroadcastMessage(conversationID, append(participantIDs, alsoTo...), &proto.NewMessage{
+			MessageId:      m.ID.String(),
+			ConversationId: m.ConversationID.String(),
+			SenderId:       m.SenderID.String(),
+			Content:        m.Content,
+			CreatedAt:      m.CreatedAt.Format(time.RFC3339),
+			IsSystem:       true,
 		})
+	}()
+}
+
+func (h *ChatHandler) broadcastReaction(m *domain.ChatMessage, userID, emoji string) {
+	update := &proto.ReactionUpdated{
+		MessageId:      m.ID.String(),
+		ConversationId: m.ConversationID.String(),
+		UserId:         userID,
+		Emoji:          emoji,
+		Counts:         make(map[string]int32, len(m.Reactions)),
+	}
+	for _, r := range m.Reactions {
+		update.Counts[r.Emoji] = int32(r.Count)
 	}
 
-	return &proto.GetConversationsResponse{Conversations: out}, nil
+	go func() {
+		participantIDs, err := h.svc.Participants(context.Background(), update.ConversationId)
+		if err != nil {
+			log.Printf("[Chat] Failed to load participants of %s for reaction to %s: %v", update.ConversationId, update.MessageId, err)
+			return
+		}
+		realtime.GetGlobalHub().BroadcastReactionUpdated(participantIDs, update)
+	}()
+}
+
+// chatError maps chat service errors to gRPC status codes; other errors pass through.
+func chatError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrConversationNotFound):
+		return status.Error(codes.NotFound, "conversation not found")
+	case errors.Is(err, service.ErrBlocked):
+		return status.Error(codes.PermissionDenied, "cannot message this user")
+	case errors.Is(err, service.ErrInvalidMuteDuration), errors.Is(err, service.ErrEmptyMessage),
+		errors.Is(err, service.ErrInvalidReaction), errors.Is(err, service.ErrInvalidReply),
+		errors.Is(err, service.ErrInvalidMembers), errors.Is(err, service.ErrInvalidGroupInfo),
+		errors.Is(err, service.ErrInvalidInvite), errors.Is(err, service.ErrInvalidDirectChat):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrMessageNotFound):
+		return status.Error(codes.NotFound, "message not found")
+	case errors.Is(err, service.ErrNotMember), errors.Is(err, service.ErrInviteNotFound),
+		errors.Is(err, service.ErrJoinRequestNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotMessageSender), errors.Is(err, service.ErrDeleteNotAllowed),
+		errors.Is(err, service.ErrNotAdmin):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrEditWindowExpired), errors.Is(err, service.ErrDeleteWindowExpired),
+		errors.Is(err, service.ErrNotGroup), errors.Is(err, service.ErrLastAdmin),
+		errors.Is(err, service.ErrInviteExpired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
 }
 
 // safeStringPtr returns empty string if pointer is nil
@@ -179,3 +608,109 @@ func safeStringPtr(s *string) string {
 	}
 	return *s
 }
+
+// mutedUntil formats the end of a mute, or "" when the conversation is not
+// muted (any more).
+func mutedUntil(t *time.Time) string {
+	if t == nil || !t.After(time.Now()) {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func toProtoConversation(conv *domain.Conversation) *proto.Conversation {
+	out := &proto.Conversation{
+		Id:                 conv.ID.String(),
+		ParticipantIds:     make([]string, len(conv.ParticipantIDs)),
+		IsGroup:            conv.IsGroup,
+		GroupName:          safeStringPtr(conv.GroupName),
+		GroupDescription:   safeStringPtr(conv.GroupDescription),
+		GroupIconUrl:       safeStringPtr(conv.GroupIconURL),
+		OnlyAdminsEditInfo: conv.OnlyAdminsEditInfo,
+		AnnouncementMode:   conv.AnnouncementMode,
+		CreatedAt:          conv.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		MutedUntil:         mutedUntil(conv.MutedUntil),
+	}
+	for i, pid := range conv.ParticipantIDs {
+		out.ParticipantIds[i] = pid.String()
+	}
+	for _, id := range conv.AdminIDs {
+		out.AdminIds = append(out.AdminIds, id.String())
+	}
+	return out
+}
+
+func toProtoInvite(inv *domain.GroupInvite) *proto.GroupInvite {
+	out := &proto.GroupInvite{
+		Token:            inv.Token,
+		ConversationId:   inv.ConversationID.String(),
+		MaxUses:          int32(inv.MaxUses),
+		UseCount:         int32(inv.UseCount),
+		RequiresApproval: inv.RequiresApproval,
+		CreatedAt:        inv.CreatedAt.Format(time.RFC3339),
+	}
+	if inv.CreatedBy != nil {
+		out.CreatedBy = inv.CreatedBy.String()
+	}
+	if inv.ExpiresAt != nil {
+		out.ExpiresAt = inv.ExpiresAt.Format(time.RFC3339)
+	}
+	return out
+}
+
+func toProtoMessage(m *domain.ChatMessage) *proto.Message {
+	out := &proto.Message{
+		Id:              m.ID.String(),
+		ConversationId:  m.ConversationID.String(),
+		SenderId:        senderID(m),
+		Content:         m.Content,
+		MediaUrl:        safeStringPtr(m.MediaURL),
+		MediaType:       safeStringPtr(m.MediaType),
+		ClientMessageId: safeStringPtr(m.ClientMessageID),
+		CreatedAt:       m.CreatedAt.Format(time.RFC3339),
+		IsSystem:        m.IsSystem,
+	}
+	if m.EditedAt != nil {
+		out.EditedAt = m.EditedAt.Format(time.RFC3339)
+	}
+	if m.DeletedAt != nil {
+		out.DeletedAt = m.DeletedAt.Format(time.RFC3339)
+	}
+	if m.DeletedBy != nil {
+		out.DeletedBy = m.DeletedBy.String()
+	}
+	out.Reactions = toProtoReactions(m.Reactions)
+	out.ReplyTo = toProtoQuote(m.ReplyTo)
+	return out
+}
+
+func toProtoQuote(q *domain.QuotedMessage) *proto.QuotedMessage {
+	if q == nil {
+		return nil
+	}
+	out := &proto.QuotedMessage{MessageId: q.MessageID.String(), Snippet: q.Snippet, MediaType: q.MediaType, Deleted: q.Deleted}
+	if q.SenderID != nil {
+		out.SenderId = q.SenderID.String()
+	}
+	return out
+}
+
+func toProtoReactions(reactions []*domain.ReactionCount) []*proto.Reaction {
+	out := make([]*proto.Reaction, 0, len(reactions))
+	for _, r := range reactions {
+		userIDs := make([]string, len(r.UserIDs))
+		for i, id := range r.UserIDs {
+			userIDs[i] = id.String()
+		}
+		out = append(out, &proto.Reaction{Emoji: r.Emoji, Count: int32(r.Count), UserIds: userIDs})
+	}
+	return out
+}
+
+// senderID returns the sender of m, or "" for messages of deleted accounts.
+func senderID(m *domain.ChatMessage) string {
+	if m.SenderID == uuid.Nil {
+		return ""
+	}
+	return m.SenderID.String()
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/chat/service"
+	"github.com/dykethecreator/GoApp/internal/realtime"
+	"github.com/dykethecreator/GoApp/proto"
+)
+
+// SystemNotifier posts system messages to a user's conversations and pushes
+// them to the participants. Other services use it for account events.
+//
+// Messages are pushed through the in-process hub, so only participants with a
+// stream in the same process get them live, i.e. in all_in_one. In a split
+// deployment they are stored and read with ListMessages.
+type SystemNotifier struct {
+	svc *service.ChatService
+}
+
+func NewSystemNotifier(s *service.ChatService) *SystemNotifier { return &SystemNotifier{svc: s} }
+
+// NotifyNumberChanged posts "changed their phone number" in every conversation of the user.
+func (n *SystemNotifier) NotifyNumberChanged(ctx context.Context, userID string) error {
+	return n.postToAll(ctx, userID, "changed their phone number")
+}
+
+func (n *SystemNotifier) postToAll(ctx context.Context, userID, content string) error {
+	conversations, err := n.svc.ListConversations(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, conv := range conversations {
+		m, err := n.svc.PostSystemMessage(ctx, conv.ID.String(), userID, content)
+		if err != nil {
+			return err
+		}
+		participantIDs := make([]string, len(conv.ParticipantIDs))
+		for i, pid := range conv.ParticipantIDs {
+			participantIDs[i] = pid.String()
+		}
+		log.Printf("[Chat] System message %s in %s: %s", m.ID, conv.ID, content)
+		go realtime.GetGlobalHub().BroadcastMessage(conv.ID.String(), participantIDs, &proto.NewMessage{
+			MessageId:      m.ID.String(),
+			ConversationId: m.ConversationID.String(),
+			SenderId:       m.SenderID.String(),
+			Content:        m.Content,
+			CreatedAt:      m.CreatedAt.Format(time.RFC3339),
+			IsSystem:       true,
+		})
+	}
+	return nil
+}
@@ -2,16 +2,89 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/dykethecreator/GoApp/pkg/domain"
 )
 
 type ChatRepository interface {
-	CreateConversation(ctx context.Context, participantIDs []string, isGroup bool, groupName string) (string, error)
-	AddParticipant(ctx context.Context, conversationID, userID string) error
+	// CreateConversation creates a conversation; creatorID becomes the admin of a group.
+	CreateConversation(ctx context.Context, creatorID string, participantIDs []string, isGroup bool, groupName string) (string, error)
+	// AddParticipants adds the existing users among userIDs that are not yet
+	// participants as members, recording actorID added them. It returns the
+	// IDs it added.
+	AddParticipants(ctx context.Context, conversationID, actorID string, userIDs []string) ([]string, error)
+	// RemoveParticipant removes the user, recording action (removed or left)
+	// by actorID. If the last admin of a group goes, the longest-standing
+	// participant is promoted and returned; a group nobody is left in is
+	// deleted. It returns false if the user was not a participant.
+	RemoveParticipant(ctx context.Context, conversationID, userID, actorID string, action domain.MembershipAction) (removed bool, promotedID string, err error)
+	// SetParticipantRole changes the participant's role, recording actorID
+	// changed it. It returns false if the user is not a participant, already
+	// has the role, or is the last admin being demoted.
+	SetParticipantRole(ctx context.Context, conversationID, userID, actorID string, role domain.ChatMemberRole) (bool, error)
+	// UpdateGroupInfo changes the info of a group; nil values are left
+	// unchanged and an empty description or icon URL removes it. It returns
+	// false if there is no such group.
+	UpdateGroupInfo(ctx context.Context, conversationID string, name, description, iconURL *string) (bool, error)
+	// UpdateGroupSettings changes the settings of a group; nil values are left
+	// unchanged. It returns false if there is no such group.
+	UpdateGroupSettings(ctx context.Context, conversationID string, onlyAdminsEditInfo, announcementMode *bool) (bool, error)
+	CreateInvite(ctx context.Context, inv *domain.GroupInvite) (*domain.GroupInvite, error)
+	// GetInvite returns nil if no invite has the token.
+	GetInvite(ctx context.Context, token string) (*domain.GroupInvite, error)
+	// ListInvites returns the group's invites that were not revoked, newest first.
+	ListInvites(ctx context.Context, conversationID string) ([]*domain.GroupInvite, error)
+	// RevokeInvite returns false if the group has no unrevoked invite with the token.
+	RevokeInvite(ctx context.Context, conversationID, token string) (bool, error)
+	// UseInvite lets the user join the invite's group, or files a join request
+	// if the invite requires approval, counting a use only if the user was
+	// added or the request is new. A user with a pending request gets true
+	// back without a use. Otherwise it returns false if the invite is revoked,
+	// expired or used up.
+	UseInvite(ctx context.Context, inviteID, userID string) (bool, error)
+	// ListJoinRequests returns the group's pending join requests, oldest first.
+	ListJoinRequests(ctx context.Context, conversationID string) ([]*domain.JoinRequest, error)
+	// ResolveJoinRequest removes the user's pending request, adding the user
+	// to the group if approve is set and otherwise giving its use of the
+	// invite back. It returns false if there is no such request.
+	ResolveJoinRequest(ctx context.Context, conversationID, userID, actorID string, approve bool) (bool, error)
+	// ParticipantRole returns the user's role in the conversation, or "" if the user is not a participant.
+	ParticipantRole(ctx context.Context, conversationID, userID string) (domain.ChatMemberRole, error)
 
 	InsertMessage(ctx context.Context, m *domain.ChatMessage) (*domain.ChatMessage, error)
-	ListMessages(ctx context.Context, conversationID string, beforeID string, limit int) ([]*domain.ChatMessage, error)
+	// ListMessages returns the conversation's messages newest first, without
+	// those userID deleted for themselves.
+	ListMessages(ctx context.Context, conversationID, userID string, beforeID string, limit int) ([]*domain.ChatMessage, error)
+	// GetMessage returns the message, or nil if it does not exist.
+	GetMessage(ctx context.Context, messageID string) (*domain.ChatMessage, error)
+	// GetMessages returns the messages that exist among messageIDs, in no particular order.
+	GetMessages(ctx context.Context, messageIDs []string) ([]*domain.ChatMessage, error)
+	// EditMessage replaces the content of the message, keeping the previous
+	// version in its edit history. It returns nil if the message does not
+	// exist or was deleted.
+	EditMessage(ctx context.Context, messageID, content string) (*domain.ChatMessage, error)
+	// DeleteMessage turns the message into a tombstone deleted by deletedBy,
+	// clearing its content, media, edit history and reactions. It returns nil if the
+	// message does not exist or was already deleted.
+	DeleteMessage(ctx context.Context, messageID, deletedBy string) (*domain.ChatMessage, error)
+	// HideMessage deletes the message for userID only.
+	HideMessage(ctx context.Context, messageID, userID string) error
+
+	// SetReaction adds the user's reaction to the message or replaces its emoji.
+	SetReaction(ctx context.Context, messageID, userID, emoji string) error
+	// RemoveReaction removes the user's reaction; it returns false if there was none.
+	RemoveReaction(ctx context.Context, messageID, userID string) (bool, error)
+	// ListReactions returns the reactions to the messages, oldest first.
+	ListReactions(ctx context.Context, messageIDs []string) ([]*domain.MessageReaction, error)
 
 	ListConversations(ctx context.Context, userID string) ([]*domain.Conversation, error)
+	// GetConversation returns the conversation with its participant IDs, or nil if it does not exist.
+	GetConversation(ctx context.Context, conversationID string) (*domain.Conversation, error)
+
+	// SetMutedUntil mutes the conversation for the participant until the given
+	// time, or unmutes it for nil. It returns false if userID is not a participant.
+	SetMutedUntil(ctx context.Context, conversationID, userID string, until *time.Time) (bool, error)
+	// MutedParticipants returns which of the users have the conversation muted at the given time.
+	MutedParticipants(ctx context.Context, conversationID string, userIDs []string, at time.Time) (map[string]bool, error)
 }
@@ -2,29 +2,938 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/dykethecreator/GoApp/internal/chat/repository"
+	"github.com/dykethecreator/GoApp/pkg/config"
 	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
 )
 
+var (
+	ErrConversationNotFound = errors.New("conversation not found")
+	ErrBlocked              = errors.New("blocked")
+	ErrInvalidMuteDuration  = errors.New("mute duration must not be negative")
+	ErrMessageNotFound      = errors.New("message not found")
+	ErrNotMessageSender     = errors.New("only the sender may change a message")
+	ErrEditWindowExpired    = errors.New("message can no longer be edited")
+	ErrEmptyMessage         = errors.New("message content must not be empty")
+	ErrDeleteNotAllowed     = errors.New("only the sender or a group admin may delete a message for everyone")
+	ErrDeleteWindowExpired  = errors.New("message can no longer be deleted for everyone")
+	ErrInvalidReaction      = errors.New("reaction must be a single emoji")
+	ErrInvalidReply         = errors.New("reply must quote a message of the same conversation")
+	ErrNotGroup             = errors.New("conversation is not a group")
+	ErrNotAdmin             = errors.New("only a group admin may do this")
+	ErrNotMember            = errors.New("user is not a member of the group")
+	ErrLastAdmin            = errors.New("the last admin of a group cannot be demoted")
+	ErrInvalidMembers       = errors.New("user IDs must be valid and not empty")
+	ErrInvalidGroupInfo     = errors.New("invalid group info")
+	ErrInvalidInvite        = errors.New("invite expiry and max uses must not be negative")
+	ErrInviteNotFound       = errors.New("invite not found")
+	ErrInviteExpired        = errors.New("invite has expired or was used up")
+	ErrJoinRequestNotFound  = errors.New("join request not found")
+	ErrInvalidDirectChat    = errors.New("a 1:1 conversation needs the caller and exactly one other user")
+)
+
+const (
+	maxReactionBytes          = 32  // message_reactions.reaction_emoji
+	maxSnippetLength          = 100 // runes of a quoted message shown in a reply
+	maxGroupNameLength        = 100
+	maxGroupDescriptionLength = 512
+	maxGroupIconURLLength     = 2048
+)
+
+// Default limits, used when none are configured.
+const (
+	DefaultEditWindow   = 15 * time.Minute
+	DefaultDeleteWindow = 48 * time.Hour
+)
+
+// BlockChecker reports whether either of two users has blocked the other.
+type BlockChecker interface {
+	IsBlocked(ctx context.Context, userA, userB string) (bool, error)
+}
+
 type ChatService struct {
-	repo repository.ChatRepository
+	repo         repository.ChatRepository
+	blocks       BlockChecker // nil disables block enforcement
+	editWindow   time.Duration
+	deleteWindow time.Duration
+}
+
+// NewChatService creates a ChatService with the default limits.
+func NewChatService(r repository.ChatRepository, blocks BlockChecker) *ChatService {
+	return NewChatServiceWithConfig(r, blocks, config.ChatConfig{})
 }
 
-func NewChatService(r repository.ChatRepository) *ChatService { return &ChatService{repo: r} }
+// NewChatServiceWithConfig creates a ChatService with the limits in cfg; zero
+// limits use the defaults.
+func NewChatServiceWithConfig(r repository.ChatRepository, blocks BlockChecker, cfg config.ChatConfig) *ChatService {
+	s := &ChatService{repo: r, blocks: blocks, editWindow: cfg.EditWindow, deleteWindow: cfg.DeleteWindow}
+	if s.editWindow <= 0 {
+		s.editWindow = DefaultEditWindow
+	}
+	if s.deleteWindow <= 0 {
+		s.deleteWindow = DefaultDeleteWindow
+	}
+	return s
+}
 
-func (s *ChatService) CreateConversation(ctx context.Context, participantIDs []string, isGroup bool, groupName string) (string, error) {
-	return s.repo.CreateConversation(ctx, participantIDs, isGroup, groupName)
+// CreateConversation creates a conversation; the creator always takes part and
+// becomes a group's admin. A 1:1 conversation is between the creator and
+// exactly one other user, who must not be separated from them by a block.
+func (s *ChatService) CreateConversation(ctx context.Context, creatorID string, participantIDs []string, isGroup bool, groupName string) (*domain.Conversation, error) {
+	if !slices.Contains(participantIDs, creatorID) {
+		participantIDs = append([]string{creatorID}, participantIDs...)
+	}
+	if !isGroup {
+		participantIDs = slices.Compact(slices.Sorted(slices.Values(participantIDs)))
+		if len(participantIDs) != 2 {
+			return nil, ErrInvalidDirectChat
+		}
+		if err := s.checkBlocks(ctx, participantIDs); err != nil {
+			return nil, err
+		}
+	}
+	id, err := s.repo.CreateConversation(ctx, creatorID, participantIDs, isGroup, groupName)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetConversation(ctx, id)
 }
 
+// SendMessage stores a message. Only participants may send, and only admins
+// in announcement mode; in 1:1 conversations it fails with ErrBlocked when
+// either participant has blocked the other. A reply must quote a message of
+// the same conversation that was not deleted; the returned message carries
+// its preview.
 func (s *ChatService) SendMessage(ctx context.Context, m *domain.ChatMessage) (*domain.ChatMessage, error) {
-	return s.repo.InsertMessage(ctx, m)
+	role, err := s.repo.ParticipantRole(ctx, m.ConversationID.String(), m.SenderID.String())
+	if err != nil {
+		return nil, err
+	}
+	if role == "" {
+		return nil, ErrConversationNotFound
+	}
+	var quoted *domain.ChatMessage
+	if m.ReplyToMessageID != nil {
+		if quoted, err = s.repo.GetMessage(ctx, m.ReplyToMessageID.String()); err != nil {
+			return nil, err
+		}
+		if quoted == nil || quoted.ConversationID != m.ConversationID || quoted.DeletedAt != nil {
+			return nil, ErrInvalidReply
+		}
+	}
+	conv, err := s.repo.GetConversation(ctx, m.ConversationID.String())
+	if err != nil {
+		return nil, err
+	}
+	if conv == nil {
+		return nil, ErrConversationNotFound
+	}
+	if conv.IsGroup && conv.AnnouncementMode && role != domain.AdminRole {
+		return nil, ErrNotAdmin
+	}
+	if s.blocks != nil {
+		if !conv.IsGroup {
+			ids := make([]string, 0, len(conv.ParticipantIDs)+1)
+			ids = append(ids, m.SenderID.String())
+			for _, pid := range conv.ParticipantIDs {
+				ids = append(ids, pid.String())
+			}
+			if err := s.checkBlocks(ctx, ids); err != nil {
+				return nil, err
+			}
+		}
+	}
+	m, err = s.repo.InsertMessage(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	if quoted != nil {
+		m.ReplyTo = quote(quoted)
+	}
+	return m, nil
 }
 
-func (s *ChatService) ListMessages(ctx context.Context, conversationID, beforeID string, limit int) ([]*domain.ChatMessage, error) {
-	return s.repo.ListMessages(ctx, conversationID, beforeID, limit)
+// PostSystemMessage adds a system message about userID to the conversation,
+// e.g. "changed their phone number". Blocks do not apply.
+func (s *ChatService) PostSystemMessage(ctx context.Context, conversationID, userID, content string) (*domain.ChatMessage, error) {
+	convID, err := uuid.Parse(conversationID)
+	if err != nil {
+		return nil, ErrConversationNotFound
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.InsertMessage(ctx, &domain.ChatMessage{ConversationID: convID, SenderID: uid, Content: content, IsSystem: true})
+}
+
+// ListMessages returns the messages of the conversation the user has not
+// deleted for themselves, newest first. Messages deleted for everyone are
+// returned as tombstones.
+func (s *ChatService) ListMessages(ctx context.Context, userID, conversationID, beforeID string, limit int) ([]*domain.ChatMessage, error) {
+	if _, err := uuid.Parse(conversationID); err != nil {
+		return nil, ErrConversationNotFound
+	}
+	role, err := s.repo.ParticipantRole(ctx, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" {
+		return nil, ErrConversationNotFound
+	}
+	msgs, err := s.repo.ListMessages(ctx, conversationID, userID, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(ctx, msgs...); err != nil {
+		return nil, err
+	}
+	if err := s.attachQuotes(ctx, msgs...); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// EditMessage replaces the content of a message the user sent within the
+// edit window. The previous content is kept in the message's edit history.
+func (s *ChatService) EditMessage(ctx context.Context, userID, messageID, content string) (*domain.ChatMessage, error) {
+	// Senders who have left the conversation can no longer edit
+	m, _, err := s.participantMessage(ctx, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if m.DeletedAt != nil {
+		return nil, ErrMessageNotFound
+	}
+	if m.IsSystem || m.SenderID.String() != userID {
+		return nil, ErrNotMessageSender
+	}
+	if time.Since(m.CreatedAt) > s.editWindow {
+		return nil, ErrEditWindowExpired
+	}
+	// Media messages may drop their caption; text messages need some text
+	if strings.TrimSpace(content) == "" && m.MediaURL == nil {
+		return nil, ErrEmptyMessage
+	}
+	if content == m.Content {
+		return m, nil
+	}
+	edited, err := s.repo.EditMessage(ctx, messageID, content)
+	if err != nil {
+		return nil, err
+	}
+	if edited == nil {
+		return nil, ErrMessageNotFound
+	}
+	return edited, nil
+}
+
+// DeleteMessage deletes a message for the user only, or with forEveryone for
+// all participants. Deleting for everyone is allowed to the sender and to
+// group admins within the delete window; it leaves a tombstone without
+// content or media. Either way the user must be a participant.
+func (s *ChatService) DeleteMessage(ctx context.Context, userID, messageID string, forEveryone bool) (*domain.ChatMessage, error) {
+	m, role, err := s.participantMessage(ctx, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !forEveryone {
+		if err := s.repo.HideMessage(ctx, messageID, userID); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+	if m.DeletedAt != nil {
+		return m, nil
+	}
+	if m.IsSystem || (m.SenderID.String() != userID && role != domain.AdminRole) {
+		return nil, ErrDeleteNotAllowed
+	}
+	if time.Since(m.CreatedAt) > s.deleteWindow {
+		return nil, ErrDeleteWindowExpired
+	}
+	deleted, err := s.repo.DeleteMessage(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if deleted == nil {
+		// Deleted concurrently; report the existing tombstone
+		return s.repo.GetMessage(ctx, messageID)
+	}
+	return deleted, nil
+}
+
+// ReactToMessage sets the user's reaction to a message, replacing the emoji
+// the user reacted with before. It returns the message with its reactions.
+func (s *ChatService) ReactToMessage(ctx context.Context, userID, messageID, emoji string) (*domain.ChatMessage, error) {
+	if !validReaction(emoji) {
+		return nil, ErrInvalidReaction
+	}
+	m, _, err := s.participantMessage(ctx, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if m.DeletedAt != nil {
+		return nil, ErrMessageNotFound
+	}
+	if err := s.repo.SetReaction(ctx, messageID, userID, emoji); err != nil {
+		return nil, err
+	}
+	return m, s.attachReactions(ctx, m)
+}
+
+// RemoveReaction removes the user's reaction to a message, if any. It returns
+// the message with its remaining reactions.
+func (s *ChatService) RemoveReaction(ctx context.Context, userID, messageID string) (*domain.ChatMessage, error) {
+	m, _, err := s.participantMessage(ctx, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.RemoveReaction(ctx, messageID, userID); err != nil {
+		return nil, err
+	}
+	return m, s.attachReactions(ctx, m)
+}
+
+// attachReactions sets the reaction counts of the messages, most frequent
+// emoji first.
+func (s *ChatService) attachReactions(ctx context.Context, msgs ...*domain.ChatMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	ids := make([]string, len(msgs))
+	byID := make(map[uuid.UUID]*domain.ChatMessage, len(msgs))
+	for i, m := range msgs {
+		ids[i] = m.ID.String()
+		byID[m.ID] = m
+		m.Reactions = nil
+	}
+	reactions, err := s.repo.ListReactions(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, r := range reactions {
+		m := byID[r.MessageID]
+		if m == nil {
+			continue
+		}
+		var count *domain.ReactionCount
+		for _, c := range m.Reactions {
+			if c.Emoji == r.ReactionEmoji {
+				count = c
+				break
+			}
+		}
+		if count == nil {
+			count = &domain.ReactionCount{Emoji: r.ReactionEmoji}
+			m.Reactions = append(m.Reactions, count)
+		}
+		count.Count++
+		count.UserIDs = append(count.UserIDs, r.UserID)
+	}
+	for _, m := range msgs {
+		// Stable, so equally frequent emoji stay in the order they were first used
+		sort.SliceStable(m.Reactions, func(i, j int) bool { return m.Reactions[i].Count > m.Reactions[j].Count })
+	}
+	return nil
+}
+
+// attachQuotes sets the preview of the quoted message of every reply.
+func (s *ChatService) attachQuotes(ctx context.Context, msgs ...*domain.ChatMessage) error {
+	var ids []string
+	for _, m := range msgs {
+		if m.ReplyToMessageID != nil {
+			ids = append(ids, m.ReplyToMessageID.String())
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	quoted, err := s.repo.GetMessages(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uuid.UUID]*domain.ChatMessage, len(quoted))
+	for _, q := range quoted {
+		byID[q.ID] = q
+	}
+	for _, m := range msgs {
+		if m.ReplyToMessageID == nil {
+			continue
+		}
+		if q := byID[*m.ReplyToMessageID]; q != nil {
+			m.ReplyTo = quote(q)
+		} else {
+			m.ReplyTo = &domain.QuotedMessage{MessageID: *m.ReplyToMessageID, Deleted: true}
+		}
+	}
+	return nil
+}
+
+// quote returns the preview of q shown in replies to it.
+func quote(q *domain.ChatMessage) *domain.QuotedMessage {
+	out := &domain.QuotedMessage{MessageID: q.ID}
+	if q.SenderID != uuid.Nil {
+		sender := q.SenderID
+		out.SenderID = &sender
+	}
+	if q.DeletedAt != nil {
+		out.Deleted = true
+		return out
+	}
+	out.Snippet = q.Content
+	if utf8.RuneCountInString(out.Snippet) > maxSnippetLength {
+		out.Snippet = string([]rune(out.Snippet)[:maxSnippetLength]) + "…"
+	}
+	if q.MediaType != nil {
+		out.MediaType = *q.MediaType
+	}
+	return out
+}
+
+// validReaction accepts one emoji, including sequences such as flags, skin
+// tones and keycaps, and rejects text.
+func validReaction(emoji string) bool {
+	if emoji == "" || len(emoji) > maxReactionBytes || !utf8.ValidString(emoji) {
+		return false
+	}
+	ascii := true
+	for _, r := range emoji {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || unicode.IsLetter(r) {
+			return false
+		}
+		if r >= utf8.RuneSelf {
+			ascii = false
+		}
+	}
+	return !ascii
+}
+
+// participantMessage returns the message and the user's role in its
+// conversation. Messages of conversations the user is not part of are
+// reported as not found.
+func (s *ChatService) participantMessage(ctx context.Context, userID, messageID string) (*domain.ChatMessage, domain.ChatMemberRole, error) {
+	if _, err := uuid.Parse(messageID); err != nil {
+		return nil, "", ErrMessageNotFound
+	}
+	m, err := s.repo.GetMessage(ctx, messageID)
+	if err != nil {
+		return nil, "", err
+	}
+	if m == nil {
+		return nil, "", ErrMessageNotFound
+	}
+	role, err := s.repo.ParticipantRole(ctx, m.ConversationID.String(), userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if role == "" {
+		return nil, "", ErrMessageNotFound
+	}
+	return m, role, nil
+}
+
+// Participants returns the IDs of the conversation's participants.
+func (s *ChatService) Participants(ctx context.Context, conversationID string) ([]string, error) {
+	conv, err := s.repo.GetConversation(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conv == nil {
+		return nil, ErrConversationNotFound
+	}
+	ids := make([]string, len(conv.ParticipantIDs))
+	for i, pid := range conv.ParticipantIDs {
+		ids[i] = pid.String()
+	}
+	return ids, nil
 }
 
 func (s *ChatService) ListConversations(ctx context.Context, userID string) ([]*domain.Conversation, error) {
 	return s.repo.ListConversations(ctx, userID)
 }
+
+// MuteConversation stops push notifications of the conversation to the user
+// for duration, or without end when forever is set. A zero duration unmutes.
+// It returns when the mute ends, or nil when the conversation is not muted.
+func (s *ChatService) MuteConversation(ctx context.Context, userID, conversationID string, duration time.Duration, forever bool) (*time.Time, error) {
+	if _, err := uuid.Parse(conversationID); err != nil {
+		return nil, ErrConversationNotFound
+	}
+	if duration < 0 {
+		return nil, ErrInvalidMuteDuration
+	}
+	var until *time.Time
+	switch {
+	case forever:
+		t := domain.MutedForever
+		until = &t
+	case duration > 0:
+		t := time.Now().Add(duration)
+		until = &t
+	}
+	ok, err := s.repo.SetMutedUntil(ctx, conversationID, userID, until)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrConversationNotFound
+	}
+	return until, nil
+}
+
+// AddMembers adds users to a group; only its admins may. Users separated from
+// the admin by a block are refused. It returns the IDs of the users added,
+// skipping unknown users and those already in the group.
+func (s *ChatService) AddMembers(ctx context.Context, actorID, conversationID string, userIDs []string) ([]string, error) {
+	if len(userIDs) == 0 {
+		return nil, ErrInvalidMembers
+	}
+	for _, id := range userIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, ErrInvalidMembers
+		}
+	}
+	if _, err := s.requireAdmin(ctx, actorID, conversationID); err != nil {
+		return nil, err
+	}
+	for _, id := range userIDs {
+		if err := s.checkBlocks(ctx, []string{actorID, id}); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.AddParticipants(ctx, conversationID, actorID, userIDs)
+}
+
+// RemoveMember removes a user from a group; only its admins may, except that
+// anyone may remove themselves, which is leaving. It returns the member
+// promoted when the last admin left, if any.
+func (s *ChatService) RemoveMember(ctx context.Context, actorID, conversationID, userID string) (string, error) {
+	if userID == actorID {
+		return s.LeaveGroup(ctx, actorID, conversationID)
+	}
+	if _, err := s.requireAdmin(ctx, actorID, conversationID); err != nil {
+		return "", err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return "", ErrNotMember
+	}
+	removed, promoted, err := s.repo.RemoveParticipant(ctx, conversationID, userID, actorID, domain.MemberRemoved)
+	if err != nil {
+		return "", err
+	}
+	if !removed {
+		return "", ErrNotMember
+	}
+	return promoted, nil
+}
+
+// LeaveGroup removes the user from a group. When the last admin leaves, the
+// longest-standing member becomes admin; its ID is returned.
+func (s *ChatService) LeaveGroup(ctx context.Context, userID, conversationID string) (string, error) {
+	if _, _, err := s.groupRole(ctx, userID, conversationID); err != nil {
+		return "", err
+	}
+	removed, promoted, err := s.repo.RemoveParticipant(ctx, conversationID, userID, userID, domain.MemberLeft)
+	if err != nil {
+		return "", err
+	}
+	if !removed {
+		return "", ErrConversationNotFound
+	}
+	return promoted, nil
+}
+
+// PromoteAdmin makes a member of a group an admin; only its admins may.
+func (s *ChatService) PromoteAdmin(ctx context.Context, actorID, conversationID, userID string) error {
+	return s.setRole(ctx, actorID, conversationID, userID, domain.AdminRole)
+}
+
+// DemoteAdmin makes an admin of a group a plain member again; only its admins
+// may. The last admin cannot be demoted.
+func (s *ChatService) DemoteAdmin(ctx context.Context, actorID, conversationID, userID string) error {
+	return s.setRole(ctx, actorID, conversationID, userID, domain.MemberRole)
+}
+
+func (s *ChatService) setRole(ctx context.Context, actorID, conversationID, userID string, role domain.ChatMemberRole) error {
+	if _, err := s.requireAdmin(ctx, actorID, conversationID); err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return ErrNotMember
+	}
+	current, err := s.repo.ParticipantRole(ctx, conversationID, userID)
+	if err != nil {
+		return err
+	}
+	switch current {
+	case "":
+		return ErrNotMember
+	case role:
+		return nil
+	}
+	ok, err := s.repo.SetParticipantRole(ctx, conversationID, userID, actorID, role)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if role == domain.MemberRole {
+			return ErrLastAdmin
+		}
+		return ErrNotMember
+	}
+	return nil
+}
+
+// GroupInfo holds changes to the info of a group. Nil fields are left
+// unchanged; an empty description or icon URL removes it.
+type GroupInfo struct {
+	Name        *string
+	Description *string
+	IconURL     *string
+}
+
+// GroupSettings holds changes to the settings of a group; nil fields are left
+// unchanged.
+type GroupSettings struct {
+	OnlyAdminsEditInfo *bool
+	AnnouncementMode   *bool
+}
+
+// UpdateGroupInfo changes the name, description and/or icon of a group. Only
+// admins may when the group restricts editing its info. It returns the group
+// and the changes that were made; values equal to the current ones are not
+// changes.
+func (s *ChatService) UpdateGroupInfo(ctx context.Context, userID, conversationID string, info GroupInfo) (*domain.Conversation, GroupInfo, error) {
+	if info.Name != nil {
+		name := strings.TrimSpace(*info.Name)
+		if name == "" || utf8.RuneCountInString(name) > maxGroupNameLength {
+			return nil, GroupInfo{}, fmt.Errorf("%w: group_name must be 1-%d characters", ErrInvalidGroupInfo, maxGroupNameLength)
+		}
+		info.Name = &name
+	}
+	if info.Description != nil {
+		description := strings.TrimSpace(*info.Description)
+		if utf8.RuneCountInString(description) > maxGroupDescriptionLength {
+			return nil, GroupInfo{}, fmt.Errorf("%w: group_description must be at most %d characters", ErrInvalidGroupInfo, maxGroupDescriptionLength)
+		}
+		info.Description = &description
+	}
+	if info.IconURL != nil && *info.IconURL != "" {
+		if err := validateIconURL(*info.IconURL); err != nil {
+			return nil, GroupInfo{}, err
+		}
+	}
+
+	conv, role, err := s.groupRole(ctx, userID, conversationID)
+	if err != nil {
+		return nil, GroupInfo{}, err
+	}
+	if conv.OnlyAdminsEditInfo && role != domain.AdminRole {
+		return nil, GroupInfo{}, ErrNotAdmin
+	}
+
+	changed := GroupInfo{
+		Name:        changedString(conv.GroupName, info.Name),
+		Description: changedString(conv.GroupDescription, info.Description),
+		IconURL:     changedString(conv.GroupIconURL, info.IconURL),
+	}
+	if changed == (GroupInfo{}) {
+		return conv, changed, nil
+	}
+	ok, err := s.repo.UpdateGroupInfo(ctx, conversationID, changed.Name, changed.Description, changed.IconURL)
+	if err != nil {
+		return nil, GroupInfo{}, err
+	}
+	if !ok {
+		return nil, GroupInfo{}, ErrConversationNotFound
+	}
+	if conv, err = s.repo.GetConversation(ctx, conversationID); err != nil {
+		return nil, GroupInfo{}, err
+	}
+	return conv, changed, nil
+}
+
+// UpdateGroupSettings changes who may edit the info of a group and whether it
+// is in announcement mode; only its admins may. It returns the group and the
+// settings that changed.
+func (s *ChatService) UpdateGroupSettings(ctx context.Context, userID, conversationID string, settings GroupSettings) (*domain.Conversation, GroupSettings, error) {
+	conv, err := s.requireAdmin(ctx, userID, conversationID)
+	if err != nil {
+		return nil, GroupSettings{}, err
+	}
+
+	changed := GroupSettings{
+		OnlyAdminsEditInfo: changedBool(conv.OnlyAdminsEditInfo, settings.OnlyAdminsEditInfo),
+		AnnouncementMode:   changedBool(conv.AnnouncementMode, settings.AnnouncementMode),
+	}
+	if changed == (GroupSettings{}) {
+		return conv, changed, nil
+	}
+	ok, err := s.repo.UpdateGroupSettings(ctx, conversationID, changed.OnlyAdminsEditInfo, changed.AnnouncementMode)
+	if err != nil {
+		return nil, GroupSettings{}, err
+	}
+	if !ok {
+		return nil, GroupSettings{}, ErrConversationNotFound
+	}
+	if conv, err = s.repo.GetConversation(ctx, conversationID); err != nil {
+		return nil, GroupSettings{}, err
+	}
+	return conv, changed, nil
+}
+
+// CreateInvite creates an invite link to a group; only its admins may. A zero
+// ttl never expires and a zero maxUses allows any number of uses. Users
+// joining through an invite requiring approval file a join request instead.
+func (s *ChatService) CreateInvite(ctx context.Context, actorID, conversationID string, ttl time.Duration, maxUses int, requiresApproval bool) (*domain.GroupInvite, error) {
+	if ttl < 0 || maxUses < 0 {
+		return nil, ErrInvalidInvite
+	}
+	conv, err := s.requireAdmin(ctx, actorID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := inviteToken()
+	if err != nil {
+		return nil, err
+	}
+	inv := &domain.GroupInvite{ConversationID: conv.ID, Token: token, MaxUses: maxUses, RequiresApproval: requiresApproval}
+	if id, err := uuid.Parse(actorID); err == nil {
+		inv.CreatedBy = &id
+	}
+	if ttl > 0 {
+		t := time.Now().Add(ttl)
+		inv.ExpiresAt = &t
+	}
+	return s.repo.CreateInvite(ctx, inv)
+}
+
+// ListInvites returns the invites of a group that were not revoked, including
+// expired and used up ones; only its admins may list them.
+func (s *ChatService) ListInvites(ctx context.Context, actorID, conversationID string) ([]*domain.GroupInvite, error) {
+	if _, err := s.requireAdmin(ctx, actorID, conversationID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListInvites(ctx, conversationID)
+}
+
+// RevokeInvite stops an invite of a group from being used; only its admins may.
+func (s *ChatService) RevokeInvite(ctx context.Context, actorID, conversationID, token string) error {
+	if _, err := s.requireAdmin(ctx, actorID, conversationID); err != nil {
+		return err
+	}
+	ok, err := s.repo.RevokeInvite(ctx, conversationID, token)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInviteNotFound
+	}
+	return nil
+}
+
+// JoinViaInvite adds the user to the group of an invite (joined), or files a
+// join request for its admins to approve (pending). A pending requester gets
+// the group without its participants. Users separated from the admin who
+// created the invite by a block are refused. Members of the group get it back
+// without using the invite.
+func (s *ChatService) JoinViaInvite(ctx context.Context, userID, token string) (conv *domain.Conversation, joined, pending bool, err error) {
+	inv, err := s.repo.GetInvite(ctx, token)
+	if err != nil {
+		return nil, false, false, err
+	}
+	if inv == nil || inv.RevokedAt != nil {
+		return nil, false, false, ErrInviteNotFound
+	}
+	conversationID := inv.ConversationID.String()
+	role, err := s.repo.ParticipantRole(ctx, conversationID, userID)
+	if err != nil {
+		return nil, false, false, err
+	}
+	if role == "" {
+		// Usability is checked by UseInvite: a pending requester asking again
+		// gets the pending request back even once the invite is used up
+		if inv.CreatedBy != nil {
+			if err := s.checkBlocks(ctx, []string{userID, inv.CreatedBy.String()}); err != nil {
+				return nil, false, false, err
+			}
+		}
+		ok, err := s.repo.UseInvite(ctx, inv.ID.String(), userID)
+		if err != nil {
+			return nil, false, false, err
+		}
+		if !ok {
+			return nil, false, false, ErrInviteExpired
+		}
+		joined, pending = !inv.RequiresApproval, inv.RequiresApproval
+	}
+
+	if conv, err = s.repo.GetConversation(ctx, conversationID); err != nil {
+		return nil, false, false, err
+	}
+	if conv == nil {
+		return nil, false, false, ErrConversationNotFound
+	}
+	if pending {
+		conv.ParticipantIDs, conv.AdminIDs = nil, nil
+	}
+	return conv, joined, pending, nil
+}
+
+// ListJoinRequests returns the pending join requests of a group, oldest
+// first; only its admins may list them.
+func (s *ChatService) ListJoinRequests(ctx context.Context, actorID, conversationID string) ([]*domain.JoinRequest, error) {
+	if _, err := s.requireAdmin(ctx, actorID, conversationID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListJoinRequests(ctx, conversationID)
+}
+
+// ApproveJoinRequest adds the user who requested to join to the group; only
+// its admins may. Users separated from the admin by a block are refused.
+func (s *ChatService) ApproveJoinRequest(ctx context.Context, actorID, conversationID, userID string) error {
+	return s.resolveJoinRequest(ctx, actorID, conversationID, userID, true)
+}
+
+// DeclineJoinRequest drops the user's request to join the group; only its
+// admins may.
+func (s *ChatService) DeclineJoinRequest(ctx context.Context, actorID, conversationID, userID string) error {
+	return s.resolveJoinRequest(ctx, actorID, conversationID, userID, false)
+}
+
+func (s *ChatService) resolveJoinRequest(ctx context.Context, actorID, conversationID, userID string, approve bool) error {
+	if _, err := s.requireAdmin(ctx, actorID, conversationID); err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return ErrJoinRequestNotFound
+	}
+	if approve {
+		if err := s.checkBlocks(ctx, []string{actorID, userID}); err != nil {
+			return err
+		}
+	}
+	ok, err := s.repo.ResolveJoinRequest(ctx, conversationID, userID, actorID, approve)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrJoinRequestNotFound
+	}
+	return nil
+}
+
+// inviteToken returns 16 random bytes, base64url encoded.
+func inviteToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// changedString returns update if it differs from current, where nil and
+// empty both mean unset; otherwise nil.
+func changedString(current, update *string) *string {
+	if update == nil {
+		return nil
+	}
+	if current == nil && *update == "" || current != nil && *current == *update {
+		return nil
+	}
+	return update
+}
+
+// changedBool returns update if it differs from current; otherwise nil.
+func changedBool(current bool, update *bool) *bool {
+	if update == nil || *update == current {
+		return nil
+	}
+	return update
+}
+
+func validateIconURL(raw string) error {
+	if len(raw) > maxGroupIconURLLength {
+		return fmt.Errorf("%w: group_icon_url is too long", ErrInvalidGroupInfo)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: group_icon_url must be an http(s) URL", ErrInvalidGroupInfo)
+	}
+	return nil
+}
+
+// groupRole returns a group and the user's role in it. Conversations the user
+// is not part of are reported as not found.
+func (s *ChatService) groupRole(ctx context.Context, userID, conversationID string) (*domain.Conversation, domain.ChatMemberRole, error) {
+	if _, err := uuid.Parse(conversationID); err != nil {
+		return nil, "", ErrConversationNotFound
+	}
+	role, err := s.repo.ParticipantRole(ctx, conversationID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if role == "" {
+		return nil, "", ErrConversationNotFound
+	}
+	conv, err := s.repo.GetConversation(ctx, conversationID)
+	if err != nil {
+		return nil, "", err
+	}
+	if conv == nil {
+		return nil, "", ErrConversationNotFound
+	}
+	if !conv.IsGroup {
+		return nil, "", ErrNotGroup
+	}
+	return conv, role, nil
+}
+
+// requireAdmin returns the group, or ErrNotAdmin unless the user is one of its admins.
+func (s *ChatService) requireAdmin(ctx context.Context, userID, conversationID string) (*domain.Conversation, error) {
+	conv, role, err := s.groupRole(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if role != domain.AdminRole {
+		return nil, ErrNotAdmin
+	}
+	return conv, nil
+}
+
+// checkBlocks returns ErrBlocked if any two of the users are separated by a block.
+func (s *ChatService) checkBlocks(ctx context.Context, userIDs []string) error {
+	if s.blocks == nil {
+		return nil
+	}
+	for i := 0; i < len(userIDs); i++ {
+		for j := i + 1; j < len(userIDs); j++ {
+			if userIDs[i] == userIDs[j] {
+				continue
+			}
+			blocked, err := s.blocks.IsBlocked(ctx, userIDs[i], userIDs[j])
+			if err != nil {
+				return err
+			}
+			if blocked {
+				return ErrBlocked
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,728 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dykethecreator/GoApp/pkg/config"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+// fakeChatRepo keeps conversations and messages in memory.
+type fakeChatRepo struct {
+	conversations map[string]*domain.Conversation
+	roles         map[string]domain.ChatMemberRole // by conversation ID|user ID
+	messages      map[string]*domain.ChatMessage
+	edits         []*domain.MessageEdit
+	hidden        map[string]bool // by message ID|user ID
+	reactions     []*domain.MessageReaction
+	invites       []*domain.GroupInvite
+	joinRequests  []*domain.JoinRequest
+}
+
+func newFakeChatRepo() *fakeChatRepo {
+	return &fakeChatRepo{
+		conversations: map[string]*domain.Conversation{},
+		roles:         map[string]domain.ChatMemberRole{},
+		messages:      map[string]*domain.ChatMessage{},
+		hidden:        map[string]bool{},
+	}
+}
+
+func (f *fakeChatRepo) CreateConversation(ctx context.Context, creatorID string, participantIDs []string, isGroup bool, groupName string) (string, error) {
+	conv := &domain.Conversation{ID: uuid.New(), IsGroup: isGroup, CreatedAt: time.Now()}
+	if groupName != "" {
+		conv.GroupName = &groupName
+	}
+	for _, id := range participantIDs {
+		conv.ParticipantIDs = append(conv.ParticipantIDs, uuid.MustParse(id))
+		role := domain.MemberRole
+		if isGroup && id == creatorID {
+			role = domain.AdminRole
+		}
+		f.roles[conv.ID.String()+"|"+id] = role
+	}
+	f.conversations[conv.ID.String()] = conv
+	return conv.ID.String(), nil
+}
+
+func (f *fakeChatRepo) ParticipantRole(ctx context.Context, conversationID, userID string) (domain.ChatMemberRole, error) {
+	return f.roles[conversationID+"|"+userID], nil
+}
+
+func (f *fakeChatRepo) AddParticipants(ctx context.Context, conversationID, actorID string, userIDs []string) ([]string, error) {
+	conv := f.conversations[conversationID]
+	added := []string{}
+	for _, id := range userIDs {
+		if f.roles[conversationID+"|"+id] == "" {
+			conv.ParticipantIDs = append(conv.ParticipantIDs, uuid.MustParse(id))
+			f.roles[conversationID+"|"+id] = domain.MemberRole
+			added = append(added, id)
+		}
+	}
+	return added, nil
+}
+
+func (f *fakeChatRepo) RemoveParticipant(ctx context.Context, conversationID, userID, actorID string, action domain.MembershipAction) (bool, string, error) {
+	conv := f.conversations[conversationID]
+	if f.roles[conversationID+"|"+userID] == "" {
+		return false, "", nil
+	}
+	delete(f.roles, conversationID+"|"+userID)
+	remaining := conv.ParticipantIDs[:0]
+	for _, pid := range conv.ParticipantIDs {
+		if pid.String() != userID {
+			remaining = append(remaining, pid)
+		}
+	}
+	conv.ParticipantIDs = remaining
+	for _, pid := range conv.ParticipantIDs {
+		if f.roles[conversationID+"|"+pid.String()] == domain.AdminRole {
+			return true, "", nil
+		}
+	}
+	if len(conv.ParticipantIDs) == 0 {
+		return true, "", nil
+	}
+	promoted := conv.ParticipantIDs[0].String()
+	f.roles[conversationID+"|"+promoted] = domain.AdminRole
+	return true, promoted, nil
+}
+
+func (f *fakeChatRepo) SetParticipantRole(ctx context.Context, conversationID, userID, actorID string, role domain.ChatMemberRole) (bool, error) {
+	current := f.roles[conversationID+"|"+userID]
+	if current == "" || current == role {
+		return false, nil
+	}
+	if role != domain.AdminRole {
+		admins := 0
+		for _, pid := range f.conversations[conversationID].ParticipantIDs {
+			if f.roles[conversationID+"|"+pid.String()] == domain.AdminRole {
+				admins++
+			}
+		}
+		if admins == 1 {
+			return false, nil
+		}
+	}
+	f.roles[conversationID+"|"+userID] = role
+	return true, nil
+}
+
+func (f *fakeChatRepo) UpdateGroupInfo(ctx context.Context, conversationID string, name, description, iconURL *string) (bool, error) {
+	conv := f.conversations[conversationID]
+	if name != nil {
+		conv.GroupName = name
+	}
+	if description != nil {
+		conv.GroupDescription = description
+	}
+	if iconURL != nil {
+		conv.GroupIconURL = iconURL
+	}
+	return true, nil
+}
+
+func (f *fakeChatRepo) UpdateGroupSettings(ctx context.Context, conversationID string, onlyAdminsEditInfo, announcementMode *bool) (bool, error) {
+	conv := f.conversations[conversationID]
+	if onlyAdminsEditInfo != nil {
+		conv.OnlyAdminsEditInfo = *onlyAdminsEditInfo
+	}
+	if announcementMode != nil {
+		conv.AnnouncementMode = *announcementMode
+	}
+	return true, nil
+}
+
+func (f *fakeChatRepo) CreateInvite(ctx context.Context, inv *domain.GroupInvite) (*domain.GroupInvite, error) {
+	inv.ID, inv.CreatedAt = uuid.New(), time.Now()
+	f.invites = append(f.invites, inv)
+	return inv, nil
+}
+
+func (f *fakeChatRepo) GetInvite(ctx context.Context, token string) (*domain.GroupInvite, error) {
+	for _, inv := range f.invites {
+		if inv.Token == token {
+			return inv, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeChatRepo) ListInvites(ctx context.Context, conversationID string) ([]*domain.GroupInvite, error) {
+	var out []*domain.GroupInvite
+	for _, inv := range f.invites {
+		if inv.ConversationID.String() == conversationID && inv.RevokedAt == nil {
+			out = append(out, inv)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeChatRepo) RevokeInvite(ctx context.Context, conversationID, token string) (bool, error) {
+	inv, _ := f.GetInvite(ctx, token)
+	if inv == nil || inv.ConversationID.String() != conversationID || inv.RevokedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	inv.RevokedAt = &now
+	return true, nil
+}
+
+func (f *fakeChatRepo) UseInvite(ctx context.Context, inviteID, userID string) (bool, error) {
+	for _, inv := range f.invites {
+		if inv.ID.String() != inviteID {
+			continue
+		}
+		if inv.RequiresApproval {
+			for _, r := range f.joinRequests {
+				if r.ConversationID == inv.ConversationID && r.UserID.String() == userID {
+					return true, nil
+				}
+			}
+		}
+		if !inv.Usable(time.Now()) {
+			return false, nil
+		}
+		if inv.RequiresApproval {
+			inv.UseCount++
+			f.joinRequests = append(f.joinRequests, &domain.JoinRequest{ConversationID: inv.ConversationID, UserID: uuid.MustParse(userID), InviteID: &inv.ID, RequestedAt: time.Now()})
+			return true, nil
+		}
+		added, err := f.AddParticipants(ctx, inv.ConversationID.String(), userID, []string{userID})
+		if len(added) > 0 {
+			inv.UseCount++
+		}
+		return true, err
+	}
+	return false, nil
+}
+
+func (f *fakeChatRepo) ListJoinRequests(ctx context.Context, conversationID string) ([]*domain.JoinRequest, error) {
+	var out []*domain.JoinRequest
+	for _, r := range f.joinRequests {
+		if r.ConversationID.String() == conversationID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeChatRepo) ResolveJoinRequest(ctx context.Context, conversationID, userID, actorID string, approve bool) (bool, error) {
+	for i, r := range f.joinRequests {
+		if r.ConversationID.String() == conversationID && r.UserID.String() == userID {
+			f.joinRequests = append(f.joinRequests[:i], f.joinRequests[i+1:]...)
+			if approve {
+				return true, f.join(conversationID, userID)
+			}
+			for _, inv := range f.invites {
+				if r.InviteID != nil && inv.ID == *r.InviteID && inv.UseCount > 0 {
+					inv.UseCount--
+				}
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeChatRepo) join(conversationID, userID string) error {
+	_, err := f.AddParticipants(context.Background(), conversationID, userID, []string{userID})
+	return err
+}
+
+func (f *fakeChatRepo) InsertMessage(ctx context.Context, m *domain.ChatMessage) (*domain.ChatMessage, error) {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	f.messages[m.ID.String()] = m
+	return m, nil
+}
+
+func (f *fakeChatRepo) ListMessages(ctx context.Context, conversationID, userID string, beforeID string, limit int) ([]*domain.ChatMessage, error) {
+	var out []*domain.ChatMessage
+	for _, m := range f.messages {
+		if m.ConversationID.String() == conversationID && !f.hidden[m.ID.String()+"|"+userID] {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeChatRepo) GetMessage(ctx context.Context, messageID string) (*domain.ChatMessage, error) {
+	m, ok := f.messages[messageID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *m
+	return &copied, nil
+}
+
+func (f *fakeChatRepo) GetMessages(ctx context.Context, messageIDs []string) ([]*domain.ChatMessage, error) {
+	var out []*domain.ChatMessage
+	for _, id := range messageIDs {
+		if m, ok := f.messages[id]; ok {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeChatRepo) EditMessage(ctx context.Context, messageID, content string) (*domain.ChatMessage, error) {
+	m, ok := f.messages[messageID]
+	if !ok || m.DeletedAt != nil {
+		return nil, nil
+	}
+	now := time.Now()
+	f.edits = append(f.edits, &domain.MessageEdit{MessageID: m.ID, Content: m.Content, ReplacedAt: now})
+	m.Content, m.EditedAt = content, &now
+	return m, nil
+}
+
+func (f *fakeChatRepo) DeleteMessage(ctx context.Context, messageID, deletedBy string) (*domain.ChatMessage, error) {
+	m, ok := f.messages[messageID]
+	if !ok || m.DeletedAt != nil {
+		return nil, nil
+	}
+	now, by := time.Now(), uuid.MustParse(deletedBy)
+	m.Content, m.MediaURL, m.MediaType, m.DeletedAt, m.DeletedBy = "", nil, nil, &now, &by
+	return m, nil
+}
+
+func (f *fakeChatRepo) HideMessage(ctx context.Context, messageID, userID string) error {
+	f.hidden[messageID+"|"+userID] = true
+	return nil
+}
+
+func (f *fakeChatRepo) SetReaction(ctx context.Context, messageID, userID, emoji string) error {
+	f.RemoveReaction(ctx, messageID, userID)
+	f.reactions = append(f.reactions, &domain.MessageReaction{MessageID: uuid.MustParse(messageID), UserID: uuid.MustParse(userID), ReactionEmoji: emoji, CreatedAt: time.Now()})
+	return nil
+}
+
+func (f *fakeChatRepo) RemoveReaction(ctx context.Context, messageID, userID string) (bool, error) {
+	for i, r := range f.reactions {
+		if r.MessageID.String() == messageID && r.UserID.String() == userID {
+			f.reactions = append(f.reactions[:i], f.reactions[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeChatRepo) ListReactions(ctx context.Context, messageIDs []string) ([]*domain.MessageReaction, error) {
+	var out []*domain.MessageReaction
+	for _, r := range f.reactions {
+		for _, id := range messageIDs {
+			if r.MessageID.String() == id {
+				out = append(out, r)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeChatRepo) ListConversations(ctx context.Context, userID string) ([]*domain.Conversation, error) {
+	return nil, nil
+}
+
+func (f *fakeChatRepo) GetConversation(ctx context.Context, conversationID string) (*domain.Conversation, error) {
+	return f.conversations[conversationID], nil
+}
+
+func (f *fakeChatRepo) SetMutedUntil(ctx context.Context, conversationID, userID string, until *time.Time) (bool, error) {
+	return true, nil
+}
+
+func (f *fakeChatRepo) MutedParticipants(ctx context.Context, conversationID string, userIDs []string, at time.Time) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
+
+// fakeBlocks records blocks as blocker|blocked.
+type fakeBlocks map[string]bool
+
+func (b fakeBlocks) IsBlocked(ctx context.Context, userA, userB string) (bool, error) {
+	return b[userA+"|"+userB] || b[userB+"|"+userA], nil
+}
+
+func TestBlocks_EnforcedOnDirectChatsBothWays(t *testing.T) {
+	blocks := fakeBlocks{}
+	s := NewChatService(newFakeChatRepo(), blocks)
+	ctx := context.Background()
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+
+	conv, err := s.CreateConversation(ctx, alice.String(), []string{bob.String()}, false, "")
+	if err != nil {
+		t.Fatalf("CreateConversation: %v", err)
+	}
+	if len(conv.ParticipantIDs) != 2 {
+		t.Fatalf("participants = %v, want the caller added to the 1:1", conv.ParticipantIDs)
+	}
+
+	blocks[alice.String()+"|"+bob.String()] = true
+	for _, sender := range []uuid.UUID{alice, bob} {
+		if _, err := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: conv.ID, SenderID: sender, Content: "hi"}); !errors.Is(err, ErrBlocked) {
+			t.Errorf("SendMessage by %s = %v, want ErrBlocked", sender, err)
+		}
+		other := bob
+		if sender == bob {
+			other = alice
+		}
+		if _, err := s.CreateConversation(ctx, sender.String(), []string{other.String()}, false, ""); !errors.Is(err, ErrBlocked) {
+			t.Errorf("CreateConversation by %s = %v, want ErrBlocked", sender, err)
+		}
+	}
+
+	// A caller cannot create a 1:1 between two other users
+	if _, err := s.CreateConversation(ctx, carol.String(), []string{bob.String(), alice.String()}, false, ""); !errors.Is(err, ErrInvalidDirectChat) {
+		t.Errorf("CreateConversation without the caller = %v, want ErrInvalidDirectChat", err)
+	}
+	if _, err := s.CreateConversation(ctx, carol.String(), []string{carol.String()}, false, ""); !errors.Is(err, ErrInvalidDirectChat) {
+		t.Errorf("CreateConversation with only the caller = %v, want ErrInvalidDirectChat", err)
+	}
+}
+
+func TestEditMessage_SenderWithinWindowKeepsHistory(t *testing.T) {
+	repo := newFakeChatRepo()
+	s := NewChatServiceWithConfig(repo, nil, config.ChatConfig{EditWindow: time.Minute})
+	ctx := context.Background()
+	alice, bob := uuid.New(), uuid.New()
+	convID, _ := repo.CreateConversation(ctx, alice.String(), []string{alice.String(), bob.String()}, false, "")
+
+	m, _ := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: uuid.MustParse(convID), SenderID: alice, Content: "helo"})
+	if _, err := s.EditMessage(ctx, bob.String(), m.ID.String(), "hacked"); !errors.Is(err, ErrNotMessageSender) {
+		t.Fatalf("expected another participant's edit to fail with ErrNotMessageSender, got %v", err)
+	}
+	if _, err := s.EditMessage(ctx, alice.String(), m.ID.String(), "  "); !errors.Is(err, ErrEmptyMessage) {
+		t.Fatalf("expected blank edit to fail with ErrEmptyMessage, got %v", err)
+	}
+
+	edited, err := s.EditMessage(ctx, alice.String(), m.ID.String(), "hello")
+	if err != nil {
+		t.Fatalf("EditMessage error: %v", err)
+	}
+	if edited.Content != "hello" || edited.EditedAt == nil {
+		t.Fatalf("edited message = %+v, want new content and edited_at", edited)
+	}
+	if len(repo.edits) != 1 || repo.edits[0].Content != "helo" {
+		t.Fatalf("edit history = %+v, want the original version", repo.edits)
+	}
+
+	old, _ := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: uuid.MustParse(convID), SenderID: alice, Content: "old", CreatedAt: time.Now().Add(-2 * time.Minute)})
+	if _, err := s.EditMessage(ctx, alice.String(), old.ID.String(), "new"); !errors.Is(err, ErrEditWindowExpired) {
+		t.Fatalf("expected edit after the window to fail with ErrEditWindowExpired, got %v", err)
+	}
+	if _, err := s.EditMessage(ctx, alice.String(), uuid.NewString(), "x"); !errors.Is(err, ErrMessageNotFound) {
+		t.Fatalf("expected ErrMessageNotFound, got %v", err)
+	}
+}
+
+func TestDeleteMessage_ForMeAndForEveryone(t *testing.T) {
+	repo := newFakeChatRepo()
+	s := NewChatServiceWithConfig(repo, nil, config.ChatConfig{DeleteWindow: time.Hour})
+	ctx := context.Background()
+	admin, alice, bob, outsider := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	created, _ := s.CreateConversation(ctx, admin.String(), []string{admin.String(), alice.String(), bob.String()}, true, "team")
+	conv, convID := created.ID, created.ID.String()
+	media := "https://cdn.example.com/photo.jpg"
+	m, _ := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: conv, SenderID: alice, Content: "look", MediaURL: &media})
+
+	if _, err := s.DeleteMessage(ctx, outsider.String(), m.ID.String(), false); !errors.Is(err, ErrMessageNotFound) {
+		t.Fatalf("expected a non-participant's delete to fail with ErrMessageNotFound, got %v", err)
+	}
+	if _, err := s.DeleteMessage(ctx, bob.String(), m.ID.String(), false); err != nil {
+		t.Fatalf("DeleteMessage for me: %v", err)
+	}
+	if got, _ := s.ListMessages(ctx, bob.String(), convID, "", 50); len(got) != 0 {
+		t.Fatalf("bob still sees %d messages after deleting the message for bob only", len(got))
+	}
+	if got, _ := s.ListMessages(ctx, alice.String(), convID, "", 50); len(got) != 1 || got[0].Content != "look" {
+		t.Fatalf("alice's messages = %+v, want hers untouched", got)
+	}
+
+	if _, err := s.DeleteMessage(ctx, bob.String(), m.ID.String(), true); !errors.Is(err, ErrDeleteNotAllowed) {
+		t.Fatalf("expected another member's delete for everyone to fail with ErrDeleteNotAllowed, got %v", err)
+	}
+	deleted, err := s.DeleteMessage(ctx, admin.String(), m.ID.String(), true)
+	if err != nil {
+		t.Fatalf("admin DeleteMessage for everyone: %v", err)
+	}
+	if deleted.DeletedAt == nil || deleted.Content != "" || deleted.MediaURL != nil || *deleted.DeletedBy != admin {
+		t.Fatalf("tombstone = %+v, want content and media cleared and deleted by the admin", deleted)
+	}
+	if _, err := s.EditMessage(ctx, alice.String(), m.ID.String(), "again"); !errors.Is(err, ErrMessageNotFound) {
+		t.Fatalf("expected editing a deleted message to fail with ErrMessageNotFound, got %v", err)
+	}
+
+	old, _ := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: conv, SenderID: alice, Content: "old", CreatedAt: time.Now().Add(-2 * time.Hour)})
+	if _, err := s.DeleteMessage(ctx, alice.String(), old.ID.String(), true); !errors.Is(err, ErrDeleteWindowExpired) {
+		t.Fatalf("expected delete after the window to fail with ErrDeleteWindowExpired, got %v", err)
+	}
+}
+
+func TestReactions_OnePerUserCountedInListMessages(t *testing.T) {
+	repo := newFakeChatRepo()
+	s := NewChatService(repo, nil)
+	ctx := context.Background()
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	created, _ := s.CreateConversation(ctx, alice.String(), []string{alice.String(), bob.String(), carol.String()}, true, "trip")
+	convID := created.ID.String()
+	m, _ := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: created.ID, SenderID: alice, Content: "tickets booked"})
+
+	for _, emoji := range []string{"", "ok", "👍 👍"} {
+		if _, err := s.ReactToMessage(ctx, bob.String(), m.ID.String(), emoji); !errors.Is(err, ErrInvalidReaction) {
+			t.Errorf("ReactToMessage(%q): expected ErrInvalidReaction, got %v", emoji, err)
+		}
+	}
+	if _, err := s.ReactToMessage(ctx, uuid.NewString(), m.ID.String(), "👍"); !errors.Is(err, ErrMessageNotFound) {
+		t.Fatalf("expected a non-participant's reaction to fail with ErrMessageNotFound, got %v", err)
+	}
+
+	s.ReactToMessage(ctx, bob.String(), m.ID.String(), "😂")
+	s.ReactToMessage(ctx, bob.String(), m.ID.String(), "👍") // replaces bob's 😂
+	s.ReactToMessage(ctx, carol.String(), m.ID.String(), "👍")
+	got, err := s.ReactToMessage(ctx, alice.String(), m.ID.String(), "❤️")
+	if err != nil {
+		t.Fatalf("ReactToMessage error: %v", err)
+	}
+	if len(got.Reactions) != 2 || got.Reactions[0].Emoji != "👍" || got.Reactions[0].Count != 2 || got.Reactions[1].Emoji != "❤️" {
+		t.Fatalf("reactions = %+v, want 👍 x2 then ❤️ x1", got.Reactions)
+	}
+
+	if _, err := s.RemoveReaction(ctx, carol.String(), m.ID.String()); err != nil {
+		t.Fatalf("RemoveReaction error: %v", err)
+	}
+	msgs, _ := s.ListMessages(ctx, carol.String(), convID, "", 50)
+	if len(msgs) != 1 || len(msgs[0].Reactions) != 2 {
+		t.Fatalf("listed messages = %+v, want one message with two reactions", msgs)
+	}
+	if r := msgs[0].Reactions[0]; r.Count != 1 || len(r.UserIDs) != 1 || r.UserIDs[0] != bob {
+		t.Errorf("👍 reaction = %+v, want only bob's after carol's was removed", r)
+	}
+}
+
+func TestSendMessage_ReplyQuotesSameConversation(t *testing.T) {
+	repo := newFakeChatRepo()
+	s := NewChatService(repo, nil)
+	ctx := context.Background()
+	alice, bob := uuid.New(), uuid.New()
+	created, _ := s.CreateConversation(ctx, alice.String(), []string{alice.String(), bob.String()}, false, "")
+	otherConv, _ := s.CreateConversation(ctx, alice.String(), []string{alice.String(), uuid.NewString()}, false, "")
+	conv, other, convID := created.ID, otherConv.ID, created.ID.String()
+
+	photo := "image"
+	original, _ := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: conv, SenderID: alice, Content: "dinner at 8?", MediaType: &photo})
+	elsewhere, _ := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: other, SenderID: alice, Content: "secret"})
+
+	if _, err := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: conv, SenderID: bob, Content: "?", ReplyToMessageID: &elsewhere.ID}); !errors.Is(err, ErrInvalidReply) {
+		t.Fatalf("expected a reply to another conversation's message to fail with ErrInvalidReply, got %v", err)
+	}
+	reply, err := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: conv, SenderID: bob, Content: "yes", ReplyToMessageID: &original.ID})
+	if err != nil {
+		t.Fatalf("SendMessage reply: %v", err)
+	}
+	if q := reply.ReplyTo; q == nil || q.MessageID != original.ID || *q.SenderID != alice || q.Snippet != "dinner at 8?" || q.MediaType != "image" {
+		t.Fatalf("reply preview = %+v, want alice's message quoted", reply.ReplyTo)
+	}
+
+	if _, err := s.DeleteMessage(ctx, alice.String(), original.ID.String(), true); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	msgs, _ := s.ListMessages(ctx, bob.String(), convID, "", 50)
+	for _, m := range msgs {
+		if m.ID != reply.ID {
+			continue
+		}
+		if q := m.ReplyTo; q == nil || !q.Deleted || q.Snippet != "" || q.MediaType != "" {
+			t.Fatalf("preview of a deleted message = %+v, want a deleted quote without content", m.ReplyTo)
+		}
+		return
+	}
+	t.Fatalf("reply missing from ListMessages")
+}
+
+func TestGroupMembership_AdminRolesAndLastAdminLeaving(t *testing.T) {
+	s := NewChatService(newFakeChatRepo(), nil)
+	ctx := context.Background()
+	alice, bob, carol, dave := uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()
+	conv, err := s.CreateConversation(ctx, alice, []string{bob}, true, "book club")
+	if err != nil {
+		t.Fatalf("CreateConversation: %v", err)
+	}
+	convID := conv.ID.String()
+	if len(conv.ParticipantIDs) != 2 {
+		t.Fatalf("participants = %v, want the creator added to the group", conv.ParticipantIDs)
+	}
+
+	if _, err := s.AddMembers(ctx, bob, convID, []string{carol}); !errors.Is(err, ErrNotAdmin) {
+		t.Fatalf("expected a member adding to fail with ErrNotAdmin, got %v", err)
+	}
+	added, err := s.AddMembers(ctx, alice, convID, []string{carol, dave, bob})
+	if err != nil || len(added) != 2 {
+		t.Fatalf("AddMembers = %v, %v; want carol and dave added and bob skipped", added, err)
+	}
+	if _, err := s.RemoveMember(ctx, bob, convID, dave); !errors.Is(err, ErrNotAdmin) {
+		t.Fatalf("expected a member removing to fail with ErrNotAdmin, got %v", err)
+	}
+	sent, err := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: conv.ID, SenderID: uuid.MustParse(dave), Content: "hi all"})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	if _, err := s.RemoveMember(ctx, alice, convID, dave); err != nil {
+		t.Fatalf("RemoveMember: %v", err)
+	}
+	if _, err := s.EditMessage(ctx, dave, sent.ID.String(), "bye all"); !errors.Is(err, ErrMessageNotFound) {
+		t.Fatalf("expected a removed member's edit to fail with ErrMessageNotFound, got %v", err)
+	}
+	if _, err := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: conv.ID, SenderID: uuid.MustParse(dave), Content: "still here?"}); !errors.Is(err, ErrConversationNotFound) {
+		t.Fatalf("expected a removed member's message to fail with ErrConversationNotFound, got %v", err)
+	}
+
+	if err := s.DemoteAdmin(ctx, alice, convID, alice); !errors.Is(err, ErrLastAdmin) {
+		t.Fatalf("expected demoting the last admin to fail with ErrLastAdmin, got %v", err)
+	}
+	if err := s.PromoteAdmin(ctx, alice, convID, carol); err != nil {
+		t.Fatalf("PromoteAdmin: %v", err)
+	}
+	if err := s.DemoteAdmin(ctx, carol, convID, alice); err != nil {
+		t.Fatalf("DemoteAdmin by another admin: %v", err)
+	}
+	if _, err := s.AddMembers(ctx, alice, convID, []string{dave}); !errors.Is(err, ErrNotAdmin) {
+		t.Fatalf("expected a demoted admin adding to fail with ErrNotAdmin, got %v", err)
+	}
+
+	promoted, err := s.LeaveGroup(ctx, carol, convID)
+	if err != nil {
+		t.Fatalf("LeaveGroup: %v", err)
+	}
+	if promoted != alice {
+		t.Errorf("promoted %q after the last admin left, want the longest-standing member %q", promoted, alice)
+	}
+	if _, err := s.AddMembers(ctx, alice, convID, []string{dave}); err != nil {
+		t.Errorf("AddMembers by the promoted admin: %v", err)
+	}
+}
+
+func TestGroupInfo_AdminSettingsRestrictEditingAndSending(t *testing.T) {
+	s := NewChatService(newFakeChatRepo(), nil)
+	ctx := context.Background()
+	admin, member := uuid.NewString(), uuid.NewString()
+	conv, _ := s.CreateConversation(ctx, admin, []string{member}, true, "hikers")
+	convID := conv.ID.String()
+	str := func(v string) *string { return &v }
+	yes := true
+
+	if _, _, err := s.UpdateGroupInfo(ctx, member, convID, GroupInfo{Name: str("  ")}); !errors.Is(err, ErrInvalidGroupInfo) {
+		t.Fatalf("expected a blank name to fail with ErrInvalidGroupInfo, got %v", err)
+	}
+	got, changed, err := s.UpdateGroupInfo(ctx, member, convID, GroupInfo{Name: str("hikers"), Description: str(" Sunday trails "), IconURL: str("https://cdn.example.com/mountain.png")})
+	if err != nil {
+		t.Fatalf("UpdateGroupInfo by a member: %v", err)
+	}
+	if changed.Name != nil || changed.Description == nil || *got.GroupDescription != "Sunday trails" || changed.IconURL == nil {
+		t.Errorf("changed = %+v, group = %+v; want the description and icon changed and the unchanged name left out", changed, got)
+	}
+
+	if _, _, err := s.UpdateGroupSettings(ctx, member, convID, GroupSettings{OnlyAdminsEditInfo: &yes}); !errors.Is(err, ErrNotAdmin) {
+		t.Fatalf("expected a member changing settings to fail with ErrNotAdmin, got %v", err)
+	}
+	if _, _, err := s.UpdateGroupSettings(ctx, admin, convID, GroupSettings{OnlyAdminsEditInfo: &yes, AnnouncementMode: &yes}); err != nil {
+		t.Fatalf("UpdateGroupSettings: %v", err)
+	}
+	if _, _, err := s.UpdateGroupInfo(ctx, member, convID, GroupInfo{IconURL: str("")}); !errors.Is(err, ErrNotAdmin) {
+		t.Errorf("expected a member editing restricted info to fail with ErrNotAdmin, got %v", err)
+	}
+	if _, err := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: conv.ID, SenderID: uuid.MustParse(member), Content: "hi"}); !errors.Is(err, ErrNotAdmin) {
+		t.Errorf("expected a member's message in announcement mode to fail with ErrNotAdmin, got %v", err)
+	}
+	if _, err := s.SendMessage(ctx, &domain.ChatMessage{ConversationID: conv.ID, SenderID: uuid.MustParse(admin), Content: "meet at 9"}); err != nil {
+		t.Errorf("SendMessage by an admin in announcement mode: %v", err)
+	}
+}
+
+func TestJoinViaInvite_MaxUsesApprovalAndRevocation(t *testing.T) {
+	s := NewChatService(newFakeChatRepo(), nil)
+	ctx := context.Background()
+	admin, member, guest, latecomer := uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()
+	conv, _ := s.CreateConversation(ctx, admin, []string{member}, true, "choir")
+	convID := conv.ID.String()
+
+	if _, err := s.CreateInvite(ctx, member, convID, 0, 0, false); !errors.Is(err, ErrNotAdmin) {
+		t.Fatalf("expected a member creating an invite to fail with ErrNotAdmin, got %v", err)
+	}
+	single, err := s.CreateInvite(ctx, admin, convID, time.Hour, 1, false)
+	if err != nil {
+		t.Fatalf("CreateInvite: %v", err)
+	}
+	if _, joined, pending, err := s.JoinViaInvite(ctx, guest, single.Token); err != nil || !joined || pending {
+		t.Fatalf("JoinViaInvite = joined %v, pending %v, %v; want joined", joined, pending, err)
+	}
+	if _, joined, _, err := s.JoinViaInvite(ctx, guest, single.Token); err != nil || joined {
+		t.Errorf("JoinViaInvite by a member = joined %v, %v; want the group back without using the invite", joined, err)
+	}
+	if _, _, _, err := s.JoinViaInvite(ctx, latecomer, single.Token); !errors.Is(err, ErrInviteExpired) {
+		t.Fatalf("expected a used up invite to fail with ErrInviteExpired, got %v", err)
+	}
+
+	gated, _ := s.CreateInvite(ctx, admin, convID, 0, 0, true)
+	got, joined, pending, err := s.JoinViaInvite(ctx, latecomer, gated.Token)
+	if err != nil || joined || !pending || len(got.ParticipantIDs) != 0 {
+		t.Fatalf("JoinViaInvite = %+v, joined %v, pending %v, %v; want a pending request without the participants", got, joined, pending, err)
+	}
+	if _, err := s.ListMessages(ctx, latecomer, convID, "", 50); !errors.Is(err, ErrConversationNotFound) {
+		t.Errorf("expected a pending requester to be unable to read the group, got %v", err)
+	}
+	if err := s.ApproveJoinRequest(ctx, member, convID, latecomer); !errors.Is(err, ErrNotAdmin) {
+		t.Errorf("expected a member approving to fail with ErrNotAdmin, got %v", err)
+	}
+	if err := s.ApproveJoinRequest(ctx, admin, convID, latecomer); err != nil {
+		t.Fatalf("ApproveJoinRequest: %v", err)
+	}
+	if _, err := s.ListMessages(ctx, latecomer, convID, "", 50); err != nil {
+		t.Errorf("ListMessages after approval: %v", err)
+	}
+
+	if err := s.RevokeInvite(ctx, admin, convID, gated.Token); err != nil {
+		t.Fatalf("RevokeInvite: %v", err)
+	}
+	if _, _, _, err := s.JoinViaInvite(ctx, uuid.NewString(), gated.Token); !errors.Is(err, ErrInviteNotFound) {
+		t.Errorf("expected a revoked invite to fail with ErrInviteNotFound, got %v", err)
+	}
+	if invites, _ := s.ListInvites(ctx, admin, convID); len(invites) != 1 || invites[0].Token != single.Token {
+		t.Errorf("ListInvites = %v, want only the unrevoked invite", invites)
+	}
+}
+
+func TestJoinViaInvite_PendingAndDeclinedRequestsDoNotUseTheInvite(t *testing.T) {
+	s := NewChatService(newFakeChatRepo(), nil)
+	ctx := context.Background()
+	admin, requester, other := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	conv, _ := s.CreateConversation(ctx, admin, nil, true, "garden")
+	convID := conv.ID.String()
+	gated, err := s.CreateInvite(ctx, admin, convID, 0, 1, true)
+	if err != nil {
+		t.Fatalf("CreateInvite: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, _, pending, err := s.JoinViaInvite(ctx, requester, gated.Token); err != nil || !pending {
+			t.Fatalf("JoinViaInvite #%d = pending %v, %v; want a pending request", i+1, pending, err)
+		}
+	}
+	if invites, _ := s.ListInvites(ctx, admin, convID); len(invites) != 1 || invites[0].UseCount != 1 {
+		t.Fatalf("ListInvites = %+v, want one use for the repeated request", invites)
+	}
+
+	if err := s.DeclineJoinRequest(ctx, admin, convID, requester); err != nil {
+		t.Fatalf("DeclineJoinRequest: %v", err)
+	}
+	if _, _, pending, err := s.JoinViaInvite(ctx, other, gated.Token); err != nil || !pending {
+		t.Fatalf("JoinViaInvite after a decline = pending %v, %v; want the declined use given back", pending, err)
+	}
+}
@@ -3,65 +3,472 @@ package store
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/dykethecreator/GoApp/internal/chat/repository"
 	"github.com/dykethecreator/GoApp/pkg/domain"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type ChatStore struct{ db *sql.DB }
 
 func NewChatStore(db *sql.DB) repository.ChatRepository { return &ChatStore{db: db} }
 
-func (s *ChatStore) CreateConversation(ctx context.Context, participantIDs []string, isGroup bool, groupName string) (string, error) {
+// execer is satisfied by *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func (s *ChatStore) CreateConversation(ctx context.Context, creatorID string, participantIDs []string, isGroup bool, groupName string) (string, error) {
 	id := uuid.New()
 	var grpName *string
 	if groupName != "" {
 		grpName = &groupName
 	}
-	if _, err := s.db.ExecContext(ctx,
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
 		`INSERT INTO conversations(id, is_group, group_name, created_at) VALUES($1, $2, $3, NOW())`,
 		id, isGroup, grpName); err != nil {
 		return "", err
 	}
 	for _, uid := range participantIDs {
-		if _, err := s.db.ExecContext(ctx, `INSERT INTO conversation_participants(conversation_id, user_id, joined_at) VALUES($1,$2,NOW())`, id, uid); err != nil {
+		role, action := domain.MemberRole, domain.MemberAdded
+		if isGroup && uid == creatorID {
+			role, action = domain.AdminRole, domain.MemberCreated
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO conversation_participants(conversation_id, user_id, role, joined_at) VALUES($1,$2,$3,NOW())`, id, uid, role); err != nil {
 			return "", err
 		}
+		if isGroup {
+			if err := recordMembership(ctx, tx, id.String(), uid, creatorID, action); err != nil {
+				return "", err
+			}
+		}
 	}
-	return id.String(), nil
+	return id.String(), tx.Commit()
 }
 
-func (s *ChatStore) AddParticipant(ctx context.Context, conversationID, userID string) error {
-	_, err := s.db.ExecContext(ctx, `INSERT INTO conversation_participants(conversation_id, user_id, joined_at) VALUES($1,$2,NOW()) ON CONFLICT DO NOTHING`, conversationID, userID)
+func (s *ChatStore) AddParticipants(ctx context.Context, conversationID, actorID string, userIDs []string) ([]string, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		INSERT INTO conversation_participants(conversation_id, user_id, role, joined_at)
+		SELECT $1, u.id, 'member', NOW() FROM users u WHERE u.id = ANY($2::uuid[])
+		ON CONFLICT DO NOTHING
+		RETURNING user_id`, conversationID, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	added := []string{}
+	for rows.Next() {
+		var uid uuid.UUID
+		if err := rows.Scan(&uid); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		added = append(added, uid.String())
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, uid := range added {
+		if err := recordMembership(ctx, tx, conversationID, uid, actorID, domain.MemberAdded); err != nil {
+			return nil, err
+		}
+	}
+	return added, tx.Commit()
+}
+
+// promoteEarliestMember makes the longest-standing participant the admin of
+// each group in $1 that has participants but no admin left, returning whom
+// it promoted.
+const promoteEarliestMember = `
+	UPDATE conversation_participants p SET role = 'admin'
+	FROM (
+		SELECT DISTINCT ON (p2.conversation_id) p2.conversation_id, p2.user_id
+		FROM conversation_participants p2
+		JOIN conversations c ON c.id = p2.conversation_id
+		WHERE p2.conversation_id = ANY($1::uuid[]) AND c.is_group
+			AND NOT EXISTS (SELECT 1 FROM conversation_participants a WHERE a.conversation_id = p2.conversation_id AND a.role = 'admin')
+		ORDER BY p2.conversation_id, p2.joined_at, p2.user_id
+	) first
+	WHERE p.conversation_id = first.conversation_id AND p.user_id = first.user_id
+	RETURNING p.conversation_id, p.user_id`
+
+func (s *ChatStore) RemoveParticipant(ctx context.Context, conversationID, userID, actorID string, action domain.MembershipAction) (bool, string, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, "", err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM conversation_participants WHERE conversation_id = $1 AND user_id = $2`, conversationID, userID)
+	if err != nil {
+		return false, "", err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, "", err
+	}
+	if err := recordMembership(ctx, tx, conversationID, userID, actorID, action); err != nil {
+		return false, "", err
+	}
+
+	var promoted string
+	err = tx.QueryRowContext(ctx, promoteEarliestMember, pq.Array([]string{conversationID})).Scan(new(uuid.UUID), &promoted)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return false, "", err
+	default:
+		if err := recordMembership(ctx, tx, conversationID, promoted, "", domain.MemberPromoted); err != nil {
+			return false, "", err
+		}
+	}
+	// A group nobody is left in is gone
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM conversations c WHERE c.id = $1
+			AND NOT EXISTS (SELECT 1 FROM conversation_participants p WHERE p.conversation_id = c.id)`, conversationID); err != nil {
+		return false, "", err
+	}
+	return true, promoted, tx.Commit()
+}
+
+func (s *ChatStore) SetParticipantRole(ctx context.Context, conversationID, userID, actorID string, role domain.ChatMemberRole) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Lock the admins so two admins cannot demote each other at once
+	if _, err := tx.ExecContext(ctx, `SELECT 1 FROM conversation_participants WHERE conversation_id = $1 AND role = 'admin' FOR UPDATE`, conversationID); err != nil {
+		return false, err
+	}
+	res, err := tx.ExecContext(ctx, `
+		UPDATE conversation_participants SET role = $3
+		WHERE conversation_id = $1 AND user_id = $2 AND role <> $3
+			AND ($3 = 'admin' OR EXISTS (
+				SELECT 1 FROM conversation_participants a
+				WHERE a.conversation_id = $1 AND a.role = 'admin' AND a.user_id <> $2))`,
+		conversationID, userID, role)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	action := domain.MemberPromoted
+	if role != domain.AdminRole {
+		action = domain.MemberDemoted
+	}
+	if err := recordMembership(ctx, tx, conversationID, userID, actorID, action); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// recordMembership adds a change to the membership history of a group; an
+// empty actorID records an automatic change.
+func recordMembership(ctx context.Context, db execer, conversationID, userID, actorID string, action domain.MembershipAction) error {
+	var actor *string
+	if actorID != "" {
+		actor = &actorID
+	}
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO conversation_membership_events(conversation_id, user_id, actor_id, action, created_at)
+		VALUES($1, $2, $3, $4, NOW())`, conversationID, userID, actor, action)
 	return err
 }
 
+const inviteColumns = `id, conversation_id, token, created_by, expires_at, max_uses, use_count, requires_approval, revoked_at, created_at`
+
+func scanInvite(row interface{ Scan(...any) error }) (*domain.GroupInvite, error) {
+	var inv domain.GroupInvite
+	var createdBy uuid.NullUUID
+	var expiresAt, revokedAt sql.NullTime
+	var maxUses sql.NullInt64
+	if err := row.Scan(&inv.ID, &inv.ConversationID, &inv.Token, &createdBy, &expiresAt, &maxUses, &inv.UseCount, &inv.RequiresApproval, &revokedAt, &inv.CreatedAt); err != nil {
+		return nil, err
+	}
+	if createdBy.Valid {
+		inv.CreatedBy = &createdBy.UUID
+	}
+	if expiresAt.Valid {
+		inv.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		inv.RevokedAt = &revokedAt.Time
+	}
+	inv.MaxUses = int(maxUses.Int64)
+	return &inv, nil
+}
+
+func (s *ChatStore) CreateInvite(ctx context.Context, inv *domain.GroupInvite) (*domain.GroupInvite, error) {
+	var maxUses *int
+	if inv.MaxUses > 0 {
+		maxUses = &inv.MaxUses
+	}
+	return scanInvite(s.db.QueryRowContext(ctx, `
+		INSERT INTO group_invites(conversation_id, token, created_by, expires_at, max_uses, requires_approval, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING `+inviteColumns,
+		inv.ConversationID, inv.Token, inv.CreatedBy, inv.ExpiresAt, maxUses, inv.RequiresApproval))
+}
+
+func (s *ChatStore) GetInvite(ctx context.Context, token string) (*domain.GroupInvite, error) {
+	inv, err := scanInvite(s.db.QueryRowContext(ctx, `SELECT `+inviteColumns+` FROM group_invites WHERE token = $1`, token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return inv, err
+}
+
+func (s *ChatStore) ListInvites(ctx context.Context, conversationID string) ([]*domain.GroupInvite, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+inviteColumns+` FROM group_invites
+		WHERE conversation_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC`, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []*domain.GroupInvite{}
+	for rows.Next() {
+		inv, err := scanInvite(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, inv)
+	}
+	return out, rows.Err()
+}
+
+func (s *ChatStore) RevokeInvite(ctx context.Context, conversationID, token string) (bool, error) {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE group_invites SET revoked_at = NOW()
+		WHERE conversation_id = $1 AND token = $2 AND revoked_at IS NULL`, conversationID, token)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s *ChatStore) UseInvite(ctx context.Context, inviteID, userID string) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Locking the invite row serializes concurrent joins, so they cannot
+	// exceed max_uses
+	var conversationID string
+	var requiresApproval, usable bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT conversation_id, requires_approval,
+			revoked_at IS NULL
+				AND (expires_at IS NULL OR expires_at > NOW())
+				AND (max_uses IS NULL OR use_count < max_uses)
+		FROM group_invites WHERE id = $1
+		FOR UPDATE`, inviteID).Scan(&conversationID, &requiresApproval, &usable)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if requiresApproval {
+		// Asking again while a request is pending neither counts nor needs a use left
+		var pending bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS(SELECT 1 FROM group_join_requests WHERE conversation_id = $1 AND user_id = $2)`,
+			conversationID, userID).Scan(&pending)
+		if err != nil || pending {
+			return pending, err
+		}
+	}
+	if !usable {
+		return false, nil
+	}
+
+	// Only a new join request or member counts as a use
+	var added bool
+	if requiresApproval {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO group_join_requests(conversation_id, user_id, invite_id, requested_at)
+			VALUES($1, $2, $3, NOW())
+			ON CONFLICT DO NOTHING`, conversationID, userID, inviteID)
+		if err != nil {
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return false, err
+		}
+		added = n > 0
+	} else if added, err = joinGroup(ctx, tx, conversationID, userID, userID); err != nil {
+		return false, err
+	}
+	if added {
+		if _, err := tx.ExecContext(ctx, `UPDATE group_invites SET use_count = use_count + 1 WHERE id = $1`, inviteID); err != nil {
+			return false, err
+		}
+	}
+	return true, tx.Commit()
+}
+
+func (s *ChatStore) ListJoinRequests(ctx context.Context, conversationID string) ([]*domain.JoinRequest, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT conversation_id, user_id, invite_id, requested_at FROM group_join_requests
+		WHERE conversation_id = $1 ORDER BY requested_at`, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []*domain.JoinRequest{}
+	for rows.Next() {
+		var r domain.JoinRequest
+		var inviteID uuid.NullUUID
+		if err := rows.Scan(&r.ConversationID, &r.UserID, &inviteID, &r.RequestedAt); err != nil {
+			return nil, err
+		}
+		if inviteID.Valid {
+			r.InviteID = &inviteID.UUID
+		}
+		out = append(out, &r)
+	}
+	return out, rows.Err()
+}
+
+func (s *ChatStore) ResolveJoinRequest(ctx context.Context, conversationID, userID, actorID string, approve bool) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var inviteID uuid.NullUUID
+	err = tx.QueryRowContext(ctx, `
+		DELETE FROM group_join_requests WHERE conversation_id = $1 AND user_id = $2
+		RETURNING invite_id`, conversationID, userID).Scan(&inviteID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if approve {
+		if _, err := joinGroup(ctx, tx, conversationID, userID, actorID); err != nil {
+			return false, err
+		}
+	} else if inviteID.Valid {
+		// A declined request gives its use of the invite back
+		if _, err := tx.ExecContext(ctx, `UPDATE group_invites SET use_count = use_count - 1 WHERE id = $1 AND use_count > 0`, inviteID.UUID); err != nil {
+			return false, err
+		}
+	}
+	return true, tx.Commit()
+}
+
+// joinGroup adds the user as a member who joined through an invite, unless
+// they already are a participant. It reports whether the user was added.
+func joinGroup(ctx context.Context, tx *sql.Tx, conversationID, userID, actorID string) (bool, error) {
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO conversation_participants(conversation_id, user_id, role, joined_at)
+		VALUES($1, $2, 'member', NOW())
+		ON CONFLICT DO NOTHING`, conversationID, userID)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	return true, recordMembership(ctx, tx, conversationID, userID, actorID, domain.MemberJoined)
+}
+
+func (s *ChatStore) ParticipantRole(ctx context.Context, conversationID, userID string) (domain.ChatMemberRole, error) {
+	var role domain.ChatMemberRole
+	err := s.db.QueryRowContext(ctx, `SELECT role FROM conversation_participants WHERE conversation_id = $1 AND user_id = $2`, conversationID, userID).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return role, err
+}
+
 func (s *ChatStore) InsertMessage(ctx context.Context, m *domain.ChatMessage) (*domain.ChatMessage, error) {
 	if m.ID == uuid.Nil {
 		m.ID = uuid.New()
 	}
 	err := s.db.QueryRowContext(ctx, `
-		INSERT INTO messages(id, conversation_id, sender_id, content, media_url, media_type, client_message_id, created_at) 
-		VALUES($1,$2,$3,$4,$5,$6,$7,NOW()) 
+		INSERT INTO messages(id, conversation_id, sender_id, content, media_url, media_type, client_message_id, is_system, reply_to_message_id, created_at) 
+		VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,NOW()) 
 		RETURNING created_at
-	`, m.ID, m.ConversationID, m.SenderID, m.Content, m.MediaURL, m.MediaType, m.ClientMessageID).Scan(&m.CreatedAt)
+	`, m.ID, m.ConversationID, m.SenderID, m.Content, m.MediaURL, m.MediaType, m.ClientMessageID, m.IsSystem, m.ReplyToMessageID).Scan(&m.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (s *ChatStore) ListMessages(ctx context.Context, conversationID string, beforeID string, limit int) ([]*domain.ChatMessage, error) {
+// messageColumns are the columns scanMessage reads, in order.
+const messageColumns = `id, conversation_id, sender_id, content, media_url, media_type, client_message_id, is_system, created_at, edited_at, deleted_at, deleted_by, reply_to_message_id`
+
+func scanMessage(row interface{ Scan(...any) error }) (*domain.ChatMessage, error) {
+	var m domain.ChatMessage
+	var mediaURL, mediaType, clientID sql.NullString
+	var editedAt, deletedAt sql.NullTime
+	var deletedBy, replyTo uuid.NullUUID
+	if err := row.Scan(&m.ID, &m.ConversationID, &m.SenderID, &m.Content, &mediaURL, &mediaType, &clientID, &m.IsSystem, &m.CreatedAt, &editedAt, &deletedAt, &deletedBy, &replyTo); err != nil {
+		return nil, err
+	}
+	if mediaURL.Valid {
+		m.MediaURL = &mediaURL.String
+	}
+	if mediaType.Valid {
+		m.MediaType = &mediaType.String
+	}
+	if clientID.Valid {
+		m.ClientMessageID = &clientID.String
+	}
+	if editedAt.Valid {
+		m.EditedAt = &editedAt.Time
+	}
+	if deletedAt.Valid {
+		m.DeletedAt = &deletedAt.Time
+	}
+	if deletedBy.Valid {
+		m.DeletedBy = &deletedBy.UUID
+	}
+	if replyTo.Valid {
+		m.ReplyToMessageID = &replyTo.UUID
+	}
+	return &m, nil
+}
+
+func (s *ChatStore) ListMessages(ctx context.Context, conversationID, userID string, beforeID string, limit int) ([]*domain.ChatMessage, error) {
 	if limit <= 0 {
 		limit = 50
 	}
+	const visible = ` AND NOT EXISTS (SELECT 1 FROM hidden_messages h WHERE h.user_id = $2 AND h.message_id = messages.id)`
 	var rows *sql.Rows
 	var err error
 	if beforeID != "" {
-		rows, err = s.db.QueryContext(ctx, `SELECT id, conversation_id, sender_id, content, media_url, media_type, client_message_id, created_at FROM messages WHERE conversation_id=$1 AND created_at < (SELECT created_at FROM messages WHERE id=$2) ORDER BY created_at DESC LIMIT $3`, conversationID, beforeID, limit)
+		rows, err = s.db.QueryContext(ctx, `SELECT `+messageColumns+` FROM messages WHERE conversation_id=$1`+visible+` AND created_at < (SELECT created_at FROM messages WHERE id=$3) ORDER BY created_at DESC LIMIT $4`, conversationID, userID, beforeID, limit)
 	} else {
-		rows, err = s.db.QueryContext(ctx, `SELECT id, conversation_id, sender_id, content, media_url, media_type, client_message_id, created_at FROM messages WHERE conversation_id=$1 ORDER BY created_at DESC LIMIT $2`, conversationID, limit)
+		rows, err = s.db.QueryContext(ctx, `SELECT `+messageColumns+` FROM messages WHERE conversation_id=$1`+visible+` ORDER BY created_at DESC LIMIT $3`, conversationID, userID, limit)
 	}
 	if err != nil {
 		return nil, err
@@ -69,31 +476,163 @@ func (s *ChatStore) ListMessages(ctx context.Context, conversationID string, bef
 	defer rows.Close()
 	out := []*domain.ChatMessage{}
 	for rows.Next() {
-		var m domain.ChatMessage
-		var mediaURL, mediaType, clientID sql.NullString
-		if err := rows.Scan(&m.ID, &m.ConversationID, &m.SenderID, &m.Content, &mediaURL, &mediaType, &clientID, new(sql.NullTime)); err != nil {
+		m, err := scanMessage(rows)
+		if err != nil {
 			return nil, err
 		}
-		if mediaURL.Valid {
-			s := mediaURL.String
-			m.MediaURL = &s
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func (s *ChatStore) GetMessage(ctx context.Context, messageID string) (*domain.ChatMessage, error) {
+	m, err := scanMessage(s.db.QueryRowContext(ctx, `SELECT `+messageColumns+` FROM messages WHERE id = $1`, messageID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return m, err
+}
+
+func (s *ChatStore) GetMessages(ctx context.Context, messageIDs []string) ([]*domain.ChatMessage, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+messageColumns+` FROM messages WHERE id = ANY($1::uuid[])`, pq.Array(messageIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []*domain.ChatMessage{}
+	for rows.Next() {
+		m, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
 		}
-		if mediaType.Valid {
-			s := mediaType.String
-			m.MediaType = &s
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+func (s *ChatStore) EditMessage(ctx context.Context, messageID, content string) (*domain.ChatMessage, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Locking the row keeps concurrent edits from recording the same version twice
+	var previous string
+	err = tx.QueryRowContext(ctx, `SELECT content FROM messages WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, messageID).Scan(&previous)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO message_edits(message_id, content, replaced_at) VALUES($1, $2, NOW())`, messageID, previous); err != nil {
+		return nil, err
+	}
+	m, err := scanMessage(tx.QueryRowContext(ctx, `
+		UPDATE messages SET content = $2, edited_at = NOW() WHERE id = $1
+		RETURNING `+messageColumns, messageID, content))
+	if err != nil {
+		return nil, err
+	}
+	return m, tx.Commit()
+}
+
+func (s *ChatStore) DeleteMessage(ctx context.Context, messageID, deletedBy string) (*domain.ChatMessage, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	m, err := scanMessage(tx.QueryRowContext(ctx, `
+		UPDATE messages SET content = '', media_url = NULL, media_type = NULL, deleted_at = NOW(), deleted_by = $2
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING `+messageColumns, messageID, deletedBy))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Earlier versions and reactions must not outlive the message
+	for _, q := range []string{
+		`DELETE FROM message_edits WHERE message_id = $1`,
+		`DELETE FROM message_reactions WHERE message_id = $1`,
+	} {
+		if _, err := tx.ExecContext(ctx, q, messageID); err != nil {
+			return nil, err
 		}
-		if clientID.Valid {
-			s := clientID.String
-			m.ClientMessageID = &s
+	}
+	return m, tx.Commit()
+}
+
+func (s *ChatStore) HideMessage(ctx context.Context, messageID, userID string) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO hidden_messages(message_id, user_id, hidden_at) VALUES($1, $2, NOW()) ON CONFLICT DO NOTHING`, messageID, userID)
+	return err
+}
+
+func (s *ChatStore) SetReaction(ctx context.Context, messageID, userID, emoji string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO message_reactions(message_id, user_id, reaction_emoji, created_at) VALUES($1, $2, $3, NOW())
+		ON CONFLICT (message_id, user_id) DO UPDATE SET reaction_emoji = EXCLUDED.reaction_emoji, created_at = NOW()`,
+		messageID, userID, emoji)
+	return err
+}
+
+func (s *ChatStore) RemoveReaction(ctx context.Context, messageID, userID string) (bool, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM message_reactions WHERE message_id = $1 AND user_id = $2`, messageID, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s *ChatStore) ListReactions(ctx context.Context, messageIDs []string) ([]*domain.MessageReaction, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT message_id, user_id, reaction_emoji, created_at FROM message_reactions
+		WHERE message_id = ANY($1::uuid[]) ORDER BY created_at`, pq.Array(messageIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []*domain.MessageReaction{}
+	for rows.Next() {
+		var r domain.MessageReaction
+		if err := rows.Scan(&r.MessageID, &r.UserID, &r.ReactionEmoji, &r.CreatedAt); err != nil {
+			return nil, err
 		}
-		out = append(out, &m)
+		out = append(out, &r)
 	}
-	return out, nil
+	return out, rows.Err()
+}
+
+const conversationColumns = `c.id, c.is_group, c.group_name, c.group_description, c.group_icon_url, c.only_admins_edit_info, c.announcement_mode, c.created_at`
+
+// scanConversation scans conversationColumns followed by extra.
+func scanConversation(row interface{ Scan(...any) error }, extra ...any) (*domain.Conversation, error) {
+	var conv domain.Conversation
+	var groupName, description, iconURL sql.NullString
+	dest := append([]any{&conv.ID, &conv.IsGroup, &groupName, &description, &iconURL, &conv.OnlyAdminsEditInfo, &conv.AnnouncementMode, &conv.CreatedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if groupName.Valid {
+		conv.GroupName = &groupName.String
+	}
+	if description.Valid {
+		conv.GroupDescription = &description.String
+	}
+	if iconURL.Valid {
+		conv.GroupIconURL = &iconURL.String
+	}
+	return &conv, nil
 }
 
 func (s *ChatStore) ListConversations(ctx context.Context, userID string) ([]*domain.Conversation, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT c.id, c.is_group, c.group_name, c.created_at 
+		SELECT `+conversationColumns+`, p.muted_until
 		FROM conversations c 
 		JOIN conversation_participants p ON p.conversation_id = c.id 
 		WHERE p.user_id = $1 
@@ -106,38 +645,120 @@ func (s *ChatStore) ListConversations(ctx context.Context, userID string) ([]*do
 
 	out := []*domain.Conversation{}
 	for rows.Next() {
-		var conv domain.Conversation
-		var groupName sql.NullString
-		if err := rows.Scan(&conv.ID, &conv.IsGroup, &groupName, &conv.CreatedAt); err != nil {
+		var mutedUntil sql.NullTime
+		conv, err := scanConversation(rows, &mutedUntil)
+		if err != nil {
 			return nil, err
 		}
-		if groupName.Valid {
-			s := groupName.String
-			conv.GroupName = &s
+		if mutedUntil.Valid {
+			conv.MutedUntil = &mutedUntil.Time
 		}
 
-		// Fetch participant IDs for this conversation
-		participantRows, pErr := s.db.QueryContext(ctx, `
-			SELECT user_id 
-			FROM conversation_participants 
-			WHERE conversation_id = $1
-		`, conv.ID)
-		if pErr != nil {
-			return nil, pErr
+		out = append(out, conv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, conv := range out {
+		if err := s.loadParticipants(ctx, conv); err != nil {
+			return nil, err
 		}
+	}
+	return out, nil
+}
 
-		conv.ParticipantIDs = []uuid.UUID{}
-		for participantRows.Next() {
-			var pid uuid.UUID
-			if err := participantRows.Scan(&pid); err != nil {
-				participantRows.Close()
-				return nil, err
-			}
-			conv.ParticipantIDs = append(conv.ParticipantIDs, pid)
+func (s *ChatStore) GetConversation(ctx context.Context, conversationID string) (*domain.Conversation, error) {
+	conv, err := scanConversation(s.db.QueryRowContext(ctx, `SELECT `+conversationColumns+` FROM conversations c WHERE c.id = $1`, conversationID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadParticipants(ctx, conv); err != nil {
+		return nil, err
+	}
+	return conv, nil
+}
+
+func (s *ChatStore) UpdateGroupInfo(ctx context.Context, conversationID string, name, description, iconURL *string) (bool, error) {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE conversations SET
+			group_name = COALESCE($2, group_name),
+			group_description = CASE WHEN $3::text IS NULL THEN group_description ELSE NULLIF($3, '') END,
+			group_icon_url = CASE WHEN $4::text IS NULL THEN group_icon_url ELSE NULLIF($4, '') END
+		WHERE id = $1 AND is_group`, conversationID, name, description, iconURL)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s *ChatStore) UpdateGroupSettings(ctx context.Context, conversationID string, onlyAdminsEditInfo, announcementMode *bool) (bool, error) {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE conversations SET
+			only_admins_edit_info = COALESCE($2, only_admins_edit_info),
+			announcement_mode = COALESCE($3, announcement_mode)
+		WHERE id = $1 AND is_group`, conversationID, onlyAdminsEditInfo, announcementMode)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// loadParticipants sets the participant and admin IDs of conv, longest-standing first.
+func (s *ChatStore) loadParticipants(ctx context.Context, conv *domain.Conversation) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT user_id, role FROM conversation_participants WHERE conversation_id = $1 ORDER BY joined_at, user_id`, conv.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	conv.ParticipantIDs = []uuid.UUID{}
+	conv.AdminIDs = nil
+	for rows.Next() {
+		var pid uuid.UUID
+		var role domain.ChatMemberRole
+		if err := rows.Scan(&pid, &role); err != nil {
+			return err
 		}
-		participantRows.Close()
+		conv.ParticipantIDs = append(conv.ParticipantIDs, pid)
+		if role == domain.AdminRole {
+			conv.AdminIDs = append(conv.AdminIDs, pid)
+		}
+	}
+	return rows.Err()
+}
 
-		out = append(out, &conv)
+func (s *ChatStore) SetMutedUntil(ctx context.Context, conversationID, userID string, until *time.Time) (bool, error) {
+	res, err := s.db.ExecContext(ctx, `UPDATE conversation_participants SET muted_until = $3 WHERE conversation_id = $1 AND user_id = $2`, conversationID, userID, until)
+	if err != nil {
+		return false, err
 	}
-	return out, nil
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s *ChatStore) MutedParticipants(ctx context.Context, conversationID string, userIDs []string, at time.Time) (map[string]bool, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT user_id FROM conversation_participants
+		WHERE conversation_id = $1 AND user_id = ANY($2::uuid[]) AND muted_until > $3`,
+		conversationID, pq.Array(userIDs), at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	muted := map[string]bool{}
+	for rows.Next() {
+		var uid uuid.UUID
+		if err := rows.Scan(&uid); err != nil {
+			return nil, err
+		}
+		muted[uid.String()] = true
+	}
+	return muted, rows.Err()
 }
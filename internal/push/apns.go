@@ -0,0 +1,135 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	apnsProductionHost = "https://api.push.apple.com"
+	apnsSandboxHost    = "https://api.sandbox.push.apple.com"
+	// Apple rejects provider tokens older than an hour and throttles
+	// refreshes more frequent than every 20 minutes.
+	apnsTokenLifetime = 50 * time.Minute
+	apnsMaxCollapseID = 64
+)
+
+// APNsProvider sends notifications through the Apple Push Notification
+// service over HTTP/2, authenticating with a .p8 token signing key.
+type APNsProvider struct {
+	key    *ecdsa.PrivateKey
+	keyID  string
+	teamID string
+	topic  string // app bundle ID
+	host   string
+	client *http.Client
+
+	mu       sync.Mutex
+	jwt      string
+	issuedAt time.Time
+}
+
+// NewAPNsProvider creates an APNsProvider for the app with bundle ID topic.
+// sandbox selects the development environment.
+func NewAPNsProvider(keyFile, keyID, teamID, topic string, sandbox bool) (*APNsProvider, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read APNs key: %w", err)
+	}
+	key, err := jwt.ParseECPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid APNs key: %w", err)
+	}
+	host := apnsProductionHost
+	if sandbox {
+		host = apnsSandboxHost
+	}
+	return &APNsProvider{
+		key:    key,
+		keyID:  keyID,
+		teamID: teamID,
+		topic:  topic,
+		host:   host,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (p *APNsProvider) Send(ctx context.Context, token string, n *Notification) error {
+	providerToken, err := p.providerToken()
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]any{
+		"aps": map[string]any{
+			"alert":     map[string]string{"title": n.Title, "body": n.Body},
+			"sound":     "default",
+			"thread-id": n.ConversationID,
+		},
+		"conversation_id": n.ConversationID,
+		"message_id":      n.MessageID,
+		"count":           n.Count,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.host+"/3/device/"+token, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("authorization", "bearer "+providerToken)
+	req.Header.Set("apns-topic", p.topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	if id := n.CollapseKey; id != "" && len(id) <= apnsMaxCollapseID {
+		req.Header.Set("apns-collapse-id", id)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("apns: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var e struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&e)
+	switch {
+	case resp.StatusCode == http.StatusGone, e.Reason == "BadDeviceToken", e.Reason == "Unregistered", e.Reason == "DeviceTokenNotForTopic":
+		return ErrInvalidToken
+	default:
+		return fmt.Errorf("apns: %s %s", resp.Status, e.Reason)
+	}
+}
+
+// providerToken returns the signed JWT sent with every request, renewing it
+// before Apple stops accepting it.
+func (p *APNsProvider) providerToken() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	if p.jwt != "" && now.Sub(p.issuedAt) < apnsTokenLifetime {
+		return p.jwt, nil
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{"iss": p.teamID, "iat": now.Unix()})
+	t.Header["kid"] = p.keyID
+	signed, err := t.SignedString(p.key)
+	if err != nil {
+		return "", err
+	}
+	p.jwt, p.issuedAt = signed, now
+	return signed, nil
+}
@@ -0,0 +1,193 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dykethecreator/GoApp/pkg/config"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/dykethecreator/GoApp/proto"
+)
+
+// DefaultCollapseWindow is how long messages are gathered into one
+// notification when no window is configured.
+const DefaultCollapseWindow = 3 * time.Second
+
+const maxPreviewLength = 100 // runes of message content shown in a notification
+
+// TokenStore lists and unregisters the push tokens of device sessions.
+type TokenStore interface {
+	ListPushTokens(ctx context.Context, userIDs []string) ([]*domain.PushToken, error)
+	DeletePushToken(ctx context.Context, token string) error
+}
+
+// MuteChecker reports which users have muted a conversation.
+type MuteChecker interface {
+	MutedParticipants(ctx context.Context, conversationID string, userIDs []string, at time.Time) (map[string]bool, error)
+}
+
+// Dispatcher sends push notifications of new messages to offline users. The
+// first message to a user in a conversation starts a collapse window; the
+// messages arriving within it are sent as one notification when it ends.
+type Dispatcher struct {
+	providers map[string]Provider // by domain.PushProvider* name
+	tokens    TokenStore
+	mutes     MuteChecker // nil: mutes are not checked
+	window    time.Duration
+
+	mu      sync.Mutex
+	pending map[pendingKey]*pendingNotification
+}
+
+type pendingKey struct {
+	userID         string
+	conversationID string
+}
+
+type pendingNotification struct {
+	latest *proto.NewMessage
+	count  int
+	timer  *time.Timer
+}
+
+func NewDispatcher(providers map[string]Provider, tokens TokenStore, mutes MuteChecker, window time.Duration) *Dispatcher {
+	if window <= 0 {
+		window = DefaultCollapseWindow
+	}
+	return &Dispatcher{
+		providers: providers,
+		tokens:    tokens,
+		mutes:     mutes,
+		window:    window,
+		pending:   make(map[pendingKey]*pendingNotification),
+	}
+}
+
+// NewDispatcherFromConfig creates a Dispatcher with the providers enabled in
+// cfg. It returns nil when no provider is configured, which disables push
+// notifications.
+func NewDispatcherFromConfig(cfg config.PushConfig, tokens TokenStore, mutes MuteChecker) (*Dispatcher, error) {
+	providers, err := NewProviders(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(providers) == 0 {
+		log.Printf("Warning: no push provider configured, offline users get no notifications")
+		return nil, nil
+	}
+	return NewDispatcher(providers, tokens, mutes, cfg.CollapseWindow), nil
+}
+
+// NotifyOffline queues a notification of msg for each recipient; the hub
+// calls it with the participants that have no realtime stream open. It does
+// not block.
+func (d *Dispatcher) NotifyOffline(conversationID string, recipientIDs []string, msg *proto.NewMessage) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, uid := range recipientIDs {
+		key := pendingKey{userID: uid, conversationID: conversationID}
+		if p, ok := d.pending[key]; ok {
+			p.latest = msg
+			p.count++
+			continue
+		}
+		p := &pendingNotification{latest: msg, count: 1}
+		p.timer = time.AfterFunc(d.window, func() { d.flush(key, p) })
+		d.pending[key] = p
+	}
+}
+
+// Flush sends every queued notification now, e.g. before shutting down.
+func (d *Dispatcher) Flush() {
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = make(map[pendingKey]*pendingNotification)
+	d.mu.Unlock()
+
+	for key, p := range pending {
+		p.timer.Stop()
+		d.send(key, p)
+	}
+}
+
+// flush sends p once its collapse window ends, unless Flush already did.
+func (d *Dispatcher) flush(key pendingKey, p *pendingNotification) {
+	d.mu.Lock()
+	if d.pending[key] != p {
+		d.mu.Unlock()
+		return
+	}
+	delete(d.pending, key)
+	d.mu.Unlock()
+	d.send(key, p)
+}
+
+func (d *Dispatcher) send(key pendingKey, p *pendingNotification) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if d.mutes != nil {
+		muted, err := d.mutes.MutedParticipants(ctx, key.conversationID, []string{key.userID}, time.Now())
+		if err != nil {
+			// Fail open: an unwanted notification beats a missed message
+			log.Printf("[Push] Failed to load mutes of conversation %s: %v", key.conversationID, err)
+		} else if muted[key.userID] {
+			return
+		}
+	}
+
+	tokens, err := d.tokens.ListPushTokens(ctx, []string{key.userID})
+	if err != nil {
+		log.Printf("[Push] Failed to load push tokens of user %s: %v", key.userID, err)
+		return
+	}
+	n := newNotification(p.latest, p.count)
+	for _, t := range tokens {
+		provider := d.providers[t.Provider]
+		if provider == nil {
+			continue
+		}
+		err := provider.Send(ctx, t.Token, n)
+		switch {
+		case errors.Is(err, ErrInvalidToken):
+			log.Printf("[Push] %s rejected the token of user %s device %s, unregistering it", t.Provider, key.userID, t.FamilyID)
+			if err := d.tokens.DeletePushToken(ctx, t.Token); err != nil {
+				log.Printf("[Push] Failed to unregister push token: %v", err)
+			}
+		case err != nil:
+			log.Printf("[Push] Failed to notify user %s device %s via %s: %v", key.userID, t.FamilyID, t.Provider, err)
+		}
+	}
+}
+
+// newNotification describes count messages of a conversation, msg the latest.
+func newNotification(msg *proto.NewMessage, count int) *Notification {
+	title := "New message"
+	if count > 1 {
+		title = fmt.Sprintf("%d new messages", count)
+	}
+	return &Notification{
+		ConversationID: msg.ConversationId,
+		MessageID:      msg.MessageId,
+		Title:          title,
+		Body:           preview(msg),
+		Count:          count,
+		CollapseKey:    msg.ConversationId,
+	}
+}
+
+func preview(msg *proto.NewMessage) string {
+	body := msg.Content
+	if body == "" && msg.MediaUrl != "" {
+		return "Sent an attachment"
+	}
+	if utf8.RuneCountInString(body) > maxPreviewLength {
+		body = string([]rune(body)[:maxPreviewLength]) + "…"
+	}
+	return body
+}
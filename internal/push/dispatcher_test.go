@@ -0,0 +1,74 @@
+package push
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/dykethecreator/GoApp/proto"
+	"github.com/google/uuid"
+)
+
+type fakeTokens struct {
+	tokens  map[string][]*domain.PushToken // by user ID
+	deleted []string
+}
+
+func (f *fakeTokens) ListPushTokens(ctx context.Context, userIDs []string) ([]*domain.PushToken, error) {
+	var out []*domain.PushToken
+	for _, uid := range userIDs {
+		out = append(out, f.tokens[uid]...)
+	}
+	return out, nil
+}
+
+func (f *fakeTokens) DeletePushToken(ctx context.Context, token string) error {
+	f.deleted = append(f.deleted, token)
+	return nil
+}
+
+type fakeMutes map[string]bool // muted user IDs
+
+func (f fakeMutes) MutedParticipants(ctx context.Context, conversationID string, userIDs []string, at time.Time) (map[string]bool, error) {
+	return f, nil
+}
+
+type rejectingProvider struct{}
+
+func (rejectingProvider) Send(ctx context.Context, token string, n *Notification) error {
+	return ErrInvalidToken
+}
+
+func TestDispatcher_CollapsesBurstsAndSkipsMuted(t *testing.T) {
+	alice, bob, carol := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	tokens := &fakeTokens{tokens: map[string][]*domain.PushToken{
+		alice: {{Provider: domain.PushProviderFCM, Token: "alice-phone"}},
+		bob:   {{Provider: domain.PushProviderAPNs, Token: "bob-phone"}},
+		carol: {{Provider: domain.PushProviderAPNs, Token: "carol-old-phone"}},
+	}}
+	local := NewLocalProvider()
+	d := NewDispatcher(map[string]Provider{domain.PushProviderFCM: local, domain.PushProviderAPNs: local}, tokens, fakeMutes{bob: true}, time.Hour)
+
+	conv := uuid.NewString()
+	for _, text := range []string{"hi", "are you there?", "call me"} {
+		d.NotifyOffline(conv, []string{alice, bob}, &proto.NewMessage{ConversationId: conv, MessageId: uuid.NewString(), Content: text})
+	}
+	d.Flush()
+
+	got := local.Deliveries()
+	if len(got) != 1 {
+		t.Fatalf("got %d notifications, want 1 for alice (bob muted the conversation): %+v", len(got), got)
+	}
+	n := got[0].Notification
+	if got[0].Token != "alice-phone" || n.Count != 3 || n.Body != "call me" || n.CollapseKey != conv {
+		t.Errorf("notification = %+v to %s, want the 3 messages collapsed into the latest", n, got[0].Token)
+	}
+
+	d.providers[domain.PushProviderAPNs] = rejectingProvider{}
+	d.NotifyOffline(conv, []string{carol}, &proto.NewMessage{ConversationId: conv, MessageId: uuid.NewString(), Content: "hello"})
+	d.Flush()
+	if len(tokens.deleted) != 1 || tokens.deleted[0] != "carol-old-phone" {
+		t.Errorf("deleted tokens = %v, want the rejected token unregistered", tokens.deleted)
+	}
+}
@@ -0,0 +1,196 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	fcmEndpoint      = "https://fcm.googleapis.com"
+	fcmScope         = "https://www.googleapis.com/auth/firebase.messaging"
+	googleTokenURI   = "https://oauth2.googleapis.com/token"
+	fcmTokenLifetime = time.Hour
+)
+
+// FCMProvider sends notifications through the Firebase Cloud Messaging HTTP
+// v1 API, authenticating with a Google service account key.
+type FCMProvider struct {
+	projectID   string
+	clientEmail string
+	key         *rsa.PrivateKey
+	tokenURI    string
+	endpoint    string
+	client      *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// serviceAccount is the part of a Google service account JSON key FCM needs.
+type serviceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// NewFCMProvider creates an FCMProvider for the Firebase project, reading the
+// service account key from credentialsFile.
+func NewFCMProvider(projectID, credentialsFile string) (*FCMProvider, error) {
+	data, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read FCM credentials: %w", err)
+	}
+	var sa serviceAccount
+	if err := json.Unmarshal(data, &sa); err != nil {
+		return nil, fmt.Errorf("invalid FCM credentials: %w", err)
+	}
+	if sa.ClientEmail == "" || sa.PrivateKey == "" {
+		return nil, fmt.Errorf("invalid FCM credentials: client_email and private_key are required")
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(sa.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid FCM credentials: %w", err)
+	}
+	if sa.TokenURI == "" {
+		sa.TokenURI = googleTokenURI
+	}
+	return &FCMProvider{
+		projectID:   projectID,
+		clientEmail: sa.ClientEmail,
+		key:         key,
+		tokenURI:    sa.TokenURI,
+		endpoint:    fcmEndpoint,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification map[string]string `json:"notification"`
+	Data         map[string]string `json:"data"`
+	Android      map[string]any    `json:"android"`
+	APNs         map[string]any    `json:"apns"`
+}
+
+type fcmError struct {
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+func (p *FCMProvider) Send(ctx context.Context, token string, n *Notification) error {
+	accessToken, err := p.token(ctx)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]fcmMessage{"message": {
+		Token:        token,
+		Notification: map[string]string{"title": n.Title, "body": n.Body},
+		Data:         map[string]string{"conversation_id": n.ConversationID, "message_id": n.MessageID, "count": strconv.Itoa(n.Count)},
+		Android: map[string]any{
+			"collapse_key": n.CollapseKey,
+			"priority":     "HIGH",
+			"notification": map[string]string{"tag": n.CollapseKey},
+		},
+		APNs: map[string]any{"headers": map[string]string{"apns-collapse-id": n.CollapseKey}},
+	}})
+	if err != nil {
+		return err
+	}
+
+	u := p.endpoint + "/v1/projects/" + url.PathEscape(p.projectID) + "/messages:send"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fcm: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var e fcmError
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&e)
+	for _, d := range e.Error.Details {
+		if d.ErrorCode == "UNREGISTERED" {
+			return ErrInvalidToken
+		}
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrInvalidToken
+	}
+	return fmt.Errorf("fcm: %s %s: %s", resp.Status, e.Error.Status, e.Error.Message)
+}
+
+// token returns an OAuth2 access token for the service account, exchanging a
+// signed assertion for a new one when the cached token is about to expire.
+func (p *FCMProvider) token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	if p.accessToken != "" && now.Before(p.expiresAt) {
+		return p.accessToken, nil
+	}
+
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   p.clientEmail,
+		"scope": fcmScope,
+		"aud":   p.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(fcmTokenLifetime).Unix(),
+	}).SignedString(p.key)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fcm: access token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fcm: access token: %s", resp.Status)
+	}
+	var t struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return "", fmt.Errorf("fcm: access token: %w", err)
+	}
+	p.accessToken = t.AccessToken
+	// Renew a minute early so a token never expires in flight
+	p.expiresAt = now.Add(time.Duration(t.ExpiresIn)*time.Second - time.Minute)
+	return p.accessToken, nil
+}
@@ -0,0 +1,41 @@
+package push
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Delivery is a notification "sent" by the LocalProvider.
+type Delivery struct {
+	Token        string
+	Notification Notification
+	SentAt       time.Time
+}
+
+// LocalProvider logs notifications and keeps them in memory, for development
+// and tests.
+type LocalProvider struct {
+	mu         sync.RWMutex
+	deliveries []Delivery
+}
+
+func NewLocalProvider() *LocalProvider { return &LocalProvider{} }
+
+func (p *LocalProvider) Send(ctx context.Context, token string, n *Notification) error {
+	p.mu.Lock()
+	p.deliveries = append(p.deliveries, Delivery{Token: token, Notification: *n, SentAt: time.Now()})
+	p.mu.Unlock()
+	log.Printf("[LOCAL PUSH] To: %s | %s: %s (conversation %s)", token, n.Title, n.Body, n.ConversationID)
+	return nil
+}
+
+// Deliveries returns a copy of every notification sent so far, oldest first.
+func (p *LocalProvider) Deliveries() []Delivery {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	out := make([]Delivery, len(p.deliveries))
+	copy(out, p.deliveries)
+	return out
+}
@@ -0,0 +1,63 @@
+// Package push notifies users of new messages while none of their devices has
+// a realtime stream open. Devices register a token with a push provider
+// (Firebase Cloud Messaging or Apple Push Notification service); the
+// Dispatcher collapses bursts per user and conversation and skips muted
+// conversations.
+package push
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/dykethecreator/GoApp/pkg/config"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// ErrInvalidToken is returned by providers for tokens they no longer accept
+// (app uninstalled, token rotated); the token is then unregistered.
+var ErrInvalidToken = errors.New("push token is no longer valid")
+
+// Notification is one push notification, possibly standing for several
+// messages of a conversation.
+type Notification struct {
+	ConversationID string
+	MessageID      string // latest message
+	Title          string
+	Body           string
+	Count          int    // messages collapsed into this notification
+	CollapseKey    string // devices replace earlier notifications with the same key
+}
+
+// Provider delivers notifications to the tokens of one push service.
+type Provider interface {
+	Send(ctx context.Context, token string, n *Notification) error
+}
+
+// NewProviders builds the providers enabled in cfg, keyed by the provider
+// name devices register with (domain.PushProviderFCM, domain.PushProviderAPNs).
+// With cfg.Local every token is "delivered" to one LocalProvider.
+func NewProviders(cfg config.PushConfig) (map[string]Provider, error) {
+	if cfg.Local {
+		local := NewLocalProvider()
+		log.Printf("[LOCAL PUSH] Push notifications are logged, not delivered")
+		return map[string]Provider{domain.PushProviderFCM: local, domain.PushProviderAPNs: local}, nil
+	}
+
+	providers := map[string]Provider{}
+	if cfg.FCMProjectID != "" {
+		fcm, err := NewFCMProvider(cfg.FCMProjectID, cfg.FCMCredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		providers[domain.PushProviderFCM] = fcm
+	}
+	if cfg.APNsKeyFile != "" {
+		apns, err := NewAPNsProvider(cfg.APNsKeyFile, cfg.APNsKeyID, cfg.APNsTeamID, cfg.APNsTopic, cfg.APNsSandbox)
+		if err != nil {
+			return nil, err
+		}
+		providers[domain.PushProviderAPNs] = apns
+	}
+	return providers, nil
+}
@@ -1,16 +1,27 @@
 package realtime
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/dykethecreator/GoApp/pkg/config"
+)
 
 var (
-	globalHub     *Hub
-	globalHubOnce sync.Once
+	globalHub       *Hub
+	globalHubOnce   sync.Once
+	globalHubConfig config.HubConfig
 )
 
+// ConfigureGlobalHub sets the queue sizes of the singleton hub. It must be
+// called before the first GetGlobalHub; later calls have no effect.
+func ConfigureGlobalHub(cfg config.HubConfig) {
+	globalHubConfig = cfg
+}
+
 // GetGlobalHub returns the singleton hub instance
 func GetGlobalHub() *Hub {
 	globalHubOnce.Do(func() {
-		globalHub = NewHub()
+		globalHub = NewHubWithConfig(globalHubConfig)
 		go globalHub.Run()
 	})
 	return globalHub
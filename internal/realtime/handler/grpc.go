@@ -3,6 +3,7 @@ package handler
 import (
 	"io"
 	"log"
+	"time"
 
 	"github.com/dykethecreator/GoApp/internal/auth/middleware"
 	"github.com/dykethecreator/GoApp/internal/realtime"
@@ -15,12 +16,23 @@ import (
 type RealtimeHandler struct {
 	proto.UnimplementedRealtimeServiceServer
 	hub *realtime.Hub
+
+	revoked      middleware.RevocationChecker // nil: open streams are not re-checked
+	recheckEvery time.Duration
 }
 
 func NewRealtimeHandler(hub *realtime.Hub) *RealtimeHandler {
 	return &RealtimeHandler{hub: hub}
 }
 
+// SetRevocationChecker makes open streams re-check their access token against
+// the revocation list every interval and end once it is revoked. This reaches
+// streams whose session was revoked through another process, which cannot
+// kick them from its own hub.
+func (h *RealtimeHandler) SetRevocationChecker(revoked middleware.RevocationChecker, every time.Duration) {
+	h.revoked, h.recheckEvery = revoked, every
+}
+
 func (h *RealtimeHandler) Register(s *grpc.Server) {
 	proto.RegisterRealtimeServiceServer(s, h)
 }
@@ -35,25 +47,71 @@ func (h *RealtimeHandler) Connect(stream proto.RealtimeService_ConnectServer) er
 		return status.Error(codes.Unauthenticated, "user_id not found in context")
 	}
 
+	sessionID, _ := middleware.SessionIDFromContext(ctx)
+
 	log.Printf("[Realtime] User %s connecting...", userID)
 
 	// Register client with hub
-	client := h.hub.RegisterClient(userID, stream)
+	client := h.hub.RegisterClient(userID, sessionID, stream)
 	defer h.hub.UnregisterClient(client)
 
 	// Start write pump in goroutine (sends server events to client)
 	go client.WritePump()
 
-	// Read pump (receive client events)
-	for {
-		clientEvent, err := stream.Recv()
-		if err == io.EOF {
-			log.Printf("[Realtime] User %s disconnected (EOF)", userID)
-			return nil
+	// Read pump (receive client events) runs in its own goroutine so the hub
+	// can end the stream when the device session is revoked.
+	recvErr := make(chan error, 1)
+	events := make(chan *proto.ClientEvent)
+	go func() {
+		for {
+			clientEvent, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case events <- clientEvent:
+			case <-ctx.Done():
+				return
+			}
 		}
-		if err != nil {
+	}()
+
+	// Revocations made by other processes are only seen by re-checking the token
+	var recheck <-chan time.Time
+	claims, hasClaims := middleware.ClaimsFromContext(ctx)
+	if h.revoked != nil && h.recheckEvery > 0 && hasClaims {
+		ticker := time.NewTicker(h.recheckEvery)
+		defer ticker.Stop()
+		recheck = ticker.C
+	}
+
+	for {
+		var clientEvent *proto.ClientEvent
+		select {
+		case <-client.Kicked():
+			log.Printf("[Realtime] User %s session %s revoked, closing stream", userID, sessionID)
+			return status.Error(codes.Unauthenticated, "session revoked")
+		case <-recheck:
+			revoked, err := h.revoked.IsRevoked(ctx, claims)
+			if err != nil {
+				// Keep the stream; the next check may succeed
+				log.Printf("[Realtime] Revocation re-check failed for user %s: %v", userID, err)
+				continue
+			}
+			if revoked {
+				log.Printf("[Realtime] User %s session %s revoked, closing stream", userID, sessionID)
+				return status.Error(codes.Unauthenticated, "session revoked")
+			}
+			continue
+		case err := <-recvErr:
+			if err == io.EOF {
+				log.Printf("[Realtime] User %s disconnected (EOF)", userID)
+				return nil
+			}
 			log.Printf("[Realtime] User %s receive error: %v", userID, err)
 			return err
+		case clientEvent = <-events:
 		}
 
 		// Handle client events
@@ -1,87 +1,223 @@
 package realtime
 
 import (
+	"context"
 	"log"
 	"sync"
 	"time"
 
+	"github.com/dykethecreator/GoApp/pkg/config"
 	"github.com/dykethecreator/GoApp/proto"
 )
 
+// BlockFilter reports the users separated from userID by a block in either
+// direction; presence and typing events are not exchanged between them.
+type BlockFilter interface {
+	BlockedPeers(ctx context.Context, userID string) (map[string]bool, error)
+}
+
+// PresencePolicy decides who may see a user's presence under their privacy
+// settings (blocks included).
+type PresencePolicy interface {
+	// PresenceAudience returns which viewers may see userID's online status
+	// and, among those, which may also see its last-seen time.
+	PresenceAudience(ctx context.Context, userID string, viewerIDs []string) (online, lastSeen map[string]bool, err error)
+}
+
+// presenceChange is a user going online or offline. Working out who may see
+// it can hit the database, so it is handled off the hub loop.
+type presenceChange struct {
+	userID string
+	status string
+}
+
+// lookupTimeout bounds the block and privacy lookups of a presence or typing
+// event.
+const lookupTimeout = 5 * time.Second
+
+// OfflineNotifier is told about new messages for participants without an
+// open stream, e.g. to send push notifications. It must not block.
+type OfflineNotifier interface {
+	NotifyOffline(conversationID string, recipientIDs []string, msg *proto.NewMessage)
+}
+
 // Hub manages active client connections and broadcasts messages
 type Hub struct {
-	clients    map[string]*Client // userID -> Client
-	broadcast  chan *proto.ServerEvent
+	clients    map[string]map[*Client]bool // userID -> connected clients (one per device stream)
 	register   chan *Client
 	unregister chan *Client
+	presenceQ  chan presenceChange // fanned out by presenceWorker
 	mu         sync.RWMutex
+
+	clientBuffer int // size of each client's Send queue
+
+	blocks   BlockFilter     // nil: no block filtering
+	presence PresencePolicy  // nil: presence goes to everyone not blocked
+	offline  OfflineNotifier // nil: offline participants are not notified
 }
 
 // Client represents a connected user with their stream
 type Client struct {
-	UserID string
-	Stream proto.RealtimeService_ConnectServer
-	Send   chan *proto.ServerEvent
-	Hub    *Hub
+	UserID    string
+	SessionID string // device session of the access token; empty for legacy tokens
+	Stream    proto.RealtimeService_ConnectServer
+	Send      chan *proto.ServerEvent
+	Hub       *Hub
+
+	kicked   chan struct{}
+	kickOnce sync.Once
 }
 
-// NewHub creates a new Hub instance
+// Kicked is closed when the hub forcibly disconnects the client (e.g. its session was revoked).
+func (c *Client) Kicked() <-chan struct{} {
+	return c.kicked
+}
+
+func (c *Client) kick() {
+	c.kickOnce.Do(func() { close(c.kicked) })
+}
+
+// DefaultBufferSize is the hub and client queue size when none is configured.
+const DefaultBufferSize = 256
+
+// NewHub creates a new Hub instance with the default queue sizes
 func NewHub() *Hub {
+	return NewHubWithConfig(config.HubConfig{})
+}
+
+// NewHubWithConfig creates a Hub with the queue sizes in cfg; zero sizes use
+// DefaultBufferSize.
+func NewHubWithConfig(cfg config.HubConfig) *Hub {
+	presence, client := cfg.BroadcastBuffer, cfg.ClientBuffer
+	if presence <= 0 {
+		presence = DefaultBufferSize
+	}
+	if client <= 0 {
+		client = DefaultBufferSize
+	}
 	return &Hub{
-		clients:    make(map[string]*Client),
-		broadcast:  make(chan *proto.ServerEvent, 256),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
+		clients:      make(map[string]map[*Client]bool),
+		presenceQ:    make(chan presenceChange, presence),
+		register:     make(chan *Client),
+		unregister:   make(chan *Client),
+		clientBuffer: client,
 	}
 }
 
-// Run starts the hub's main loop
+// SetBlockFilter makes presence and typing events skip users separated by a
+// block.
+func (h *Hub) SetBlockFilter(f BlockFilter) {
+	h.mu.Lock()
+	h.blocks = f
+	h.mu.Unlock()
+}
+
+// SetPresencePolicy applies users' privacy settings to presence events. It
+// takes precedence over the block filter for presence.
+func (h *Hub) SetPresencePolicy(p PresencePolicy) {
+	h.mu.Lock()
+	h.presence = p
+	h.mu.Unlock()
+}
+
+// SetOfflineNotifier makes new messages for participants without an open
+// stream go to n. System messages are not passed on.
+func (h *Hub) SetOfflineNotifier(n OfflineNotifier) {
+	h.mu.Lock()
+	h.offline = n
+	h.mu.Unlock()
+}
+
+// blockedPeers returns the users who must not see userID's presence or typing.
+// ok is false when the filter failed; callers then send nothing (fail closed).
+func (h *Hub) blockedPeers(userID string) (peers map[string]bool, ok bool) {
+	h.mu.RLock()
+	f := h.blocks
+	h.mu.RUnlock()
+	if f == nil {
+		return nil, true
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+	peers, err := f.BlockedPeers(ctx, userID)
+	if err != nil {
+		log.Printf("[Hub] Failed to load blocks of user %s, dropping event: %v", userID, err)
+		return nil, false
+	}
+	return peers, true
+}
+
+// Run starts the hub's main loop. The loop only updates the client maps and
+// queues events without blocking; presence lookups run in presenceWorker.
 func (h *Hub) Run() {
+	go h.presenceWorker()
 	for {
 		select {
 		case client := <-h.register:
 			h.mu.Lock()
-			h.clients[client.UserID] = client
+			firstConnection := len(h.clients[client.UserID]) == 0
+			if firstConnection {
+				h.clients[client.UserID] = make(map[*Client]bool)
+			}
+			h.clients[client.UserID][client] = true
 			h.mu.Unlock()
-			log.Printf("[Hub] User %s connected (total: %d)", client.UserID, len(h.clients))
+			log.Printf("[Hub] User %s connected (session %s, total users: %d)", client.UserID, client.SessionID, len(h.clients))
 
 			// Broadcast presence update
-			h.BroadcastPresence(client.UserID, "online")
+			if firstConnection {
+				h.queuePresence(client.UserID, "online")
+			}
 
 		case client := <-h.unregister:
 			h.mu.Lock()
-			if _, ok := h.clients[client.UserID]; ok {
-				delete(h.clients, client.UserID)
+			lastConnection := false
+			if userClients, ok := h.clients[client.UserID]; ok && userClients[client] {
+				delete(userClients, client)
 				close(client.Send)
+				if len(userClients) == 0 {
+					delete(h.clients, client.UserID)
+					lastConnection = true
+				}
 			}
 			h.mu.Unlock()
-			log.Printf("[Hub] User %s disconnected (total: %d)", client.UserID, len(h.clients))
+			log.Printf("[Hub] User %s disconnected (session %s, total users: %d)", client.UserID, client.SessionID, len(h.clients))
 
 			// Broadcast presence update
-			h.BroadcastPresence(client.UserID, "offline")
-
-		case event := <-h.broadcast:
-			h.mu.RLock()
-			for _, client := range h.clients {
-				select {
-				case client.Send <- event:
-				default:
-					// Client send buffer full, skip
-					log.Printf("[Hub] Warning: Client %s send buffer full, dropping message", client.UserID)
-				}
+			if lastConnection {
+				h.queuePresence(client.UserID, "offline")
 			}
-			h.mu.RUnlock()
+
 		}
 	}
 }
 
-// RegisterClient adds a new client connection
-func (h *Hub) RegisterClient(userID string, stream proto.RealtimeService_ConnectServer) *Client {
+// queuePresence hands a presence change to presenceWorker, dropping it when
+// the worker is too far behind rather than stalling the hub loop.
+func (h *Hub) queuePresence(userID, status string) {
+	select {
+	case h.presenceQ <- presenceChange{userID: userID, status: status}:
+	default:
+		log.Printf("[Hub] Warning: presence queue full, dropping %s update of user %s", status, userID)
+	}
+}
+
+// presenceWorker broadcasts presence changes one at a time, so a user's
+// online and offline updates keep their order.
+func (h *Hub) presenceWorker() {
+	for change := range h.presenceQ {
+		h.BroadcastPresence(change.userID, change.status)
+	}
+}
+
+// RegisterClient adds a new client connection for one device session of a user
+func (h *Hub) RegisterClient(userID, sessionID string, stream proto.RealtimeService_ConnectServer) *Client {
 	client := &Client{
-		UserID: userID,
-		Stream: stream,
-		Send:   make(chan *proto.ServerEvent, 256),
-		Hub:    h,
+		UserID:    userID,
+		SessionID: sessionID,
+		Stream:    stream,
+		Send:      make(chan *proto.ServerEvent, h.clientBuffer),
+		Hub:       h,
+		kicked:    make(chan struct{}),
 	}
 	h.register <- client
 	return client
@@ -92,6 +228,56 @@ func (h *Hub) UnregisterClient(client *Client) {
 	h.unregister <- client
 }
 
+// DisconnectSession kicks every stream of the user opened with the given
+// device session. It returns the number of streams kicked.
+func (h *Hub) DisconnectSession(userID, sessionID string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	kicked := 0
+	for client := range h.clients[userID] {
+		if client.SessionID == sessionID {
+			client.kick()
+			kicked++
+		}
+	}
+	if kicked > 0 {
+		log.Printf("[Hub] Disconnected %d stream(s) of user %s for revoked session %s", kicked, userID, sessionID)
+	}
+	return kicked
+}
+
+// DisconnectUser kicks every stream of the user, e.g. after a logout from all devices.
+func (h *Hub) DisconnectUser(userID string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	kicked := 0
+	for client := range h.clients[userID] {
+		client.kick()
+		kicked++
+	}
+	if kicked > 0 {
+		log.Printf("[Hub] Disconnected %d stream(s) of user %s", kicked, userID)
+	}
+	return kicked
+}
+
+// sendToUser queues an event on every stream of the user without blocking.
+// It returns how many streams accepted the event. Callers must hold h.mu.
+func (h *Hub) sendToUser(userID string, event *proto.ServerEvent) int {
+	sent := 0
+	for client := range h.clients[userID] {
+		select {
+		case client.Send <- event:
+			sent++
+		default:
+			log.Printf("[Hub] ⚠️  Client %s send buffer full, dropping event", client.UserID)
+		}
+	}
+	return sent
+}
+
 // BroadcastMessage sends a new message event to all clients in a conversation
 func (h *Hub) BroadcastMessage(conversationID string, participantIDs []string, msg *proto.NewMessage) {
 	event := &proto.ServerEvent{
@@ -99,29 +285,39 @@ func (h *Hub) BroadcastMessage(conversationID string, participantIDs []string, m
 	}
 
 	h.mu.RLock()
-	defer h.mu.RUnlock()
-
 	log.Printf("[Hub] Broadcasting message %s to conversation %s (%d participants)", msg.MessageId[:8], conversationID[:8], len(participantIDs))
 
 	sent := 0
+	var offline []string
 	for _, uid := range participantIDs {
-		if client, ok := h.clients[uid]; ok {
-			select {
-			case client.Send <- event:
+		if _, ok := h.clients[uid]; ok {
+			if h.sendToUser(uid, event) > 0 {
 				sent++
 				log.Printf("[Hub] ✅ Sent to client %s", uid[:8])
-			default:
-				log.Printf("[Hub] ⚠️  Client %s send buffer full for message %s", uid[:8], msg.MessageId[:8])
 			}
 		} else {
 			log.Printf("[Hub] ⚠️  Client %s not connected", uid[:8])
+			if uid != msg.SenderId {
+				offline = append(offline, uid)
+			}
 		}
 	}
+	notifier := h.offline
+	h.mu.RUnlock()
 	log.Printf("[Hub] Message sent to %d/%d connected clients", sent, len(participantIDs))
+
+	if notifier != nil && len(offline) > 0 && !msg.IsSystem {
+		notifier.NotifyOffline(conversationID, offline, msg)
+	}
 }
 
-// BroadcastTyping sends typing indicator to conversation participants
+// BroadcastTyping sends typing indicator to conversation participants,
+// except those separated from the typer by a block
 func (h *Hub) BroadcastTyping(conversationID, userID string, participantIDs []string, isTyping bool) {
+	blocked, ok := h.blockedPeers(userID)
+	if !ok {
+		return
+	}
 	event := &proto.ServerEvent{
 		Event: &proto.ServerEvent_Typing{
 			Typing: &proto.TypingIndicator{
@@ -136,30 +332,130 @@ func (h *Hub) BroadcastTyping(conversationID, userID string, participantIDs []st
 	defer h.mu.RUnlock()
 
 	for _, uid := range participantIDs {
-		if uid == userID {
-			continue // Don't send typing to the typer
+		if uid == userID || blocked[uid] {
+			continue // Don't send typing to the typer or blocked users
+		}
+		h.sendToUser(uid, event)
+	}
+}
+
+// BroadcastPresence sends online/offline status to the connected clients
+// allowed to see it: with a presence policy, per the user's privacy settings
+// (the last-seen time only to those allowed to see it); otherwise to everyone
+// not separated from the user by a block
+func (h *Hub) BroadcastPresence(userID, status string) {
+	lastSeen := time.Now().Format(time.RFC3339)
+	newEvent := func(lastSeen string) *proto.ServerEvent {
+		return &proto.ServerEvent{
+			Event: &proto.ServerEvent_Presence{
+				Presence: &proto.PresenceUpdate{
+					UserId:   userID,
+					Status:   status,
+					LastSeen: lastSeen,
+				},
+			},
+		}
+	}
+
+	h.mu.RLock()
+	policy := h.presence
+	var viewers []string
+	if policy != nil {
+		viewers = make([]string, 0, len(h.clients))
+		for uid := range h.clients {
+			viewers = append(viewers, uid)
 		}
-		if client, ok := h.clients[uid]; ok {
-			select {
-			case client.Send <- event:
-			default:
+	}
+	h.mu.RUnlock()
+
+	if policy == nil {
+		blocked, ok := h.blockedPeers(userID)
+		if !ok {
+			return
+		}
+		event := newEvent(lastSeen)
+		h.mu.RLock()
+		defer h.mu.RUnlock()
+		for uid := range h.clients {
+			if !blocked[uid] {
+				h.sendToUser(uid, event)
 			}
 		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+	online, withLastSeen, err := policy.PresenceAudience(ctx, userID, viewers)
+	if err != nil {
+		log.Printf("[Hub] Failed to load presence audience of user %s, dropping event: %v", userID, err)
+		return
+	}
+	full, bare := newEvent(lastSeen), newEvent("")
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for uid := range withLastSeen {
+		h.sendToUser(uid, full)
+	}
+	for uid := range online {
+		if !withLastSeen[uid] {
+			h.sendToUser(uid, bare)
+		}
 	}
 }
 
-// BroadcastPresence sends online/offline status to all connected clients
-func (h *Hub) BroadcastPresence(userID, status string) {
+// BroadcastProfileUpdate sends a profile change to the given users (the
+// owner's contacts and the owner's other devices) if they are connected
+func (h *Hub) BroadcastProfileUpdate(recipientIDs []string, update *proto.ProfileUpdated) {
 	event := &proto.ServerEvent{
-		Event: &proto.ServerEvent_Presence{
-			Presence: &proto.PresenceUpdate{
-				UserId:   userID,
-				Status:   status,
-				LastSeen: time.Now().Format(time.RFC3339),
-			},
-		},
+		Event: &proto.ServerEvent_ProfileUpdated{ProfileUpdated: update},
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	sent := 0
+	for _, uid := range recipientIDs {
+		if h.sendToUser(uid, event) > 0 {
+			sent++
+		}
+	}
+	log.Printf("[Hub] Profile update of user %s sent to %d/%d recipients", update.UserId, sent, len(recipientIDs))
+}
+
+// BroadcastMessageEdited sends an edited message's new content to the
+// conversation's connected participants
+func (h *Hub) BroadcastMessageEdited(participantIDs []string, edit *proto.MessageEdited) {
+	h.sendToConversation(participantIDs, &proto.ServerEvent{
+		Event: &proto.ServerEvent_MessageEdited{MessageEdited: edit},
+	})
+}
+
+// BroadcastMessageDeleted sends a message deletion to the given users'
+// connected streams
+func (h *Hub) BroadcastMessageDeleted(recipientIDs []string, deletion *proto.MessageDeleted) {
+	h.sendToConversation(recipientIDs, &proto.ServerEvent{
+		Event: &proto.ServerEvent_MessageDeleted{MessageDeleted: deletion},
+	})
+}
+
+// BroadcastReactionUpdated sends a reaction change to the conversation's
+// connected participants
+func (h *Hub) BroadcastReactionUpdated(participantIDs []string, update *proto.ReactionUpdated) {
+	h.sendToConversation(participantIDs, &proto.ServerEvent{
+		Event: &proto.ServerEvent_ReactionUpdated{ReactionUpdated: update},
+	})
+}
+
+// sendToConversation queues an event about an existing message on the streams
+// of the connected participants. Unlike new messages, these events are not
+// pushed to offline users; they see the change when they next list messages.
+func (h *Hub) sendToConversation(participantIDs []string, event *proto.ServerEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, uid := range participantIDs {
+		h.sendToUser(uid, event)
 	}
-	h.broadcast <- event
 }
 
 // WritePump sends queued messages to the client stream
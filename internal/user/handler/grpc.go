@@ -0,0 +1,311 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/auth/middleware"
+	"github.com/dykethecreator/GoApp/internal/realtime"
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/internal/user/service"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/dykethecreator/GoApp/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UserHandler struct {
+	proto.UnimplementedUserServiceServer
+	svc *service.UserService
+}
+
+func NewUserHandler(s *service.UserService) *UserHandler { return &UserHandler{svc: s} }
+
+func (h *UserHandler) Register(s *grpc.Server) { proto.RegisterUserServiceServer(s, h) }
+
+func (h *UserHandler) GetUser(ctx context.Context, req *proto.GetUserRequest) (*proto.GetUserResponse, error) {
+	callerID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID := req.UserId
+	if userID == "" {
+		userID = callerID
+	}
+	u, err := h.svc.GetUser(ctx, callerID, userID)
+	if err != nil {
+		return nil, userError(err, "failed to get user")
+	}
+	return &proto.GetUserResponse{User: toProtoProfile(u, callerID)}, nil
+}
+
+func (h *UserHandler) BatchGetUsers(ctx context.Context, req *proto.BatchGetUsersRequest) (*proto.BatchGetUsersResponse, error) {
+	callerID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	users, err := h.svc.BatchGetUsers(ctx, callerID, req.UserIds)
+	if err != nil {
+		return nil, userError(err, "failed to get users")
+	}
+	out := make([]*proto.UserProfile, 0, len(users))
+	for _, u := range users {
+		out = append(out, toProtoProfile(u, callerID))
+	}
+	return &proto.BatchGetUsersResponse{Users: out}, nil
+}
+
+func (h *UserHandler) UpdateProfile(ctx context.Context, req *proto.UpdateProfileRequest) (*proto.UpdateProfileResponse, error) {
+	callerID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := h.svc.UpdateProfile(ctx, callerID, req.DisplayName, req.AboutText, req.ProfilePictureUrl)
+	if err != nil {
+		return nil, userError(err, "failed to update profile")
+	}
+
+	// Notify contacts (best-effort, non-blocking), each as their privacy view allows
+	go func() {
+		views, err := h.svc.ProfileUpdateViews(context.Background(), u)
+		if err != nil {
+			log.Printf("[User] Failed to load contacts of %s for profile update: %v", callerID, err)
+			return
+		}
+		for _, v := range views {
+			realtime.GetGlobalHub().BroadcastProfileUpdate(v.RecipientIDs, &proto.ProfileUpdated{
+				UserId:            v.User.ID.String(),
+				DisplayName:       v.User.DisplayName,
+				ProfilePictureUrl: v.User.ProfilePictureURL,
+				AboutText:         v.User.AboutText,
+				UpdatedAt:         v.User.UpdatedAt.Format(time.RFC3339),
+			})
+		}
+	}()
+
+	return &proto.UpdateProfileResponse{User: toProtoProfile(u, callerID)}, nil
+}
+
+func (h *UserHandler) SyncContacts(ctx context.Context, req *proto.SyncContactsRequest) (*proto.SyncContactsResponse, error) {
+	callerID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sync := service.ContactSync{
+		FullSync:            req.FullSync,
+		RemovedPhoneNumbers: req.RemovedPhoneNumbers,
+		RemovedPhoneHashes:  req.RemovedPhoneHashes,
+	}
+	for _, c := range req.Contacts {
+		sync.Contacts = append(sync.Contacts, service.ContactEntry{
+			PhoneNumber:         c.PhoneNumber,
+			PhoneHash:           c.PhoneHash,
+			DisplayNameOverride: c.DisplayNameOverride,
+		})
+	}
+
+	synced, err := h.svc.SyncContacts(ctx, callerID, sync)
+	if err != nil {
+		return nil, userError(err, "failed to sync contacts")
+	}
+	out := make([]*proto.SyncedContact, 0, len(synced))
+	for _, c := range synced {
+		out = append(out, &proto.SyncedContact{
+			PhoneNumber:         c.Contact.ContactPhoneNumber,
+			PhoneHash:           c.PhoneHash,
+			DisplayNameOverride: c.Contact.DisplayNameOverride,
+			User:                toProtoProfile(c.User, callerID),
+		})
+	}
+	return &proto.SyncContactsResponse{Contacts: out, HashSalt: h.svc.ContactHashSalt()}, nil
+}
+
+func (h *UserHandler) BlockUser(ctx context.Context, req *proto.BlockUserRequest) (*proto.BlockUserResponse, error) {
+	callerID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.svc.BlockUser(ctx, callerID, req.UserId); err != nil {
+		return nil, userError(err, "failed to block user")
+	}
+	return &proto.BlockUserResponse{}, nil
+}
+
+func (h *UserHandler) UnblockUser(ctx context.Context, req *proto.UnblockUserRequest) (*proto.UnblockUserResponse, error) {
+	callerID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.svc.UnblockUser(ctx, callerID, req.UserId); err != nil {
+		return nil, userError(err, "failed to unblock user")
+	}
+	return &proto.UnblockUserResponse{}, nil
+}
+
+func (h *UserHandler) ListBlocked(ctx context.Context, req *proto.ListBlockedRequest) (*proto.ListBlockedResponse, error) {
+	callerID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := h.svc.ListBlocked(ctx, callerID)
+	if err != nil {
+		return nil, userError(err, "failed to list blocked users")
+	}
+	out := make([]*proto.BlockedUser, 0, len(blocked))
+	for _, b := range blocked {
+		out = append(out, &proto.BlockedUser{
+			User:      toProtoProfile(b.User, callerID),
+			BlockedAt: b.BlockedAt.Format(time.RFC3339),
+		})
+	}
+	return &proto.ListBlockedResponse{Users: out}, nil
+}
+
+func (h *UserHandler) GetPrivacySettings(ctx context.Context, req *proto.GetPrivacySettingsRequest) (*proto.GetPrivacySettingsResponse, error) {
+	callerID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := h.svc.GetPrivacySettings(ctx, callerID)
+	if err != nil {
+		return nil, userError(err, "failed to get privacy settings")
+	}
+	return &proto.GetPrivacySettingsResponse{Settings: toProtoPrivacy(settings)}, nil
+}
+
+func (h *UserHandler) UpdatePrivacySettings(ctx context.Context, req *proto.UpdatePrivacySettingsRequest) (*proto.UpdatePrivacySettingsResponse, error) {
+	callerID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	update := repository.PrivacyUpdate{
+		LastSeen:     fromProtoVisibility(req.LastSeen),
+		Online:       fromProtoVisibility(req.Online),
+		ProfilePhoto: fromProtoVisibility(req.ProfilePhoto),
+		About:        fromProtoVisibility(req.About),
+	}
+	settings, err := h.svc.UpdatePrivacySettings(ctx, callerID, update)
+	if err != nil {
+		return nil, userError(err, "failed to update privacy settings")
+	}
+	return &proto.UpdatePrivacySettingsResponse{Settings: toProtoPrivacy(settings)}, nil
+}
+
+func (h *UserHandler) DeleteAccount(ctx context.Context, req *proto.DeleteAccountRequest) (*proto.DeleteAccountResponse, error) {
+	callerID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.svc.DeleteAccount(ctx, callerID, req.PhoneNumber); err != nil {
+		return nil, userError(err, "failed to delete account")
+	}
+	realtime.GetGlobalHub().DisconnectUser(callerID)
+	return &proto.DeleteAccountResponse{}, nil
+}
+
+func (h *UserHandler) ExportMyData(ctx context.Context, req *proto.ExportMyDataRequest) (*proto.ExportMyDataResponse, error) {
+	callerID, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	format := service.ExportZIP
+	if req.Format == proto.ExportFormat_EXPORT_FORMAT_JSON {
+		format = service.ExportJSON
+	}
+	export, err := h.svc.ExportMyData(ctx, callerID, format)
+	if err != nil {
+		return nil, userError(err, "failed to export data")
+	}
+	out := &proto.ExportMyDataResponse{
+		ExportId:  export.ID,
+		BlobKey:   export.Key,
+		Format:    proto.ExportFormat_EXPORT_FORMAT_ZIP,
+		SizeBytes: export.SizeBytes,
+		CreatedAt: export.CreatedAt.Format(time.RFC3339),
+	}
+	if export.Format == service.ExportJSON {
+		out.Format = proto.ExportFormat_EXPORT_FORMAT_JSON
+	}
+	return out, nil
+}
+
+func callerFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok || userID == "" {
+		return "", status.Error(codes.Unauthenticated, "user_id not found in context")
+	}
+	return userID, nil
+}
+
+// userError maps user service errors to gRPC status codes.
+func userError(err error, msg string) error {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, service.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrHashingDisabled), errors.Is(err, service.ErrPrivacyDisabled),
+		errors.Is(err, service.ErrAccountsDisabled), errors.Is(err, service.ErrExportDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// toProtoProfile converts a user to its public profile. The phone number is only
+// included on the caller's own profile.
+func toProtoProfile(u *domain.User, callerID string) *proto.UserProfile {
+	out := &proto.UserProfile{
+		Id:                u.ID.String(),
+		DisplayName:       u.DisplayName,
+		ProfilePictureUrl: u.ProfilePictureURL,
+		AboutText:         u.AboutText,
+	}
+	if u.ID.String() == callerID {
+		out.PhoneNumber = u.PhoneNumber
+	}
+	if !u.LastSeenAt.IsZero() {
+		out.LastSeenAt = u.LastSeenAt.Format(time.RFC3339)
+	}
+	if !u.UpdatedAt.IsZero() {
+		out.UpdatedAt = u.UpdatedAt.Format(time.RFC3339)
+	}
+	return out
+}
+
+var visibilityToProto = map[domain.PrivacyVisibility]proto.PrivacyVisibility{
+	domain.PrivacyEveryone: proto.PrivacyVisibility_PRIVACY_VISIBILITY_EVERYONE,
+	domain.PrivacyContacts: proto.PrivacyVisibility_PRIVACY_VISIBILITY_CONTACTS,
+	domain.PrivacyNobody:   proto.PrivacyVisibility_PRIVACY_VISIBILITY_NOBODY,
+}
+
+// fromProtoVisibility returns nil for UNSPECIFIED (leave unchanged). Unknown
+// values are passed through so that the service rejects them.
+func fromProtoVisibility(v proto.PrivacyVisibility) *domain.PrivacyVisibility {
+	if v == proto.PrivacyVisibility_PRIVACY_VISIBILITY_UNSPECIFIED {
+		return nil
+	}
+	for d, p := range visibilityToProto {
+		if p == v {
+			return &d
+		}
+	}
+	unknown := domain.PrivacyVisibility(v.String())
+	return &unknown
+}
+
+func toProtoPrivacy(s *domain.PrivacySettings) *proto.PrivacySettings {
+	out := &proto.PrivacySettings{
+		LastSeen:     visibilityToProto[s.LastSeen],
+		Online:       visibilityToProto[s.Online],
+		ProfilePhoto: visibilityToProto[s.ProfilePhoto],
+		About:        visibilityToProto[s.About],
+	}
+	if !s.UpdatedAt.IsZero() {
+		out.UpdatedAt = s.UpdatedAt.Format(time.RFC3339)
+	}
+	return out
+}
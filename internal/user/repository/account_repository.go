@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// AccountRepository reads and deletes everything stored about a user.
+type AccountRepository interface {
+	// ExportAccount collects the user's data, or returns nil if the user does not exist.
+	ExportAccount(ctx context.Context, userID string) (*domain.AccountExport, error)
+	// DeleteAccount removes the user in one transaction. Messages the user sent
+	// stay in their conversations with no sender; the user's memberships,
+	// contacts, statuses, blocks and devices are removed, and conversations
+	// left without participants are deleted. It reports whether the user existed.
+	DeleteAccount(ctx context.Context, userID string) (bool, error)
+}
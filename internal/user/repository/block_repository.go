@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// BlockRepository stores who blocked whom.
+type BlockRepository interface {
+	// BlockUser records the block; blocking twice is not an error.
+	BlockUser(ctx context.Context, blockerID, blockedID string) error
+	// UnblockUser removes the block and reports whether one existed.
+	UnblockUser(ctx context.Context, blockerID, blockedID string) (bool, error)
+	// ListBlocked returns the users blocked by blockerID, newest first.
+	ListBlocked(ctx context.Context, blockerID string) ([]*domain.BlockedUser, error)
+	// IsBlocked reports whether either user has blocked the other.
+	IsBlocked(ctx context.Context, userA, userB string) (bool, error)
+	// ListBlockPeers returns the users who blocked userID or were blocked by userID.
+	ListBlockPeers(ctx context.Context, userID string) ([]string, error)
+}
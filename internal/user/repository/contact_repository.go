@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// ContactUpsert is one address book entry to store for a user.
+type ContactUpsert struct {
+	PhoneNumber string
+	// DisplayNameOverride: nil keeps the stored value, empty clears it.
+	DisplayNameOverride *string
+}
+
+// ContactRepository defines address book storage and contact discovery.
+type ContactRepository interface {
+	// SetHashSalt sets the salt phone number hashes are computed with,
+	// recomputing the stored hashes if it changed. Services call it at startup.
+	SetHashSalt(ctx context.Context, salt string) error
+	// MatchPhoneHashes returns hash -> phone number for registered users whose
+	// lowercase hex SHA-256(salt + phone_number) is among hashes.
+	MatchPhoneHashes(ctx context.Context, hashes []string) (map[string]string, error)
+	// UpsertContacts stores the entries and links them to registered users by phone number.
+	UpsertContacts(ctx context.Context, userID string, contacts []ContactUpsert) error
+	DeleteContacts(ctx context.Context, userID string, phoneNumbers []string) error
+	// DeleteContactsExcept removes every contact of the user whose number is not in keep.
+	DeleteContactsExcept(ctx context.Context, userID string, keep []string) error
+	// LinkRegisteredContacts fills contact_user_id for contacts who registered after they were uploaded.
+	LinkRegisteredContacts(ctx context.Context, userID string) error
+	ListContacts(ctx context.Context, userID string) ([]*domain.Contact, error)
+}
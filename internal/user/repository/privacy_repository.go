@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// PrivacyUpdate changes a user's privacy settings; nil fields are left unchanged.
+type PrivacyUpdate struct {
+	LastSeen     *domain.PrivacyVisibility
+	Online       *domain.PrivacyVisibility
+	ProfilePhoto *domain.PrivacyVisibility
+	About        *domain.PrivacyVisibility
+}
+
+// PrivacyRepository stores privacy settings and answers the "is the viewer one
+// of the owner's contacts" question they depend on.
+type PrivacyRepository interface {
+	// GetPrivacySettings returns the stored settings of each user; users without a row are absent.
+	GetPrivacySettings(ctx context.Context, userIDs []string) (map[string]*domain.PrivacySettings, error)
+	// UpdatePrivacySettings applies the update, creating the row with defaults if needed.
+	UpdatePrivacySettings(ctx context.Context, userID string, update PrivacyUpdate) (*domain.PrivacySettings, error)
+	// ListContactUserIDs returns the registered users in ownerID's address book.
+	ListContactUserIDs(ctx context.Context, ownerID string) ([]string, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// UserRepository defines profile reads and writes for the user service.
+type UserRepository interface {
+	FindByID(ctx context.Context, userID string) (*domain.User, error)
+	// FindByIDs returns the users that exist among userIDs, in no particular order.
+	FindByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error)
+	// UpdateProfile sets the non-nil fields and returns the updated user, or nil if it does not exist.
+	UpdateProfile(ctx context.Context, userID string, displayName, aboutText, profilePictureURL *string) (*domain.User, error)
+	// ListContactOwners returns the IDs of users who have userID in their contacts.
+	ListContactOwners(ctx context.Context, userID string) ([]string, error)
+}
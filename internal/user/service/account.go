@@ -0,0 +1,202 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrAccountsDisabled is returned when the service runs without account storage.
+	ErrAccountsDisabled = errors.New("account deletion and export are not enabled")
+	// ErrExportDisabled is returned when no export directory is configured.
+	ErrExportDisabled = errors.New("data export is not enabled")
+)
+
+// SessionRevoker ends every session of a user, including access tokens that
+// have not expired yet.
+type SessionRevoker interface {
+	RevokeUser(ctx context.Context, userID string) error
+}
+
+// ExportFormat is the file format of a data export.
+type ExportFormat string
+
+const (
+	ExportZIP  ExportFormat = "zip"  // one JSON file per section
+	ExportJSON ExportFormat = "json" // a single JSON document
+)
+
+// Accounts deletes accounts and writes personal data exports to a local blob
+// directory, one subdirectory per user.
+type Accounts struct {
+	repo      repository.AccountRepository
+	revoker   SessionRevoker // nil: access tokens stay valid until they expire
+	exportDir string         // empty disables exports
+}
+
+func NewAccounts(repo repository.AccountRepository, revoker SessionRevoker, exportDir string) *Accounts {
+	return &Accounts{repo: repo, revoker: revoker, exportDir: exportDir}
+}
+
+// DataExport is an export written to the blob directory.
+type DataExport struct {
+	ID        string
+	Key       string // path relative to the blob directory
+	Format    ExportFormat
+	SizeBytes int64
+	CreatedAt time.Time
+}
+
+// DeleteAccount permanently deletes the caller's account. phoneNumber must be
+// the account's number, as a confirmation. Every session is revoked first;
+// messages the user sent stay in their conversations with no sender.
+func (s *UserService) DeleteAccount(ctx context.Context, userID, phoneNumber string) error {
+	if s.accounts == nil {
+		return ErrAccountsDisabled
+	}
+	u, err := s.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if number, err := s.normalizePhone(phoneNumber); err != nil || number != u.PhoneNumber {
+		return fmt.Errorf("%w: phone_number does not match the account", ErrInvalidArgument)
+	}
+
+	if s.accounts.revoker != nil {
+		if err := s.accounts.revoker.RevokeUser(ctx, userID); err != nil {
+			return fmt.Errorf("revoke sessions: %w", err)
+		}
+	}
+	deleted, err := s.accounts.repo.DeleteAccount(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrUserNotFound
+	}
+	if s.blocks != nil {
+		s.blocks.invalidate(userID)
+	}
+	if s.privacy != nil {
+		s.privacy.invalidate(userID)
+	}
+	log.Printf("[User] Deleted account %s", userID)
+	return nil
+}
+
+// ExportMyData writes the caller's profile, privacy settings, contacts, blocks,
+// conversations, sent messages and devices to the blob directory.
+func (s *UserService) ExportMyData(ctx context.Context, userID string, format ExportFormat) (*DataExport, error) {
+	if format == "" {
+		format = ExportZIP
+	}
+	if format != ExportZIP && format != ExportJSON {
+		return nil, fmt.Errorf("%w: format must be zip or json", ErrInvalidArgument)
+	}
+	if s.accounts == nil {
+		return nil, ErrAccountsDisabled
+	}
+	if s.accounts.exportDir == "" {
+		return nil, ErrExportDisabled
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, fmt.Errorf("%w: user_id must be a UUID", ErrInvalidArgument)
+	}
+	data, err := s.accounts.repo.ExportAccount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrUserNotFound
+	}
+	return s.accounts.writeExport(userID, data, format)
+}
+
+// writeExport writes the export to a temporary file and renames it into place,
+// so that a partially written export is never visible under its key.
+func (a *Accounts) writeExport(userID string, data *domain.AccountExport, format ExportFormat) (*DataExport, error) {
+	dir := filepath.Join(a.exportDir, userID)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp(dir, ".export-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+
+	if format == ExportZIP {
+		err = writeExportZIP(f, data)
+	} else {
+		err = writeJSON(f, data)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("write export: %w", err)
+	}
+
+	out := &DataExport{ID: uuid.NewString(), Format: format, CreatedAt: data.ExportedAt}
+	out.Key = userID + "/" + out.ID + "." + string(format)
+	path := filepath.Join(a.exportDir, filepath.FromSlash(out.Key))
+	if err := os.Rename(f.Name(), path); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	out.SizeBytes = info.Size()
+	return out, nil
+}
+
+func writeExportZIP(w io.Writer, data *domain.AccountExport) error {
+	zw := zip.NewWriter(w)
+	files := []struct {
+		name  string
+		value any
+	}{
+		{"profile.json", struct {
+			ExportedAt      time.Time               `json:"exported_at"`
+			User            *domain.User            `json:"user"`
+			PrivacySettings *domain.PrivacySettings `json:"privacy_settings,omitempty"`
+		}{data.ExportedAt, data.User, data.PrivacySettings}},
+		{"contacts.json", data.Contacts},
+		{"blocked_users.json", data.Blocked},
+		{"conversations.json", data.Conversations},
+		{"messages.json", data.Messages},
+		{"devices.json", data.Devices},
+	}
+	for _, file := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: data.ExportedAt})
+		if err != nil {
+			return err
+		}
+		if err := writeJSON(fw, file.value); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+type fakeAccountRepo struct {
+	users   map[string]*domain.User
+	deleted []string
+}
+
+func (f *fakeAccountRepo) ExportAccount(ctx context.Context, userID string) (*domain.AccountExport, error) {
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &domain.AccountExport{
+		ExportedAt: time.Now().UTC(),
+		User:       u,
+		Contacts:   []*domain.Contact{{UserID: u.ID, ContactPhoneNumber: "+905550000001"}},
+		Messages:   []*domain.ChatMessage{{ID: uuid.New(), SenderID: u.ID, Content: "hi"}},
+	}, nil
+}
+
+func (f *fakeAccountRepo) DeleteAccount(ctx context.Context, userID string) (bool, error) {
+	if _, ok := f.users[userID]; !ok {
+		return false, nil
+	}
+	delete(f.users, userID)
+	f.deleted = append(f.deleted, userID)
+	return true, nil
+}
+
+type fakeRevoker struct{ revoked []string }
+
+func (f *fakeRevoker) RevokeUser(ctx context.Context, userID string) error {
+	f.revoked = append(f.revoked, userID)
+	return nil
+}
+
+func TestDeleteAccount_RequiresPhoneAndRevokesSessions(t *testing.T) {
+	id := uuid.New()
+	u := &domain.User{ID: id, PhoneNumber: "+905551112233"}
+	users := &fakeUserRepo{users: map[string]*domain.User{id.String(): u}}
+	repo := &fakeAccountRepo{users: map[string]*domain.User{id.String(): u}}
+	revoker := &fakeRevoker{}
+	s := NewUserService(users, nil)
+	s.SetAccounts(NewAccounts(repo, revoker, ""))
+	ctx := context.Background()
+
+	if err := s.DeleteAccount(ctx, id.String(), "+905550000000"); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument for wrong phone number, got %v", err)
+	}
+	if len(revoker.revoked) != 0 || len(repo.deleted) != 0 {
+		t.Fatalf("expected nothing revoked or deleted, got %v / %v", revoker.revoked, repo.deleted)
+	}
+
+	if err := s.DeleteAccount(ctx, id.String(), u.PhoneNumber); err != nil {
+		t.Fatalf("DeleteAccount error: %v", err)
+	}
+	if len(revoker.revoked) != 1 || revoker.revoked[0] != id.String() {
+		t.Fatalf("expected sessions of %s revoked, got %v", id, revoker.revoked)
+	}
+	if len(repo.deleted) != 1 {
+		t.Fatalf("expected account deleted, got %v", repo.deleted)
+	}
+
+	noAccounts := NewUserService(users, nil)
+	if err := noAccounts.DeleteAccount(ctx, id.String(), u.PhoneNumber); !errors.Is(err, ErrAccountsDisabled) {
+		t.Fatalf("expected ErrAccountsDisabled, got %v", err)
+	}
+}
+
+func TestExportMyData_WritesArchive(t *testing.T) {
+	id := uuid.New()
+	u := &domain.User{ID: id, PhoneNumber: "+905551112233", DisplayName: "Ada"}
+	repo := &fakeAccountRepo{users: map[string]*domain.User{id.String(): u}}
+	dir := t.TempDir()
+	s := NewUserService(&fakeUserRepo{}, nil)
+	s.SetAccounts(NewAccounts(repo, nil, dir))
+	ctx := context.Background()
+
+	export, err := s.ExportMyData(ctx, id.String(), ExportZIP)
+	if err != nil {
+		t.Fatalf("ExportMyData error: %v", err)
+	}
+	zr, err := zip.OpenReader(filepath.Join(dir, export.Key))
+	if err != nil {
+		t.Fatalf("open export: %v", err)
+	}
+	defer zr.Close()
+	names := map[string]bool{}
+	for _, f := range zr.File {
+		names[f.Name] = true
+	}
+	for _, want := range []string{"profile.json", "contacts.json", "conversations.json", "messages.json", "devices.json"} {
+		if !names[want] {
+			t.Fatalf("expected %s in export, got %v", want, names)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(dir, export.Key)); err != nil || info.Size() != export.SizeBytes {
+		t.Fatalf("expected size %d, got %v (err %v)", export.SizeBytes, info, err)
+	}
+
+	export, err = s.ExportMyData(ctx, id.String(), ExportJSON)
+	if err != nil {
+		t.Fatalf("ExportMyData json error: %v", err)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, export.Key))
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	var decoded domain.AccountExport
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.User == nil || decoded.User.DisplayName != "Ada" {
+		t.Fatalf("expected JSON export of the profile, got %+v (err %v)", decoded.User, err)
+	}
+
+	if _, err := s.ExportMyData(ctx, uuid.NewString(), ExportZIP); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+	disabled := NewUserService(&fakeUserRepo{}, nil)
+	disabled.SetAccounts(NewAccounts(repo, nil, ""))
+	if _, err := disabled.ExportMyData(ctx, id.String(), ExportZIP); !errors.Is(err, ErrExportDisabled) {
+		t.Fatalf("expected ErrExportDisabled, got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+// DefaultBlockCacheTTL is how long BlockedPeers answers are cached. A block
+// made through another instance hides presence here within this time; message
+// checks (IsBlocked) always read the database.
+const DefaultBlockCacheTTL = 30 * time.Second
+
+type blockPeers struct {
+	peers     map[string]bool
+	fetchedAt time.Time
+}
+
+// BlockList answers whether two users are separated by a block. It is shared
+// by the user service (block/unblock), the chat service (message checks) and
+// the realtime hub (presence and typing filtering).
+type BlockList struct {
+	repo repository.BlockRepository
+	ttl  time.Duration
+
+	mu    sync.Mutex
+	cache map[string]blockPeers
+	now   func() time.Time
+}
+
+func NewBlockList(repo repository.BlockRepository, ttl time.Duration) *BlockList {
+	if ttl <= 0 {
+		ttl = DefaultBlockCacheTTL
+	}
+	return &BlockList{repo: repo, ttl: ttl, cache: make(map[string]blockPeers), now: time.Now}
+}
+
+// IsBlocked reports whether either user has blocked the other.
+func (b *BlockList) IsBlocked(ctx context.Context, userA, userB string) (bool, error) {
+	if userA == userB {
+		return false, nil
+	}
+	return b.repo.IsBlocked(ctx, userA, userB)
+}
+
+// BlockedPeers returns the users who blocked userID or whom userID blocked.
+func (b *BlockList) BlockedPeers(ctx context.Context, userID string) (map[string]bool, error) {
+	now := b.now()
+	b.mu.Lock()
+	e, ok := b.cache[userID]
+	b.mu.Unlock()
+	if ok && now.Sub(e.fetchedAt) < b.ttl {
+		return e.peers, nil
+	}
+
+	ids, err := b.repo.ListBlockPeers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	peers := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		peers[id] = true
+	}
+
+	b.mu.Lock()
+	if len(b.cache) >= 10000 {
+		for k, old := range b.cache {
+			if now.Sub(old.fetchedAt) >= b.ttl {
+				delete(b.cache, k)
+			}
+		}
+	}
+	b.cache[userID] = blockPeers{peers: peers, fetchedAt: now}
+	b.mu.Unlock()
+	return peers, nil
+}
+
+func (b *BlockList) block(ctx context.Context, blockerID, blockedID string) error {
+	if err := b.repo.BlockUser(ctx, blockerID, blockedID); err != nil {
+		return err
+	}
+	b.invalidate(blockerID, blockedID)
+	return nil
+}
+
+func (b *BlockList) unblock(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	removed, err := b.repo.UnblockUser(ctx, blockerID, blockedID)
+	if err != nil {
+		return false, err
+	}
+	b.invalidate(blockerID, blockedID)
+	return removed, nil
+}
+
+func (b *BlockList) invalidate(userIDs ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, id := range userIDs {
+		delete(b.cache, id)
+	}
+}
+
+// BlockedContact is an entry of the caller's block list.
+type BlockedContact struct {
+	User      *domain.User
+	BlockedAt time.Time
+}
+
+// BlockUser stops blockedID from messaging the caller in 1:1 chats and hides
+// presence and typing between the two. Blocking twice is not an error.
+func (s *UserService) BlockUser(ctx context.Context, blockerID, blockedID string) error {
+	target, err := s.blockTarget(ctx, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.findUser(ctx, target); err != nil {
+		return err
+	}
+	return s.blocks.block(ctx, blockerID, target)
+}
+
+// UnblockUser lifts a block. Unblocking a user who is not blocked is not an error.
+func (s *UserService) UnblockUser(ctx context.Context, blockerID, blockedID string) error {
+	target, err := s.blockTarget(ctx, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+	_, err = s.blocks.unblock(ctx, blockerID, target)
+	return err
+}
+
+// ListBlocked returns the users the caller has blocked, most recent first.
+// Blocked accounts that no longer exist are left out.
+func (s *UserService) ListBlocked(ctx context.Context, blockerID string) ([]BlockedContact, error) {
+	entries, err := s.blocks.repo.ListBlocked(ctx, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.BlockedUserID.String())
+	}
+	users, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if users, err = s.redact(ctx, blockerID, users); err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.User, len(users))
+	for _, u := range users {
+		byID[u.ID.String()] = u
+	}
+
+	out := make([]BlockedContact, 0, len(entries))
+	for _, e := range entries {
+		if u := byID[e.BlockedUserID.String()]; u != nil {
+			out = append(out, BlockedContact{User: u, BlockedAt: e.CreatedAt})
+		}
+	}
+	return out, nil
+}
+
+func (s *UserService) blockTarget(ctx context.Context, blockerID, blockedID string) (string, error) {
+	parsed, err := uuid.Parse(blockedID)
+	if err != nil {
+		return "", fmt.Errorf("%w: user_id must be a UUID", ErrInvalidArgument)
+	}
+	if parsed.String() == blockerID {
+		return "", fmt.Errorf("%w: cannot block yourself", ErrInvalidArgument)
+	}
+	return parsed.String(), nil
+}
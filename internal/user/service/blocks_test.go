@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+type fakeBlockRepo struct {
+	blocks map[[2]string]time.Time // {blocker, blocked} -> created
+}
+
+func (f *fakeBlockRepo) BlockUser(ctx context.Context, blockerID, blockedID string) error {
+	if _, ok := f.blocks[[2]string{blockerID, blockedID}]; !ok {
+		f.blocks[[2]string{blockerID, blockedID}] = time.Now()
+	}
+	return nil
+}
+
+func (f *fakeBlockRepo) UnblockUser(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	_, ok := f.blocks[[2]string{blockerID, blockedID}]
+	delete(f.blocks, [2]string{blockerID, blockedID})
+	return ok, nil
+}
+
+func (f *fakeBlockRepo) ListBlocked(ctx context.Context, blockerID string) ([]*domain.BlockedUser, error) {
+	var out []*domain.BlockedUser
+	for k, at := range f.blocks {
+		if k[0] == blockerID {
+			out = append(out, &domain.BlockedUser{BlockerUserID: uuid.MustParse(k[0]), BlockedUserID: uuid.MustParse(k[1]), CreatedAt: at})
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeBlockRepo) IsBlocked(ctx context.Context, a, b string) (bool, error) {
+	_, ab := f.blocks[[2]string{a, b}]
+	_, ba := f.blocks[[2]string{b, a}]
+	return ab || ba, nil
+}
+
+func (f *fakeBlockRepo) ListBlockPeers(ctx context.Context, userID string) ([]string, error) {
+	var out []string
+	for k := range f.blocks {
+		if k[0] == userID {
+			out = append(out, k[1])
+		} else if k[1] == userID {
+			out = append(out, k[0])
+		}
+	}
+	return out, nil
+}
+
+func TestBlockUser(t *testing.T) {
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	users := &fakeUserRepo{
+		users: map[string]*domain.User{
+			alice.String(): {ID: alice},
+			bob.String():   {ID: bob},
+			carol.String(): {ID: carol},
+		},
+		contacts: map[string][]string{alice.String(): {bob.String(), carol.String()}},
+	}
+	repo := &fakeBlockRepo{blocks: map[[2]string]time.Time{}}
+	blocks := NewBlockList(repo, time.Minute)
+	s := NewUserService(users, blocks)
+	ctx := context.Background()
+
+	if err := s.BlockUser(ctx, alice.String(), alice.String()); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("blocking yourself: got %v, want ErrInvalidArgument", err)
+	}
+	if err := s.BlockUser(ctx, alice.String(), uuid.NewString()); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("blocking unknown user: got %v, want ErrUserNotFound", err)
+	}
+
+	// Warm the peer cache, then block: the cached answer must be dropped
+	if peers, _ := blocks.BlockedPeers(ctx, alice.String()); len(peers) != 0 {
+		t.Fatalf("peers before block = %v", peers)
+	}
+	if err := s.BlockUser(ctx, bob.String(), alice.String()); err != nil {
+		t.Fatalf("BlockUser: %v", err)
+	}
+	if peers, _ := blocks.BlockedPeers(ctx, alice.String()); !peers[bob.String()] {
+		t.Fatalf("blocked-by peer missing after block: %v", peers)
+	}
+	if blocked, _ := blocks.IsBlocked(ctx, alice.String(), bob.String()); !blocked {
+		t.Fatal("IsBlocked should be symmetric")
+	}
+
+	// Bob no longer hears about Alice's profile changes
+	watchers, err := s.ProfileWatchers(ctx, alice.String())
+	if err != nil {
+		t.Fatalf("ProfileWatchers: %v", err)
+	}
+	for _, w := range watchers {
+		if w == bob.String() {
+			t.Fatalf("blocked user among profile watchers: %v", watchers)
+		}
+	}
+
+	list, err := s.ListBlocked(ctx, bob.String())
+	if err != nil || len(list) != 1 || list[0].User.ID != alice {
+		t.Fatalf("ListBlocked = %v, %v", list, err)
+	}
+
+	if err := s.UnblockUser(ctx, bob.String(), alice.String()); err != nil {
+		t.Fatalf("UnblockUser: %v", err)
+	}
+	if peers, _ := blocks.BlockedPeers(ctx, alice.String()); len(peers) != 0 {
+		t.Fatalf("peers after unblock = %v", peers)
+	}
+}
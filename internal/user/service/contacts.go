@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// ErrHashingDisabled is returned for hashed uploads when no hash salt is configured.
+var ErrHashingDisabled = errors.New("hashed contact upload is not enabled")
+
+const maxContactsPerSync = 1000
+
+// ContactEntry is one uploaded address book entry; exactly one of PhoneNumber and PhoneHash is set.
+type ContactEntry struct {
+	PhoneNumber         string
+	PhoneHash           string
+	DisplayNameOverride *string
+}
+
+// ContactSync is a full or delta address book upload.
+type ContactSync struct {
+	FullSync            bool
+	Contacts            []ContactEntry
+	RemovedPhoneNumbers []string
+	RemovedPhoneHashes  []string
+}
+
+// SyncedContact is a registered contact of the caller.
+type SyncedContact struct {
+	Contact   *domain.Contact
+	PhoneHash string
+	User      *domain.User
+}
+
+var (
+	hashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+)
+
+// normalizePhone returns a number in E.164 form; national numbers are read
+// as numbers of the configured default region.
+func (s *UserService) normalizePhone(raw string) (string, error) {
+	number, err := s.phones.Normalize(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+	}
+	return number, nil
+}
+
+// PhoneHash returns the hash clients upload instead of phone number, or "" when hashing is disabled.
+func (s *UserService) PhoneHash(phoneNumber string) string {
+	if s.contactHashSalt == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(s.contactHashSalt + phoneNumber))
+	return hex.EncodeToString(sum[:])
+}
+
+// ContactHashSalt returns the salt clients use to hash phone numbers ("" when disabled).
+func (s *UserService) ContactHashSalt() string { return s.contactHashSalt }
+
+// SyncContacts applies an address book upload for the user and returns all of
+// the user's contacts who are registered.
+func (s *UserService) SyncContacts(ctx context.Context, userID string, sync ContactSync) ([]*SyncedContact, error) {
+	if s.contacts == nil {
+		return nil, errors.New("contact repository not configured")
+	}
+	if n := len(sync.Contacts) + len(sync.RemovedPhoneNumbers) + len(sync.RemovedPhoneHashes); n > maxContactsPerSync {
+		return nil, fmt.Errorf("%w: at most %d contacts per request", ErrInvalidArgument, maxContactsPerSync)
+	}
+
+	upserts, err := s.resolveEntries(ctx, sync.Contacts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.contacts.UpsertContacts(ctx, userID, upserts); err != nil {
+		return nil, err
+	}
+
+	if sync.FullSync {
+		keep := make([]string, 0, len(upserts))
+		for _, c := range upserts {
+			keep = append(keep, c.PhoneNumber)
+		}
+		if err := s.contacts.DeleteContactsExcept(ctx, userID, keep); err != nil {
+			return nil, err
+		}
+	} else if err := s.removeContacts(ctx, userID, sync.RemovedPhoneNumbers, sync.RemovedPhoneHashes); err != nil {
+		return nil, err
+	}
+
+	if err := s.contacts.LinkRegisteredContacts(ctx, userID); err != nil {
+		return nil, err
+	}
+	if s.privacy != nil {
+		// "contacts" visibility of the user's profile depends on the address book
+		s.privacy.invalidate(userID)
+	}
+	return s.registeredContacts(ctx, userID)
+}
+
+// resolveEntries validates uploaded entries and turns hashes of registered users
+// into their phone numbers. Hashes that match nobody are dropped.
+func (s *UserService) resolveEntries(ctx context.Context, entries []ContactEntry) ([]repository.ContactUpsert, error) {
+	var hashes []string
+	for _, e := range entries {
+		if (e.PhoneNumber == "") == (e.PhoneHash == "") {
+			return nil, fmt.Errorf("%w: each contact needs exactly one of phone_number or phone_hash", ErrInvalidArgument)
+		}
+		if e.DisplayNameOverride != nil && utf8.RuneCountInString(*e.DisplayNameOverride) > maxDisplayNameLength {
+			return nil, fmt.Errorf("%w: display_name_override must be at most %d characters", ErrInvalidArgument, maxDisplayNameLength)
+		}
+		if e.PhoneHash != "" {
+			h, err := s.validHash(e.PhoneHash)
+			if err != nil {
+				return nil, err
+			}
+			hashes = append(hashes, h)
+		}
+	}
+
+	matched := map[string]string{}
+	if len(hashes) > 0 {
+		var err error
+		if matched, err = s.contacts.MatchPhoneHashes(ctx, hashes); err != nil {
+			return nil, err
+		}
+	}
+
+	// Later entries for the same number win
+	byPhone := make(map[string]int, len(entries))
+	var out []repository.ContactUpsert
+	for _, e := range entries {
+		number := matched[strings.ToLower(e.PhoneHash)]
+		if e.PhoneNumber != "" {
+			var err error
+			if number, err = s.normalizePhone(e.PhoneNumber); err != nil {
+				return nil, err
+			}
+		}
+		if number == "" {
+			continue
+		}
+		c := repository.ContactUpsert{PhoneNumber: number, DisplayNameOverride: e.DisplayNameOverride}
+		if i, ok := byPhone[number]; ok {
+			out[i] = c
+			continue
+		}
+		byPhone[number] = len(out)
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// removeContacts deletes contacts by number and by hash (matched against the user's stored numbers).
+func (s *UserService) removeContacts(ctx context.Context, userID string, phoneNumbers, hashes []string) error {
+	remove := make([]string, 0, len(phoneNumbers))
+	for _, raw := range phoneNumbers {
+		number, err := s.normalizePhone(raw)
+		if err != nil {
+			return err
+		}
+		remove = append(remove, number)
+	}
+	if len(hashes) > 0 {
+		wanted := make(map[string]bool, len(hashes))
+		for _, raw := range hashes {
+			h, err := s.validHash(raw)
+			if err != nil {
+				return err
+			}
+			wanted[h] = true
+		}
+		stored, err := s.contacts.ListContacts(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, c := range stored {
+			if wanted[s.PhoneHash(c.ContactPhoneNumber)] {
+				remove = append(remove, c.ContactPhoneNumber)
+			}
+		}
+	}
+	return s.contacts.DeleteContacts(ctx, userID, remove)
+}
+
+func (s *UserService) validHash(raw string) (string, error) {
+	if s.contactHashSalt == "" {
+		return "", ErrHashingDisabled
+	}
+	h := strings.ToLower(raw)
+	if !hashPattern.MatchString(h) {
+		return "", fmt.Errorf("%w: phone_hash must be a hex SHA-256", ErrInvalidArgument)
+	}
+	return h, nil
+}
+
+// registeredContacts returns the user's contacts who are registered, with their profiles.
+func (s *UserService) registeredContacts(ctx context.Context, userID string) ([]*SyncedContact, error) {
+	all, err := s.contacts.ListContacts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, c := range all {
+		if c.ContactUserID != nil {
+			ids = append(ids, c.ContactUserID.String())
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	users, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if users, err = s.redact(ctx, userID, users); err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.User, len(users))
+	for _, u := range users {
+		byID[u.ID.String()] = u
+	}
+
+	out := make([]*SyncedContact, 0, len(ids))
+	for _, c := range all {
+		if c.ContactUserID == nil {
+			continue
+		}
+		u, ok := byID[c.ContactUserID.String()]
+		if !ok {
+			continue
+		}
+		out = append(out, &SyncedContact{Contact: c, PhoneHash: s.PhoneHash(c.ContactPhoneNumber), User: u})
+	}
+	return out, nil
+}
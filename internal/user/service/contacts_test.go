@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/dykethecreator/GoApp/pkg/phone"
+	"github.com/google/uuid"
+)
+
+// fakeContactRepo stores contacts in memory and resolves them against fakeUserRepo.
+type fakeContactRepo struct {
+	users    *fakeUserRepo
+	contacts map[string]map[string]*domain.Contact // owner -> phone -> contact
+	salt     string
+}
+
+func (f *fakeContactRepo) userByPhone(phone string) *domain.User {
+	for _, u := range f.users.users {
+		if u.PhoneNumber == phone {
+			return u
+		}
+	}
+	return nil
+}
+
+func (f *fakeContactRepo) SetHashSalt(ctx context.Context, salt string) error {
+	f.salt = salt
+	return nil
+}
+
+func (f *fakeContactRepo) MatchPhoneHashes(ctx context.Context, hashes []string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, u := range f.users.users {
+		sum := sha256.Sum256([]byte(f.salt + u.PhoneNumber))
+		h := hex.EncodeToString(sum[:])
+		for _, want := range hashes {
+			if want == h {
+				out[h] = u.PhoneNumber
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeContactRepo) UpsertContacts(ctx context.Context, userID string, contacts []repository.ContactUpsert) error {
+	if f.contacts[userID] == nil {
+		f.contacts[userID] = map[string]*domain.Contact{}
+	}
+	for _, c := range contacts {
+		existing, ok := f.contacts[userID][c.PhoneNumber]
+		if !ok {
+			existing = &domain.Contact{UserID: uuid.MustParse(userID), ContactPhoneNumber: c.PhoneNumber}
+			f.contacts[userID][c.PhoneNumber] = existing
+		}
+		if c.DisplayNameOverride != nil {
+			existing.DisplayNameOverride = *c.DisplayNameOverride
+		}
+		if u := f.userByPhone(c.PhoneNumber); u != nil {
+			id := u.ID
+			existing.ContactUserID = &id
+		}
+	}
+	return nil
+}
+
+func (f *fakeContactRepo) DeleteContacts(ctx context.Context, userID string, phoneNumbers []string) error {
+	for _, p := range phoneNumbers {
+		delete(f.contacts[userID], p)
+	}
+	return nil
+}
+
+func (f *fakeContactRepo) DeleteContactsExcept(ctx context.Context, userID string, keep []string) error {
+	k := map[string]bool{}
+	for _, p := range keep {
+		k[p] = true
+	}
+	for p := range f.contacts[userID] {
+		if !k[p] {
+			delete(f.contacts[userID], p)
+		}
+	}
+	return nil
+}
+
+func (f *fakeContactRepo) LinkRegisteredContacts(ctx context.Context, userID string) error {
+	for _, c := range f.contacts[userID] {
+		if u := f.userByPhone(c.ContactPhoneNumber); c.ContactUserID == nil && u != nil {
+			id := u.ID
+			c.ContactUserID = &id
+		}
+	}
+	return nil
+}
+
+func (f *fakeContactRepo) ListContacts(ctx context.Context, userID string) ([]*domain.Contact, error) {
+	var out []*domain.Contact
+	for _, c := range f.contacts[userID] {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ContactPhoneNumber < out[j].ContactPhoneNumber })
+	return out, nil
+}
+
+func syncedPhones(contacts []*SyncedContact) []string {
+	var out []string
+	for _, c := range contacts {
+		out = append(out, c.Contact.ContactPhoneNumber)
+	}
+	return out
+}
+
+func TestSyncContacts_FullDeltaAndHashed(t *testing.T) {
+	owner, alice, bob := uuid.New(), uuid.New(), uuid.New()
+	users := &fakeUserRepo{users: map[string]*domain.User{
+		owner.String(): {ID: owner, PhoneNumber: "+905550000001"},
+		alice.String(): {ID: alice, PhoneNumber: "+905550000002"},
+		bob.String():   {ID: bob, PhoneNumber: "+905550000003"},
+	}}
+	contacts := &fakeContactRepo{users: users, contacts: map[string]map[string]*domain.Contact{}, salt: "pepper"}
+	phones, err := phone.NewParser("TR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewUserService(users, nil)
+	s.SetContacts(contacts, "pepper")
+	s.SetPhoneParser(phones)
+	ctx := context.Background()
+
+	// Full sync: one plain number (national format), one hash, one unregistered number
+	nick := "Ali"
+	got, err := s.SyncContacts(ctx, owner.String(), ContactSync{
+		FullSync: true,
+		Contacts: []ContactEntry{
+			{PhoneNumber: "0555 000 00 02", DisplayNameOverride: &nick},
+			{PhoneHash: s.PhoneHash("+905550000003")},
+			{PhoneNumber: "+905559999999"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SyncContacts error: %v", err)
+	}
+	if phones := syncedPhones(got); len(phones) != 2 || phones[0] != "+905550000002" || phones[1] != "+905550000003" {
+		t.Fatalf("unexpected registered contacts: %v", phones)
+	}
+	if got[0].Contact.DisplayNameOverride != "Ali" || got[0].User.ID != alice {
+		t.Fatalf("unexpected contact: %+v", got[0].Contact)
+	}
+
+	// The unregistered number is linked once it registers
+	carol := uuid.New()
+	users.users[carol.String()] = &domain.User{ID: carol, PhoneNumber: "+905559999999"}
+
+	// Delta: remove bob by hash, keep the rest
+	got, err = s.SyncContacts(ctx, owner.String(), ContactSync{RemovedPhoneHashes: []string{s.PhoneHash("+905550000003")}})
+	if err != nil {
+		t.Fatalf("SyncContacts(delta) error: %v", err)
+	}
+	if phones := syncedPhones(got); len(phones) != 2 || phones[0] != "+905550000002" || phones[1] != "+905559999999" {
+		t.Fatalf("unexpected registered contacts after delta: %v", phones)
+	}
+
+	if _, err := s.SyncContacts(ctx, owner.String(), ContactSync{Contacts: []ContactEntry{{PhoneNumber: "12345"}}}); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument for bad number, got %v", err)
+	}
+	noSalt := NewUserService(users, nil)
+	noSalt.SetContacts(contacts, "")
+	if _, err := noSalt.SyncContacts(ctx, owner.String(), ContactSync{Contacts: []ContactEntry{{PhoneHash: s.PhoneHash("+905550000002")}}}); !errors.Is(err, ErrHashingDisabled) {
+		t.Fatalf("expected ErrHashingDisabled, got %v", err)
+	}
+}
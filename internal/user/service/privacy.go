@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+// DefaultPrivacyCacheTTL is how long a user's privacy settings and contact
+// list are cached for presence fan-out and profile reads. Changes made through
+// another instance take effect here within this time.
+const DefaultPrivacyCacheTTL = 30 * time.Second
+
+type privacyEntry struct {
+	settings  *domain.PrivacySettings
+	contacts  map[string]bool // nil until a "contacts" setting needs it
+	fetchedAt time.Time
+}
+
+// Privacy decides which parts of a user's profile and presence a viewer may
+// see. Users separated by a block see each other as "nobody".
+type Privacy struct {
+	repo   repository.PrivacyRepository
+	blocks *BlockList // nil: blocks are not considered
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]*privacyEntry
+	now   func() time.Time
+}
+
+func NewPrivacy(repo repository.PrivacyRepository, blocks *BlockList, ttl time.Duration) *Privacy {
+	if ttl <= 0 {
+		ttl = DefaultPrivacyCacheTTL
+	}
+	return &Privacy{repo: repo, blocks: blocks, ttl: ttl, cache: make(map[string]*privacyEntry), now: time.Now}
+}
+
+// Settings returns the user's privacy settings, or the defaults if never changed.
+func (p *Privacy) Settings(ctx context.Context, userID string) (*domain.PrivacySettings, error) {
+	e, err := p.entry(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	return e.settings, nil
+}
+
+// entry returns the cached settings of ownerID, loading the owner's contacts
+// too when withContacts is set.
+func (p *Privacy) entry(ctx context.Context, ownerID string, withContacts bool) (*privacyEntry, error) {
+	now := p.now()
+	p.mu.Lock()
+	e, ok := p.cache[ownerID]
+	p.mu.Unlock()
+	if ok && now.Sub(e.fetchedAt) < p.ttl && (!withContacts || e.contacts != nil) {
+		return e, nil
+	}
+
+	if !ok || now.Sub(e.fetchedAt) >= p.ttl {
+		found, err := p.repo.GetPrivacySettings(ctx, []string{ownerID})
+		if err != nil {
+			return nil, err
+		}
+		settings := found[ownerID]
+		if settings == nil {
+			id, err := uuid.Parse(ownerID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: user_id must be a UUID", ErrInvalidArgument)
+			}
+			settings = domain.DefaultPrivacySettings(id)
+		}
+		e = &privacyEntry{settings: settings, fetchedAt: now}
+	} else {
+		// Fresh settings, contacts not loaded yet: keep the settings' fetch time
+		e = &privacyEntry{settings: e.settings, fetchedAt: e.fetchedAt}
+	}
+	if withContacts {
+		ids, err := p.repo.ListContactUserIDs(ctx, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		e.contacts = make(map[string]bool, len(ids))
+		for _, id := range ids {
+			e.contacts[id] = true
+		}
+	}
+
+	p.mu.Lock()
+	if len(p.cache) >= 10000 {
+		for k, old := range p.cache {
+			if now.Sub(old.fetchedAt) >= p.ttl {
+				delete(p.cache, k)
+			}
+		}
+	}
+	p.cache[ownerID] = e
+	p.mu.Unlock()
+	return e, nil
+}
+
+// audience loads what is needed to evaluate ownerID's settings for viewers.
+func (p *Privacy) audience(ctx context.Context, ownerID string) (*privacyEntry, map[string]bool, error) {
+	e, err := p.entry(ctx, ownerID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := e.settings
+	if s.LastSeen == domain.PrivacyContacts || s.Online == domain.PrivacyContacts ||
+		s.ProfilePhoto == domain.PrivacyContacts || s.About == domain.PrivacyContacts {
+		if e, err = p.entry(ctx, ownerID, true); err != nil {
+			return nil, nil, err
+		}
+	}
+	var blocked map[string]bool
+	if p.blocks != nil {
+		if blocked, err = p.blocks.BlockedPeers(ctx, ownerID); err != nil {
+			return nil, nil, err
+		}
+	}
+	return e, blocked, nil
+}
+
+// allowed reports whether viewerID may see something of ownerID set to v.
+func allowed(v domain.PrivacyVisibility, e *privacyEntry, blocked map[string]bool, ownerID, viewerID string) bool {
+	if viewerID == ownerID {
+		return true
+	}
+	if blocked[viewerID] {
+		return false
+	}
+	switch v {
+	case domain.PrivacyEveryone:
+		return true
+	case domain.PrivacyContacts:
+		return e.contacts[viewerID]
+	default:
+		return false
+	}
+}
+
+// PresenceAudience returns which viewers may see userID's online status and,
+// among those, which may also see its last-seen time.
+func (p *Privacy) PresenceAudience(ctx context.Context, userID string, viewerIDs []string) (online, lastSeen map[string]bool, err error) {
+	e, blocked, err := p.audience(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	online = make(map[string]bool)
+	lastSeen = make(map[string]bool)
+	for _, v := range viewerIDs {
+		if !allowed(e.settings.Online, e, blocked, userID, v) {
+			continue
+		}
+		online[v] = true
+		if allowed(e.settings.LastSeen, e, blocked, userID, v) {
+			lastSeen[v] = true
+		}
+	}
+	return online, lastSeen, nil
+}
+
+// Redact returns the profiles as viewerID may see them: the last-seen time,
+// profile photo and about text are cleared where the owner's settings hide
+// them. The input users are not modified.
+func (p *Privacy) Redact(ctx context.Context, viewerID string, users []*domain.User) ([]*domain.User, error) {
+	out := make([]*domain.User, 0, len(users))
+	for _, u := range users {
+		ownerID := u.ID.String()
+		if ownerID == viewerID {
+			out = append(out, u)
+			continue
+		}
+		e, blocked, err := p.audience(ctx, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		c := *u
+		if !allowed(e.settings.LastSeen, e, blocked, ownerID, viewerID) {
+			c.LastSeenAt = time.Time{}
+		}
+		if !allowed(e.settings.ProfilePhoto, e, blocked, ownerID, viewerID) {
+			c.ProfilePictureURL = ""
+		}
+		if !allowed(e.settings.About, e, blocked, ownerID, viewerID) {
+			c.AboutText = ""
+		}
+		out = append(out, &c)
+	}
+	return out, nil
+}
+
+func (p *Privacy) update(ctx context.Context, userID string, update repository.PrivacyUpdate) (*domain.PrivacySettings, error) {
+	settings, err := p.repo.UpdatePrivacySettings(ctx, userID, update)
+	if err != nil {
+		return nil, err
+	}
+	p.invalidate(userID)
+	return settings, nil
+}
+
+// invalidate drops the cached settings and contacts of the users.
+func (p *Privacy) invalidate(userIDs ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, id := range userIDs {
+		delete(p.cache, id)
+	}
+}
+
+// ErrPrivacyDisabled is returned when the service runs without privacy settings.
+var ErrPrivacyDisabled = errors.New("privacy settings are not enabled")
+
+// GetPrivacySettings returns the caller's privacy settings.
+func (s *UserService) GetPrivacySettings(ctx context.Context, userID string) (*domain.PrivacySettings, error) {
+	if s.privacy == nil {
+		return nil, ErrPrivacyDisabled
+	}
+	return s.privacy.Settings(ctx, userID)
+}
+
+// UpdatePrivacySettings changes the caller's privacy settings; nil fields are left unchanged.
+func (s *UserService) UpdatePrivacySettings(ctx context.Context, userID string, update repository.PrivacyUpdate) (*domain.PrivacySettings, error) {
+	for _, v := range []*domain.PrivacyVisibility{update.LastSeen, update.Online, update.ProfilePhoto, update.About} {
+		if v != nil && !v.Valid() {
+			return nil, fmt.Errorf("%w: visibility must be everyone, contacts or nobody", ErrInvalidArgument)
+		}
+	}
+	if s.privacy == nil {
+		return nil, ErrPrivacyDisabled
+	}
+	if update == (repository.PrivacyUpdate{}) {
+		return s.privacy.Settings(ctx, userID)
+	}
+	return s.privacy.update(ctx, userID, update)
+}
+
+// redact applies the privacy settings of the users for viewerID; without a
+// Privacy configured the users are returned as-is.
+func (s *UserService) redact(ctx context.Context, viewerID string, users []*domain.User) ([]*domain.User, error) {
+	if s.privacy == nil || len(users) == 0 {
+		return users, nil
+	}
+	return s.privacy.Redact(ctx, viewerID, users)
+}
+
+// ProfileView is a profile as a group of recipients may see it.
+type ProfileView struct {
+	RecipientIDs []string
+	User         *domain.User
+}
+
+// ProfileUpdateViews groups the profile watchers of u (see ProfileWatchers) by
+// what their privacy settings let them see, so that each group can be sent one
+// event.
+func (s *UserService) ProfileUpdateViews(ctx context.Context, u *domain.User) ([]ProfileView, error) {
+	watchers, err := s.ProfileWatchers(ctx, u.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	type key struct {
+		picture, about string
+		lastSeen       bool
+	}
+	var views []ProfileView
+	index := make(map[key]int)
+	for _, w := range watchers {
+		seen, err := s.redact(ctx, w, []*domain.User{u})
+		if err != nil {
+			return nil, err
+		}
+		v := seen[0]
+		k := key{v.ProfilePictureURL, v.AboutText, v.LastSeenAt.IsZero()}
+		i, ok := index[k]
+		if !ok {
+			i = len(views)
+			index[k] = i
+			views = append(views, ProfileView{User: v})
+		}
+		views[i].RecipientIDs = append(views[i].RecipientIDs, w)
+	}
+	return views, nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+type fakePrivacyRepo struct {
+	settings map[string]*domain.PrivacySettings
+	contacts map[string][]string // owner -> registered contacts
+}
+
+func (f *fakePrivacyRepo) GetPrivacySettings(ctx context.Context, userIDs []string) (map[string]*domain.PrivacySettings, error) {
+	out := make(map[string]*domain.PrivacySettings)
+	for _, id := range userIDs {
+		if s, ok := f.settings[id]; ok {
+			out[id] = s
+		}
+	}
+	return out, nil
+}
+
+func (f *fakePrivacyRepo) UpdatePrivacySettings(ctx context.Context, userID string, u repository.PrivacyUpdate) (*domain.PrivacySettings, error) {
+	s, ok := f.settings[userID]
+	if !ok {
+		s = domain.DefaultPrivacySettings(uuid.MustParse(userID))
+		f.settings[userID] = s
+	}
+	for dst, v := range map[*domain.PrivacyVisibility]*domain.PrivacyVisibility{
+		&s.LastSeen: u.LastSeen, &s.Online: u.Online, &s.ProfilePhoto: u.ProfilePhoto, &s.About: u.About,
+	} {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	s.UpdatedAt = time.Now()
+	return s, nil
+}
+
+func (f *fakePrivacyRepo) ListContactUserIDs(ctx context.Context, ownerID string) ([]string, error) {
+	return f.contacts[ownerID], nil
+}
+
+func TestPrivacy_RedactsProfilesAndPresence(t *testing.T) {
+	owner, friend, stranger := uuid.New(), uuid.New(), uuid.New()
+	users := &fakeUserRepo{users: map[string]*domain.User{
+		owner.String(): {ID: owner, DisplayName: "Owner", ProfilePictureURL: "https://cdn/p.jpg", AboutText: "hi", LastSeenAt: time.Now()},
+	}}
+	repo := &fakePrivacyRepo{
+		settings: map[string]*domain.PrivacySettings{},
+		contacts: map[string][]string{owner.String(): {friend.String()}},
+	}
+	s := NewUserService(users, nil)
+	s.SetPrivacy(NewPrivacy(repo, nil, time.Minute))
+	ctx := context.Background()
+
+	// Defaults: everything visible
+	if u, err := s.GetUser(ctx, stranger.String(), owner.String()); err != nil || u.AboutText != "hi" {
+		t.Fatalf("default GetUser = %+v, %v", u, err)
+	}
+
+	vis := func(v domain.PrivacyVisibility) *domain.PrivacyVisibility { return &v }
+	bad := domain.PrivacyVisibility("friends")
+	if _, err := s.UpdatePrivacySettings(ctx, owner.String(), repository.PrivacyUpdate{About: &bad}); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("invalid visibility: got %v, want ErrInvalidArgument", err)
+	}
+	if _, err := s.UpdatePrivacySettings(ctx, owner.String(), repository.PrivacyUpdate{
+		LastSeen:     vis(domain.PrivacyNobody),
+		ProfilePhoto: vis(domain.PrivacyContacts),
+		Online:       vis(domain.PrivacyContacts),
+	}); err != nil {
+		t.Fatalf("UpdatePrivacySettings: %v", err)
+	}
+
+	seen, err := s.GetUser(ctx, stranger.String(), owner.String())
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if seen.ProfilePictureURL != "" || !seen.LastSeenAt.IsZero() || seen.AboutText != "hi" {
+		t.Fatalf("stranger sees %+v", seen)
+	}
+	seen, _ = s.GetUser(ctx, friend.String(), owner.String())
+	if seen.ProfilePictureURL == "" || !seen.LastSeenAt.IsZero() {
+		t.Fatalf("contact sees %+v", seen)
+	}
+	seen, _ = s.GetUser(ctx, owner.String(), owner.String())
+	if seen.ProfilePictureURL == "" || seen.LastSeenAt.IsZero() {
+		t.Fatalf("owner sees own profile redacted: %+v", seen)
+	}
+	if users.users[owner.String()].ProfilePictureURL == "" {
+		t.Fatal("redaction modified the stored user")
+	}
+
+	online, lastSeen, err := s.privacy.PresenceAudience(ctx, owner.String(), []string{friend.String(), stranger.String(), owner.String()})
+	if err != nil {
+		t.Fatalf("PresenceAudience: %v", err)
+	}
+	if !online[friend.String()] || online[stranger.String()] || !online[owner.String()] {
+		t.Fatalf("online audience = %v", online)
+	}
+	if lastSeen[friend.String()] || !lastSeen[owner.String()] {
+		t.Fatalf("last-seen audience = %v", lastSeen)
+	}
+}
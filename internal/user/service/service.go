@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/dykethecreator/GoApp/pkg/phone"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserNotFound    = errors.New("user not found")
+	ErrInvalidArgument = errors.New("invalid argument")
+)
+
+// Limits from the users schema (display_name VARCHAR(100), about_text VARCHAR(250)).
+const (
+	maxDisplayNameLength = 100
+	maxAboutTextLength   = 250
+	maxPictureURLLength  = 2048
+	maxBatchSize         = 100
+)
+
+type UserService struct {
+	repo            repository.UserRepository
+	contacts        repository.ContactRepository
+	blocks          *BlockList
+	privacy         *Privacy      // nil: profiles are returned unredacted
+	accounts        *Accounts     // nil disables account deletion and export
+	phones          *phone.Parser // nil: only international numbers are accepted
+	contactHashSalt string        // empty disables hashed contact uploads
+}
+
+func NewUserService(r repository.UserRepository, blocks *BlockList) *UserService {
+	return &UserService{repo: r, blocks: blocks}
+}
+
+// SetContacts enables SyncContacts. With a hashSalt, clients may also upload
+// hashed numbers (see ContactHashSalt).
+func (s *UserService) SetContacts(contacts repository.ContactRepository, hashSalt string) {
+	s.contacts = contacts
+	s.contactHashSalt = hashSalt
+}
+
+// SetPrivacy applies users' privacy settings to the profiles others read.
+func (s *UserService) SetPrivacy(p *Privacy) { s.privacy = p }
+
+// SetAccounts enables account deletion and export.
+func (s *UserService) SetAccounts(a *Accounts) { s.accounts = a }
+
+// SetPhoneParser lets contact uploads use national numbers of the parser's
+// default region.
+func (s *UserService) SetPhoneParser(p *phone.Parser) { s.phones = p }
+
+// GetUser returns the user's profile as viewerID may see it, or ErrUserNotFound.
+func (s *UserService) GetUser(ctx context.Context, viewerID, userID string) (*domain.User, error) {
+	u, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.redact(ctx, viewerID, []*domain.User{u})
+	if err != nil {
+		return nil, err
+	}
+	return users[0], nil
+}
+
+func (s *UserService) findUser(ctx context.Context, userID string) (*domain.User, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, fmt.Errorf("%w: user_id must be a UUID", ErrInvalidArgument)
+	}
+	u, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+	return u, nil
+}
+
+// BatchGetUsers returns the profiles of the users that exist among userIDs, as
+// viewerID may see them. Duplicate IDs are ignored.
+func (s *UserService) BatchGetUsers(ctx context.Context, viewerID string, userIDs []string) ([]*domain.User, error) {
+	if len(userIDs) > maxBatchSize {
+		return nil, fmt.Errorf("%w: at most %d user_ids per request", ErrInvalidArgument, maxBatchSize)
+	}
+	seen := make(map[string]bool, len(userIDs))
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: user_id %q must be a UUID", ErrInvalidArgument, id)
+		}
+		if key := parsed.String(); !seen[key] {
+			seen[key] = true
+			ids = append(ids, key)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	users, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return s.redact(ctx, viewerID, users)
+}
+
+// UpdateProfile changes the user's display name, about text and/or profile picture.
+// Nil values are left unchanged.
+func (s *UserService) UpdateProfile(ctx context.Context, userID string, displayName, aboutText, profilePictureURL *string) (*domain.User, error) {
+	if displayName != nil {
+		name := strings.TrimSpace(*displayName)
+		if name == "" || utf8.RuneCountInString(name) > maxDisplayNameLength {
+			return nil, fmt.Errorf("%w: display_name must be 1-%d characters", ErrInvalidArgument, maxDisplayNameLength)
+		}
+		displayName = &name
+	}
+	if aboutText != nil {
+		about := strings.TrimSpace(*aboutText)
+		if utf8.RuneCountInString(about) > maxAboutTextLength {
+			return nil, fmt.Errorf("%w: about_text must be at most %d characters", ErrInvalidArgument, maxAboutTextLength)
+		}
+		aboutText = &about
+	}
+	if profilePictureURL != nil && *profilePictureURL != "" {
+		if err := validatePictureURL(*profilePictureURL); err != nil {
+			return nil, err
+		}
+	}
+	if displayName == nil && aboutText == nil && profilePictureURL == nil {
+		return s.findUser(ctx, userID)
+	}
+
+	u, err := s.repo.UpdateProfile(ctx, userID, displayName, aboutText, profilePictureURL)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+	return u, nil
+}
+
+// ProfileWatchers returns the users who should be told about a profile change:
+// everyone who has the user in their contacts, plus the user's own other devices.
+// Users separated from userID by a block are left out.
+func (s *UserService) ProfileWatchers(ctx context.Context, userID string) ([]string, error) {
+	owners, err := s.repo.ListContactOwners(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if s.blocks != nil && len(owners) > 0 {
+		blocked, err := s.blocks.BlockedPeers(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		kept := owners[:0]
+		for _, id := range owners {
+			if !blocked[id] {
+				kept = append(kept, id)
+			}
+		}
+		owners = kept
+	}
+	return append(owners, userID), nil
+}
+
+func validatePictureURL(raw string) error {
+	if len(raw) > maxPictureURLLength {
+		return fmt.Errorf("%w: profile_picture_url is too long", ErrInvalidArgument)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: profile_picture_url must be an http(s) URL", ErrInvalidArgument)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+)
+
+type fakeUserRepo struct {
+	users    map[string]*domain.User
+	contacts map[string][]string // contact user ID -> owners
+}
+
+func (f *fakeUserRepo) FindByID(ctx context.Context, userID string) (*domain.User, error) {
+	return f.users[userID], nil
+}
+
+func (f *fakeUserRepo) FindByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	var out []*domain.User
+	for _, id := range userIDs {
+		if u, ok := f.users[id]; ok {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeUserRepo) UpdateProfile(ctx context.Context, userID string, displayName, aboutText, pictureURL *string) (*domain.User, error) {
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, nil
+	}
+	if displayName != nil {
+		u.DisplayName = *displayName
+	}
+	if aboutText != nil {
+		u.AboutText = *aboutText
+	}
+	if pictureURL != nil {
+		u.ProfilePictureURL = *pictureURL
+	}
+	return u, nil
+}
+
+func (f *fakeUserRepo) ListContactOwners(ctx context.Context, userID string) ([]string, error) {
+	return f.contacts[userID], nil
+}
+
+func TestUpdateProfile_ValidatesSchemaLimits(t *testing.T) {
+	id := uuid.New()
+	repo := &fakeUserRepo{users: map[string]*domain.User{id.String(): {ID: id, DisplayName: "New User"}}}
+	s := NewUserService(repo, nil)
+	ctx := context.Background()
+
+	str := func(v string) *string { return &v }
+	cases := []struct {
+		name               string
+		displayName, about *string
+		picture            *string
+	}{
+		{"empty name", str("   "), nil, nil},
+		{"long name", str(strings.Repeat("a", 101)), nil, nil},
+		{"long about", nil, str(strings.Repeat("ü", 251)), nil},
+		{"bad picture", nil, nil, str("javascript:alert(1)")},
+	}
+	for _, c := range cases {
+		if _, err := s.UpdateProfile(ctx, id.String(), c.displayName, c.about, c.picture); !errors.Is(err, ErrInvalidArgument) {
+			t.Fatalf("%s: expected ErrInvalidArgument, got %v", c.name, err)
+		}
+	}
+
+	// Limits count characters, not bytes
+	u, err := s.UpdateProfile(ctx, id.String(), str(" Ayşe "), str(strings.Repeat("ü", 250)), str("https://cdn.example.com/a.jpg"))
+	if err != nil {
+		t.Fatalf("UpdateProfile error: %v", err)
+	}
+	if u.DisplayName != "Ayşe" || u.ProfilePictureURL != "https://cdn.example.com/a.jpg" {
+		t.Fatalf("unexpected profile: %+v", u)
+	}
+
+	if _, err := s.UpdateProfile(ctx, uuid.NewString(), str("x"), nil, nil); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestBatchGetUsers_DedupesAndValidates(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	repo := &fakeUserRepo{users: map[string]*domain.User{a.String(): {ID: a}, b.String(): {ID: b}}}
+	s := NewUserService(repo, nil)
+	ctx := context.Background()
+
+	users, err := s.BatchGetUsers(ctx, a.String(), []string{a.String(), a.String(), b.String(), uuid.NewString()})
+	if err != nil {
+		t.Fatalf("BatchGetUsers error: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(users))
+	}
+	if _, err := s.BatchGetUsers(ctx, a.String(), []string{"not-a-uuid"}); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument, got %v", err)
+	}
+}
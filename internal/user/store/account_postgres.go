@@ -0,0 +1,318 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// AccountStore implements AccountRepository for PostgreSQL.
+type AccountStore struct {
+	db       *sql.DB
+	contacts *ContactStore
+	blocks   *BlockStore
+	privacy  *PrivacyStore
+}
+
+func NewAccountStore(db *sql.DB) repository.AccountRepository {
+	return &AccountStore{db: db, contacts: &ContactStore{db: db}, blocks: &BlockStore{db: db}, privacy: &PrivacyStore{db: db}}
+}
+
+func (s *AccountStore) ExportAccount(ctx context.Context, userID string) (*domain.AccountExport, error) {
+	u, err := scanUser(s.db.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := &domain.AccountExport{ExportedAt: time.Now().UTC(), User: u}
+
+	settings, err := s.privacy.GetPrivacySettings(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	out.PrivacySettings = settings[userID]
+	if out.Contacts, err = s.contacts.ListContacts(ctx, userID); err != nil {
+		return nil, err
+	}
+	if out.Blocked, err = s.blocks.ListBlocked(ctx, userID); err != nil {
+		return nil, err
+	}
+	if out.Conversations, err = s.exportConversations(ctx, userID); err != nil {
+		return nil, err
+	}
+	if out.Messages, err = s.exportMessages(ctx, userID); err != nil {
+		return nil, err
+	}
+	if out.MessageEdits, err = s.exportMessageEdits(ctx, userID); err != nil {
+		return nil, err
+	}
+	if out.Reactions, err = s.exportReactions(ctx, userID); err != nil {
+		return nil, err
+	}
+	if out.Devices, err = s.exportDevices(ctx, userID); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *AccountStore) exportConversations(ctx context.Context, userID string) ([]*domain.Conversation, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT c.id, c.is_group, c.group_name, c.group_description, c.group_icon_url, c.created_at, p.muted_until,
+			ARRAY(SELECT p2.user_id::text FROM conversation_participants p2 WHERE p2.conversation_id = c.id)
+		FROM conversations c
+		JOIN conversation_participants p ON p.conversation_id = c.id
+		WHERE p.user_id = $1
+		ORDER BY c.created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []*domain.Conversation{}
+	for rows.Next() {
+		var (
+			c                               domain.Conversation
+			groupName, description, iconURL sql.NullString
+			mutedUntil                      sql.NullTime
+			participants                    []string
+		)
+		if err := rows.Scan(&c.ID, &c.IsGroup, &groupName, &description, &iconURL, &c.CreatedAt, &mutedUntil, pq.Array(&participants)); err != nil {
+			return nil, err
+		}
+		if groupName.Valid {
+			c.GroupName = &groupName.String
+		}
+		if description.Valid {
+			c.GroupDescription = &description.String
+		}
+		if iconURL.Valid {
+			c.GroupIconURL = &iconURL.String
+		}
+		if mutedUntil.Valid {
+			c.MutedUntil = &mutedUntil.Time
+		}
+		c.ParticipantIDs = make([]uuid.UUID, 0, len(participants))
+		for _, id := range participants {
+			if parsed, err := uuid.Parse(id); err == nil {
+				c.ParticipantIDs = append(c.ParticipantIDs, parsed)
+			}
+		}
+		out = append(out, &c)
+	}
+	return out, rows.Err()
+}
+
+func (s *AccountStore) exportMessages(ctx context.Context, userID string) ([]*domain.ChatMessage, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, conversation_id, sender_id, content, media_url, media_type, client_message_id, is_system, created_at, edited_at, deleted_at, reply_to_message_id
+		FROM messages WHERE sender_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []*domain.ChatMessage{}
+	for rows.Next() {
+		var (
+			m                             domain.ChatMessage
+			mediaURL, mediaType, clientID sql.NullString
+			editedAt, deletedAt           sql.NullTime
+			replyTo                       uuid.NullUUID
+		)
+		if err := rows.Scan(&m.ID, &m.ConversationID, &m.SenderID, &m.Content, &mediaURL, &mediaType, &clientID, &m.IsSystem, &m.CreatedAt, &editedAt, &deletedAt, &replyTo); err != nil {
+			return nil, err
+		}
+		if mediaURL.Valid {
+			m.MediaURL = &mediaURL.String
+		}
+		if mediaType.Valid {
+			m.MediaType = &mediaType.String
+		}
+		if clientID.Valid {
+			m.ClientMessageID = &clientID.String
+		}
+		if editedAt.Valid {
+			m.EditedAt = &editedAt.Time
+		}
+		if deletedAt.Valid {
+			m.DeletedAt = &deletedAt.Time
+		}
+		if replyTo.Valid {
+			m.ReplyToMessageID = &replyTo.UUID
+		}
+		out = append(out, &m)
+	}
+	return out, rows.Err()
+}
+
+func (s *AccountStore) exportMessageEdits(ctx context.Context, userID string) ([]*domain.MessageEdit, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT e.message_id, e.content, e.replaced_at
+		FROM message_edits e JOIN messages m ON m.id = e.message_id
+		WHERE m.sender_id = $1 ORDER BY e.replaced_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []*domain.MessageEdit{}
+	for rows.Next() {
+		var e domain.MessageEdit
+		if err := rows.Scan(&e.MessageID, &e.Content, &e.ReplacedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, &e)
+	}
+	return out, rows.Err()
+}
+
+func (s *AccountStore) exportReactions(ctx context.Context, userID string) ([]*domain.MessageReaction, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT message_id, user_id, reaction_emoji, created_at
+		FROM message_reactions WHERE user_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []*domain.MessageReaction{}
+	for rows.Next() {
+		var r domain.MessageReaction
+		if err := rows.Scan(&r.MessageID, &r.UserID, &r.ReactionEmoji, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, &r)
+	}
+	return out, rows.Err()
+}
+
+func (s *AccountStore) exportDevices(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, user_id, family_id, device_name, device_type, push_notification_token, push_provider, last_login_at, created_at, revoked_at
+		FROM user_devices WHERE user_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []*domain.UserDevice{}
+	for rows.Next() {
+		var (
+			d                              domain.UserDevice
+			name, typ, pushToken, provider sql.NullString
+			lastLogin, revokedAt           sql.NullTime
+		)
+		if err := rows.Scan(&d.ID, &d.UserID, &d.FamilyID, &name, &typ, &pushToken, &provider, &lastLogin, &d.CreatedAt, &revokedAt); err != nil {
+			return nil, err
+		}
+		d.DeviceName = name.String
+		d.DeviceType = typ.String
+		d.PushNotificationToken = pushToken.String
+		d.PushProvider = provider.String
+		d.LastLoginAt = lastLogin.Time
+		if revokedAt.Valid {
+			d.RevokedAt = &revokedAt.Time
+		}
+		out = append(out, &d)
+	}
+	return out, rows.Err()
+}
+
+func (s *AccountStore) DeleteAccount(ctx context.Context, userID string) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Other participants keep the messages; only the sender is forgotten
+	if _, err := tx.ExecContext(ctx, `UPDATE messages SET sender_id = NULL WHERE sender_id = $1`, userID); err != nil {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM message_status WHERE user_id = $1`, userID); err != nil {
+		return false, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `DELETE FROM conversation_participants WHERE user_id = $1 RETURNING conversation_id`, userID)
+	if err != nil {
+		return false, err
+	}
+	var conversationIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return false, err
+		}
+		conversationIDs = append(conversationIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	if len(conversationIDs) > 0 {
+		if _, err := tx.ExecContext(ctx, `
+			DELETE FROM conversations c
+			WHERE c.id = ANY($1::uuid[])
+				AND NOT EXISTS (SELECT 1 FROM conversation_participants p WHERE p.conversation_id = c.id)`,
+			pq.Array(conversationIDs)); err != nil {
+			return false, err
+		}
+		// Groups the user was the last admin of get their longest-standing member as admin
+		if _, err := tx.ExecContext(ctx, `
+			WITH promoted AS (
+				UPDATE conversation_participants p SET role = 'admin'
+				FROM (
+					SELECT DISTINCT ON (p2.conversation_id) p2.conversation_id, p2.user_id
+					FROM conversation_participants p2
+					JOIN conversations c ON c.id = p2.conversation_id
+					WHERE p2.conversation_id = ANY($1::uuid[]) AND c.is_group
+						AND NOT EXISTS (SELECT 1 FROM conversation_participants a WHERE a.conversation_id = p2.conversation_id AND a.role = 'admin')
+					ORDER BY p2.conversation_id, p2.joined_at, p2.user_id
+				) first
+				WHERE p.conversation_id = first.conversation_id AND p.user_id = first.user_id
+				RETURNING p.conversation_id, p.user_id
+			)
+			INSERT INTO conversation_membership_events(conversation_id, user_id, action, created_at)
+			SELECT conversation_id, user_id, 'promoted', NOW() FROM promoted`,
+			pq.Array(conversationIDs)); err != nil {
+			return false, err
+		}
+	}
+
+	for _, q := range []string{
+		`DELETE FROM contacts WHERE user_id = $1`,
+		`UPDATE contacts SET contact_user_id = NULL WHERE contact_user_id = $1`,
+		`DELETE FROM status_views WHERE user_id = $1 OR status_id IN (SELECT id FROM status_updates WHERE user_id = $1)`,
+		`DELETE FROM status_updates WHERE user_id = $1`,
+		`DELETE FROM blocked_users WHERE blocker_user_id = $1 OR blocked_user_id = $1`,
+		`DELETE FROM user_devices WHERE user_id = $1`,
+		`DELETE FROM security_events WHERE user_id = $1`,
+	} {
+		if _, err := tx.ExecContext(ctx, q, userID); err != nil {
+			return false, err
+		}
+	}
+
+	// Privacy settings, two-step PINs, hidden messages, reactions, join
+	// requests and the user's group membership history cascade; messages the
+	// user deleted for everyone, membership changes the user made and invites
+	// the user created lose their actor
+	res, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, tx.Commit()
+}
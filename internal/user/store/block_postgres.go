@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+)
+
+// BlockStore implements BlockRepository for PostgreSQL.
+type BlockStore struct {
+	db *sql.DB
+}
+
+func NewBlockStore(db *sql.DB) repository.BlockRepository {
+	return &BlockStore{db: db}
+}
+
+func (s *BlockStore) BlockUser(ctx context.Context, blockerID, blockedID string) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO blocked_users (blocker_user_id, blocked_user_id, created_at) VALUES ($1, $2, NOW()) ON CONFLICT DO NOTHING`, blockerID, blockedID)
+	return err
+}
+
+func (s *BlockStore) UnblockUser(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM blocked_users WHERE blocker_user_id = $1 AND blocked_user_id = $2`, blockerID, blockedID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s *BlockStore) ListBlocked(ctx context.Context, blockerID string) ([]*domain.BlockedUser, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT blocker_user_id, blocked_user_id, created_at FROM blocked_users WHERE blocker_user_id = $1 ORDER BY created_at DESC`, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.BlockedUser
+	for rows.Next() {
+		var b domain.BlockedUser
+		if err := rows.Scan(&b.BlockerUserID, &b.BlockedUserID, &b.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, &b)
+	}
+	return out, rows.Err()
+}
+
+func (s *BlockStore) IsBlocked(ctx context.Context, userA, userB string) (bool, error) {
+	var blocked bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (
+			SELECT 1 FROM blocked_users
+			WHERE (blocker_user_id = $1 AND blocked_user_id = $2) OR (blocker_user_id = $2 AND blocked_user_id = $1)
+		)`, userA, userB).Scan(&blocked)
+	return blocked, err
+}
+
+func (s *BlockStore) ListBlockPeers(ctx context.Context, userID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT blocked_user_id FROM blocked_users WHERE blocker_user_id = $1
+		UNION
+		SELECT blocker_user_id FROM blocked_users WHERE blocked_user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
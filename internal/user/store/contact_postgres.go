@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/lib/pq"
+)
+
+// ContactStore implements ContactRepository for PostgreSQL.
+type ContactStore struct {
+	db *sql.DB
+}
+
+func NewContactStore(db *sql.DB) repository.ContactRepository {
+	return &ContactStore{db: db}
+}
+
+// SetHashSalt stores the salt users.phone_hash is computed with. When it is
+// new or changed, phone_hash is recomputed for every user in the same
+// transaction; otherwise nothing is written.
+func (s *ContactStore) SetHashSalt(ctx context.Context, salt string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.QueryRowContext(ctx, `SELECT salt FROM contact_hash_salt FOR UPDATE`).Scan(&current)
+	if err == nil && current == salt {
+		return nil
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO contact_hash_salt (id, salt) VALUES (TRUE, $1)
+		ON CONFLICT (id) DO UPDATE SET salt = EXCLUDED.salt`, salt); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET phone_hash = encode(sha256(convert_to($1 || phone_number, 'UTF8')), 'hex')`, salt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// MatchPhoneHashes looks hashes up in the indexed users.phone_hash column.
+func (s *ContactStore) MatchPhoneHashes(ctx context.Context, hashes []string) (map[string]string, error) {
+	out := make(map[string]string)
+	if len(hashes) == 0 {
+		return out, nil
+	}
+	q := `SELECT phone_hash, phone_number FROM users WHERE phone_hash = ANY($1)`
+	rows, err := s.db.QueryContext(ctx, q, pq.Array(hashes))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hash, phone string
+		if err := rows.Scan(&hash, &phone); err != nil {
+			return nil, err
+		}
+		out[hash] = phone
+	}
+	return out, rows.Err()
+}
+
+func (s *ContactStore) UpsertContacts(ctx context.Context, userID string, contacts []repository.ContactUpsert) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	q := `INSERT INTO contacts (user_id, contact_phone_number, contact_user_id, display_name_override)
+		VALUES ($1, $2, (SELECT id FROM users WHERE phone_number = $2), NULLIF($3, ''))
+		ON CONFLICT (user_id, contact_phone_number) DO UPDATE SET
+			contact_user_id = EXCLUDED.contact_user_id,
+			display_name_override = CASE WHEN $3::text IS NULL THEN contacts.display_name_override ELSE NULLIF($3, '') END`
+	stmt, err := tx.PrepareContext(ctx, q)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, c := range contacts {
+		if _, err := stmt.ExecContext(ctx, userID, c.PhoneNumber, c.DisplayNameOverride); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *ContactStore) DeleteContacts(ctx context.Context, userID string, phoneNumbers []string) error {
+	if len(phoneNumbers) == 0 {
+		return nil
+	}
+	_, err := s.db.ExecContext(ctx, `DELETE FROM contacts WHERE user_id = $1 AND contact_phone_number = ANY($2)`, userID, pq.Array(phoneNumbers))
+	return err
+}
+
+func (s *ContactStore) DeleteContactsExcept(ctx context.Context, userID string, keep []string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM contacts WHERE user_id = $1 AND NOT (contact_phone_number = ANY($2))`, userID, pq.Array(keep))
+	return err
+}
+
+func (s *ContactStore) LinkRegisteredContacts(ctx context.Context, userID string) error {
+	q := `UPDATE contacts c SET contact_user_id = u.id
+		FROM users u
+		WHERE c.user_id = $1 AND c.contact_user_id IS NULL AND u.phone_number = c.contact_phone_number`
+	_, err := s.db.ExecContext(ctx, q, userID)
+	return err
+}
+
+func (s *ContactStore) ListContacts(ctx context.Context, userID string) ([]*domain.Contact, error) {
+	q := `SELECT user_id, contact_phone_number, contact_user_id, display_name_override
+		FROM contacts WHERE user_id = $1 ORDER BY contact_phone_number`
+	rows, err := s.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.Contact
+	for rows.Next() {
+		var (
+			c        domain.Contact
+			override sql.NullString
+		)
+		if err := rows.Scan(&c.UserID, &c.ContactPhoneNumber, &c.ContactUserID, &override); err != nil {
+			return nil, err
+		}
+		c.DisplayNameOverride = override.String
+		out = append(out, &c)
+	}
+	return out, rows.Err()
+}
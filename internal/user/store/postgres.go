@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/lib/pq"
+)
+
+// UserStore implements the user service's UserRepository for PostgreSQL.
+type UserStore struct {
+	db *sql.DB
+}
+
+// NewUserStore creates a new UserStore.
+func NewUserStore(db *sql.DB) repository.UserRepository {
+	return &UserStore{db: db}
+}
+
+const userColumns = `id, phone_number, display_name, profile_picture_url, about_text, last_seen_at, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanUser scans a row selected with userColumns. Nullable profile fields become empty values.
+func scanUser(row rowScanner) (*domain.User, error) {
+	var (
+		u                           domain.User
+		displayName, picture, about sql.NullString
+		lastSeen                    sql.NullTime
+	)
+	if err := row.Scan(&u.ID, &u.PhoneNumber, &displayName, &picture, &about, &lastSeen, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		return nil, err
+	}
+	u.DisplayName = displayName.String
+	u.ProfilePictureURL = picture.String
+	u.AboutText = about.String
+	u.LastSeenAt = lastSeen.Time
+	return &u, nil
+}
+
+func (s *UserStore) FindByID(ctx context.Context, userID string) (*domain.User, error) {
+	u, err := scanUser(s.db.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return u, err
+}
+
+func (s *UserStore) FindByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT `+userColumns+` FROM users WHERE id = ANY($1::uuid[])`, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	return out, rows.Err()
+}
+
+func (s *UserStore) UpdateProfile(ctx context.Context, userID string, displayName, aboutText, profilePictureURL *string) (*domain.User, error) {
+	q := `UPDATE users SET
+			display_name = COALESCE($2, display_name),
+			about_text = COALESCE($3, about_text),
+			profile_picture_url = COALESCE($4, profile_picture_url),
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + userColumns
+	u, err := scanUser(s.db.QueryRowContext(ctx, q, userID, displayName, aboutText, profilePictureURL))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return u, err
+}
+
+func (s *UserStore) ListContactOwners(ctx context.Context, userID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT user_id FROM contacts WHERE contact_user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/dykethecreator/GoApp/internal/user/repository"
+	"github.com/dykethecreator/GoApp/pkg/domain"
+	"github.com/lib/pq"
+)
+
+// PrivacyStore implements PrivacyRepository for PostgreSQL.
+type PrivacyStore struct {
+	db *sql.DB
+}
+
+func NewPrivacyStore(db *sql.DB) repository.PrivacyRepository {
+	return &PrivacyStore{db: db}
+}
+
+const privacyColumns = `user_id, last_seen, online, profile_photo, about, updated_at`
+
+func scanPrivacy(row rowScanner) (*domain.PrivacySettings, error) {
+	var p domain.PrivacySettings
+	if err := row.Scan(&p.UserID, &p.LastSeen, &p.Online, &p.ProfilePhoto, &p.About, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (s *PrivacyStore) GetPrivacySettings(ctx context.Context, userIDs []string) (map[string]*domain.PrivacySettings, error) {
+	out := make(map[string]*domain.PrivacySettings, len(userIDs))
+	if len(userIDs) == 0 {
+		return out, nil
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT `+privacyColumns+` FROM user_privacy_settings WHERE user_id = ANY($1)`, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		p, err := scanPrivacy(rows)
+		if err != nil {
+			return nil, err
+		}
+		out[p.UserID.String()] = p
+	}
+	return out, rows.Err()
+}
+
+func (s *PrivacyStore) UpdatePrivacySettings(ctx context.Context, userID string, update repository.PrivacyUpdate) (*domain.PrivacySettings, error) {
+	q := `INSERT INTO user_privacy_settings (user_id, last_seen, online, profile_photo, about, updated_at)
+		VALUES ($1, COALESCE($2, 'everyone'), COALESCE($3, 'everyone'), COALESCE($4, 'everyone'), COALESCE($5, 'everyone'), NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			last_seen = COALESCE($2, user_privacy_settings.last_seen),
+			online = COALESCE($3, user_privacy_settings.online),
+			profile_photo = COALESCE($4, user_privacy_settings.profile_photo),
+			about = COALESCE($5, user_privacy_settings.about),
+			updated_at = NOW()
+		RETURNING ` + privacyColumns
+	return scanPrivacy(s.db.QueryRowContext(ctx, q, userID,
+		nullVisibility(update.LastSeen), nullVisibility(update.Online), nullVisibility(update.ProfilePhoto), nullVisibility(update.About)))
+}
+
+func (s *PrivacyStore) ListContactUserIDs(ctx context.Context, ownerID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT contact_user_id FROM contacts WHERE user_id = $1 AND contact_user_id IS NOT NULL`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
+
+func nullVisibility(v *domain.PrivacyVisibility) sql.NullString {
+	if v == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(*v), Valid: true}
+}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS auth_attempts_updated_at_idx;
+DROP TABLE IF EXISTS auth_attempts;
@@ -0,0 +1,11 @@
+-- Attempt counters and lockouts for rate-limited auth actions (SendOTP / VerifyOTP)
+CREATE TABLE IF NOT EXISTS auth_attempts (
+    key VARCHAR(255) PRIMARY KEY, -- e.g. 'otp_send:phone:+90555...', 'otp_verify:ip:1.2.3.4'
+    count INT NOT NULL DEFAULT 0,
+    window_start TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    lockouts INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS auth_attempts_updated_at_idx ON auth_attempts (updated_at);
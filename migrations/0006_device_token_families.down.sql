@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS security_events_user_id_idx;
+DROP TABLE IF EXISTS security_events;
+
+DROP INDEX IF EXISTS user_devices_family_id_idx;
+ALTER TABLE user_devices
+    DROP COLUMN IF EXISTS family_id;
@@ -0,0 +1,25 @@
+-- Group rotated refresh tokens of one device session into a family so that
+-- replaying a revoked token can revoke the whole session.
+ALTER TABLE user_devices
+    ADD COLUMN IF NOT EXISTS family_id uuid;
+
+-- Existing rows each start their own family
+UPDATE user_devices SET family_id = id WHERE family_id IS NULL;
+
+ALTER TABLE user_devices
+    ALTER COLUMN family_id SET NOT NULL,
+    ALTER COLUMN family_id SET DEFAULT uuid_generate_v4();
+
+CREATE INDEX IF NOT EXISTS user_devices_family_id_idx ON user_devices (family_id);
+
+-- Audit log of security-relevant auth events (e.g. refresh token reuse)
+CREATE TABLE IF NOT EXISTS security_events (
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id uuid NOT NULL REFERENCES users(id),
+    event_type VARCHAR(50) NOT NULL,
+    family_id uuid,
+    details TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS security_events_user_id_idx ON security_events (user_id, created_at);
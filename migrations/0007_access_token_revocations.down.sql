@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS access_token_revocations_expires_at_idx;
+DROP TABLE IF EXISTS access_token_revocations;
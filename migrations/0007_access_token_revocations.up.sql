@@ -0,0 +1,10 @@
+-- Access-token revocation watermarks. Access tokens whose iat is at or before
+-- revoked_before are rejected by the auth interceptors.
+CREATE TABLE IF NOT EXISTS access_token_revocations (
+    key VARCHAR(255) PRIMARY KEY, -- 'user:<user_id>' (logout all) or 'session:<family_id>' (device revoked)
+    revoked_before TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL, -- revoked_before + access token lifetime; row can be dropped afterwards
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS access_token_revocations_expires_at_idx ON access_token_revocations (expires_at);
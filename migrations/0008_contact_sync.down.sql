@@ -0,0 +1 @@
+DROP INDEX IF EXISTS contacts_contact_user_id_idx;
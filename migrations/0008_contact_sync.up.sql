@@ -0,0 +1,2 @@
+-- Contact discovery: find who has a user in their address book (profile/presence fan-out)
+CREATE INDEX IF NOT EXISTS contacts_contact_user_id_idx ON contacts (contact_user_id) WHERE contact_user_id IS NOT NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS blocked_users_blocked_user_id_idx;
@@ -0,0 +1,2 @@
+-- Reverse lookup for "who blocked me" (presence/typing filtering, 1:1 message checks)
+CREATE INDEX IF NOT EXISTS blocked_users_blocked_user_id_idx ON blocked_users (blocked_user_id);
@@ -0,0 +1,9 @@
+-- Per-user privacy settings; users without a row see everything as 'everyone'
+CREATE TABLE IF NOT EXISTS user_privacy_settings (
+    user_id uuid PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    last_seen VARCHAR(16) NOT NULL DEFAULT 'everyone' CHECK (last_seen IN ('everyone', 'contacts', 'nobody')),
+    online VARCHAR(16) NOT NULL DEFAULT 'everyone' CHECK (online IN ('everyone', 'contacts', 'nobody')),
+    profile_photo VARCHAR(16) NOT NULL DEFAULT 'everyone' CHECK (profile_photo IN ('everyone', 'contacts', 'nobody')),
+    about VARCHAR(16) NOT NULL DEFAULT 'everyone' CHECK (about IN ('everyone', 'contacts', 'nobody')),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
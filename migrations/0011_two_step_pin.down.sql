@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pin_challenges;
+DROP TABLE IF EXISTS two_step_pins;
@@ -0,0 +1,22 @@
+-- Two-step verification: a PIN asked after the SMS code when logging in
+CREATE TABLE IF NOT EXISTS two_step_pins (
+    user_id uuid PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    pin_hash TEXT NOT NULL,                 -- pbkdf2-sha256$<iterations>$<salt>$<hash>
+    recovery_email VARCHAR(254),
+    reset_requested_at TIMESTAMPTZ,         -- cooldown reset started
+    reset_code_hash TEXT,                   -- SHA-256 of the emailed reset code
+    reset_code_expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Logins waiting for the PIN; the token itself is only known to the client
+CREATE TABLE IF NOT EXISTS pin_challenges (
+    token_hash TEXT PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device_id VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS pin_challenges_expires_at_idx ON pin_challenges (expires_at);
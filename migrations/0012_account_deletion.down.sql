@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_messages_sender;
+
+ALTER TABLE conversation_participants DROP CONSTRAINT IF EXISTS conversation_participants_user_id_fkey;
+ALTER TABLE conversation_participants
+    ADD CONSTRAINT conversation_participants_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- Messages of deleted accounts cannot get a sender back
+DELETE FROM messages WHERE sender_id IS NULL;
+
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_sender_id_fkey;
+ALTER TABLE messages
+    ADD CONSTRAINT messages_sender_id_fkey FOREIGN KEY (sender_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE messages ALTER COLUMN sender_id SET NOT NULL;
@@ -0,0 +1,14 @@
+-- Deleting a user must not delete other people's chat history. Messages keep
+-- their row with no sender; memberships are removed by the account deletion
+-- itself rather than by a cascade.
+ALTER TABLE messages ALTER COLUMN sender_id DROP NOT NULL;
+
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_sender_id_fkey;
+ALTER TABLE messages
+    ADD CONSTRAINT messages_sender_id_fkey FOREIGN KEY (sender_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE conversation_participants DROP CONSTRAINT IF EXISTS conversation_participants_user_id_fkey;
+ALTER TABLE conversation_participants
+    ADD CONSTRAINT conversation_participants_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+
+CREATE INDEX IF NOT EXISTS idx_messages_sender ON messages(sender_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS contacts_contact_phone_number_idx;
+ALTER TABLE messages DROP COLUMN IF EXISTS is_system;
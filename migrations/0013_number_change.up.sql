@@ -0,0 +1,6 @@
+-- System messages (e.g. "changed their phone number") are stored with the
+-- messages they appear between; sender_id is the user they are about.
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS is_system BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Relinking address book entries when a user changes number looks contacts up by number
+CREATE INDEX IF NOT EXISTS contacts_contact_phone_number_idx ON contacts (contact_phone_number);
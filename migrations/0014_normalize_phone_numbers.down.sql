@@ -0,0 +1,2 @@
+-- Normalized numbers are kept; only the review list is dropped.
+DROP TABLE IF EXISTS phone_number_normalization_issues;
@@ -0,0 +1,89 @@
+-- Phone numbers are stored in E.164. Existing rows are normalized where no
+-- region is needed: formatting characters are dropped and a leading 00
+-- becomes +. Users whose number is still not E.164 afterwards, or whose
+-- normalized number would collide with another account, keep their number
+-- and are listed in phone_number_normalization_issues for manual review.
+CREATE TABLE IF NOT EXISTS phone_number_normalization_issues (
+    user_id uuid NOT NULL,
+    phone_number VARCHAR(20) NOT NULL,
+    normalized VARCHAR(20),
+    conflicting_user_id uuid,
+    reason VARCHAR(20) NOT NULL, -- 'invalid' or 'collision'
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TEMP TABLE user_phone_normalization AS
+SELECT id, phone_number,
+       regexp_replace(regexp_replace(phone_number, '[^0-9+]', '', 'g'), '^00', '+') AS normalized
+FROM users;
+
+INSERT INTO phone_number_normalization_issues (user_id, phone_number, normalized, reason)
+SELECT id, phone_number, normalized, 'invalid'
+FROM user_phone_normalization
+WHERE normalized !~ '^\+[1-9][0-9]{6,14}$';
+
+INSERT INTO phone_number_normalization_issues (user_id, phone_number, normalized, conflicting_user_id, reason)
+SELECT a.id, a.phone_number, a.normalized, b.id, 'collision'
+FROM user_phone_normalization a
+JOIN user_phone_normalization b ON b.normalized = a.normalized AND b.id <> a.id
+WHERE a.normalized <> a.phone_number
+  AND a.normalized ~ '^\+[1-9][0-9]{6,14}$';
+
+UPDATE users u
+SET phone_number = n.normalized, updated_at = NOW()
+FROM user_phone_normalization n
+WHERE u.id = n.id
+  AND n.normalized <> n.phone_number
+  AND NOT EXISTS (SELECT 1 FROM phone_number_normalization_issues i WHERE i.user_id = n.id);
+
+DO $$
+DECLARE
+    invalid_count INT;
+    collision_count INT;
+BEGIN
+    SELECT COUNT(*) FILTER (WHERE reason = 'invalid'), COUNT(DISTINCT user_id) FILTER (WHERE reason = 'collision')
+    INTO invalid_count, collision_count
+    FROM phone_number_normalization_issues;
+    IF invalid_count > 0 OR collision_count > 0 THEN
+        RAISE WARNING 'phone number normalization: % invalid, % colliding; see phone_number_normalization_issues',
+            invalid_count, collision_count;
+    END IF;
+END $$;
+
+-- Address book entries: when an address book holds several forms of one
+-- number, the normalized entry (or else the first) is kept.
+CREATE TEMP TABLE contact_phone_normalization AS
+SELECT user_id, contact_phone_number,
+       regexp_replace(regexp_replace(contact_phone_number, '[^0-9+]', '', 'g'), '^00', '+') AS normalized
+FROM contacts;
+
+DELETE FROM contacts c
+USING contact_phone_normalization n
+WHERE c.user_id = n.user_id
+  AND c.contact_phone_number = n.contact_phone_number
+  AND n.normalized <> n.contact_phone_number
+  AND n.normalized ~ '^\+[1-9][0-9]{6,14}$'
+  AND EXISTS (
+      SELECT 1 FROM contact_phone_normalization m
+      WHERE m.user_id = n.user_id AND m.normalized = n.normalized
+        AND (m.contact_phone_number = m.normalized OR m.contact_phone_number < n.contact_phone_number)
+  );
+
+UPDATE contacts c
+SET contact_phone_number = n.normalized
+FROM contact_phone_normalization n
+WHERE c.user_id = n.user_id
+  AND c.contact_phone_number = n.contact_phone_number
+  AND n.normalized <> n.contact_phone_number
+  AND n.normalized ~ '^\+[1-9][0-9]{6,14}$';
+
+-- Entries that now match a registered number are linked to its user
+UPDATE contacts c
+SET contact_user_id = u.id
+FROM users u
+WHERE c.contact_user_id IS NULL
+  AND u.phone_number = c.contact_phone_number
+  AND u.id <> c.user_id;
+
+DROP TABLE user_phone_normalization;
+DROP TABLE contact_phone_normalization;
@@ -0,0 +1,15 @@
+-- Companion devices waiting to be linked by QR code. Both tokens are only
+-- known to the clients: the pairing token is shown in the QR code, the
+-- device secret stays on the new device.
+CREATE TABLE IF NOT EXISTS device_links (
+    token_hash TEXT PRIMARY KEY,
+    secret_hash TEXT NOT NULL,
+    device_name VARCHAR(100) NOT NULL,
+    device_type VARCHAR(20) NOT NULL,
+    user_id uuid REFERENCES users(id) ON DELETE CASCADE, -- set when a logged-in device approves
+    approved_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS device_links_expires_at_idx ON device_links (expires_at);
@@ -0,0 +1,3 @@
+ALTER TABLE conversation_participants DROP COLUMN IF EXISTS muted_until;
+DROP INDEX IF EXISTS user_devices_push_token_idx;
+ALTER TABLE user_devices DROP COLUMN IF EXISTS push_provider;
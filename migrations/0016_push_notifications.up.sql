@@ -0,0 +1,12 @@
+-- Push tokens are registered per device session with the service that
+-- delivers them. Tokens set before this migration have no provider and are
+-- not used until the device registers again.
+ALTER TABLE user_devices ADD COLUMN IF NOT EXISTS push_provider VARCHAR(10);
+
+CREATE INDEX IF NOT EXISTS user_devices_push_token_idx
+    ON user_devices (push_notification_token)
+    WHERE push_notification_token IS NOT NULL;
+
+-- Participants who muted a conversation get no push notifications for it
+-- until muted_until; muting "forever" stores 9999-12-31.
+ALTER TABLE conversation_participants ADD COLUMN IF NOT EXISTS muted_until TIMESTAMP;
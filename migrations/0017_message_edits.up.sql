@@ -0,0 +1,12 @@
+-- Edited messages keep their current content in messages; every version an
+-- edit replaced is kept in message_edits, replaced_at being when it was.
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS message_edits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    replaced_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS message_edits_message_idx ON message_edits (message_id, replaced_at);
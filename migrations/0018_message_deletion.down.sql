@@ -0,0 +1,5 @@
+ALTER TABLE conversation_participants DROP COLUMN IF EXISTS role;
+DROP TABLE IF EXISTS hidden_messages;
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,27 @@
+-- Messages deleted for everyone stay as tombstones: content and media are
+-- cleared and deleted_at/deleted_by say who removed them.
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Messages a participant deleted for themselves only
+CREATE TABLE IF NOT EXISTS hidden_messages (
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    hidden_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, message_id)
+);
+
+-- Group admins may delete other participants' messages. The creator of a
+-- group is its admin; existing groups get their earliest participant.
+ALTER TABLE conversation_participants ADD COLUMN IF NOT EXISTS role VARCHAR(10) NOT NULL DEFAULT 'member';
+
+UPDATE conversation_participants p SET role = 'admin'
+FROM (
+    SELECT DISTINCT ON (p2.conversation_id) p2.conversation_id, p2.user_id
+    FROM conversation_participants p2
+    JOIN conversations c ON c.id = p2.conversation_id
+    WHERE c.is_group
+    ORDER BY p2.conversation_id, p2.joined_at, p2.user_id
+) first
+WHERE p.conversation_id = first.conversation_id AND p.user_id = first.user_id;
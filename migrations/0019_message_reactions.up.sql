@@ -0,0 +1,10 @@
+-- One reaction per user and message; reacting again replaces the emoji.
+CREATE TABLE IF NOT EXISTS message_reactions (
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reaction_emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (message_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS message_reactions_user_idx ON message_reactions (user_id);
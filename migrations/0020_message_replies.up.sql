@@ -0,0 +1,4 @@
+-- A reply quotes an earlier message of the same conversation. Messages
+-- deleted for everyone stay as tombstones, so the link normally survives;
+-- it is cleared only if the quoted row itself goes away.
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS reply_to_message_id UUID REFERENCES messages(id) ON DELETE SET NULL;
@@ -0,0 +1,16 @@
+-- Membership history of group conversations: who joined, was added, removed,
+-- left, promoted or demoted, and by whom (actor_id; NULL for automatic
+-- changes such as promoting a member when the last admin leaves).
+CREATE TABLE IF NOT EXISTS conversation_membership_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS conversation_membership_events_conversation_idx
+    ON conversation_membership_events (conversation_id, created_at);
+CREATE INDEX IF NOT EXISTS conversation_membership_events_actor_idx
+    ON conversation_membership_events (actor_id);
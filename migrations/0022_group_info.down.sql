@@ -0,0 +1,5 @@
+ALTER TABLE conversations
+    DROP COLUMN IF EXISTS announcement_mode,
+    DROP COLUMN IF EXISTS only_admins_edit_info,
+    DROP COLUMN IF EXISTS group_icon_url,
+    DROP COLUMN IF EXISTS group_description;
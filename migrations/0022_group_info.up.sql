@@ -0,0 +1,7 @@
+-- Group info besides the name, and settings admins control: whether only
+-- admins may edit the info, and announcement mode (only admins may send).
+ALTER TABLE conversations
+    ADD COLUMN IF NOT EXISTS group_description TEXT,
+    ADD COLUMN IF NOT EXISTS group_icon_url TEXT,
+    ADD COLUMN IF NOT EXISTS only_admins_edit_info BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS announcement_mode BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,28 @@
+-- Invite links to groups. max_uses NULL means unlimited; expires_at NULL never
+-- expires. Links requiring approval file a join request instead of joining.
+CREATE TABLE IF NOT EXISTS group_invites (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP,
+    max_uses INT,
+    use_count INT NOT NULL DEFAULT 0,
+    requires_approval BOOLEAN NOT NULL DEFAULT FALSE,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS group_invites_conversation_idx ON group_invites (conversation_id, created_at);
+CREATE INDEX IF NOT EXISTS group_invites_created_by_idx ON group_invites (created_by);
+
+-- Pending requests to join a group through an invite requiring approval.
+CREATE TABLE IF NOT EXISTS group_join_requests (
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invite_id UUID REFERENCES group_invites(id) ON DELETE SET NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (conversation_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS group_join_requests_user_idx ON group_join_requests (user_id);
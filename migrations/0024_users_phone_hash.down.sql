@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS users_phone_hash ON users;
+DROP FUNCTION IF EXISTS users_set_phone_hash();
+DROP INDEX IF EXISTS users_phone_hash_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS phone_hash;
+DROP TABLE IF EXISTS contact_hash_salt;
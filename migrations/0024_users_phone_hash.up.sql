@@ -0,0 +1,26 @@
+-- Salted phone number hashes for contact discovery, so hashed address book
+-- uploads are matched through an index instead of hashing every user.
+-- The salt (CONTACT_HASH_SALT) is configuration, not schema: services store it
+-- in contact_hash_salt at startup, which fills phone_hash for existing users
+-- whenever the salt is new or changed. The trigger keeps phone_hash current
+-- for new users and number changes.
+CREATE TABLE IF NOT EXISTS contact_hash_salt (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    salt TEXT NOT NULL
+);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone_hash VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS users_phone_hash_idx ON users (phone_hash);
+
+CREATE OR REPLACE FUNCTION users_set_phone_hash() RETURNS trigger AS $$
+BEGIN
+    NEW.phone_hash := (SELECT encode(sha256(convert_to(salt || NEW.phone_number, 'UTF8')), 'hex') FROM contact_hash_salt);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS users_phone_hash ON users;
+CREATE TRIGGER users_phone_hash BEFORE INSERT OR UPDATE OF phone_number ON users
+    FOR EACH ROW EXECUTE FUNCTION users_set_phone_hash();
+
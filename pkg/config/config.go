@@ -1,31 +1,400 @@
+// Package config loads the configuration of the cmd binaries into one typed
+// tree. Values come from, in increasing precedence: defaults, a YAML file
+// (--config or CONFIG_FILE), the environment (including .env files) and
+// command-line flags. Every key has one environment variable and one flag;
+// see settings below.
 package config
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dykethecreator/GoApp/pkg/phone"
+	"github.com/joho/godotenv"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
-// Config holds the application configuration.
+// Config is the configuration shared by all services. Each binary validates
+// the parts it uses (see Service).
 type Config struct {
-	DBHost     string `mapstructure:"DB_HOST"`
-	DBPort     int    `mapstructure:"DB_PORT"`
-	DBUser     string `mapstructure:"DB_USER"`
-	DBPassword string `mapstructure:"DB_PASSWORD"`
-	DBName     string `mapstructure:"DB_NAME"`
+	Env      string         `mapstructure:"env"`
+	Database DatabaseConfig `mapstructure:"database"`
+	GRPC     GRPCConfig     `mapstructure:"grpc"`
+	JWT      JWTConfig      `mapstructure:"jwt"`
+	OTP      OTPConfig      `mapstructure:"otp"`
+	Mail     MailConfig     `mapstructure:"mail"`
+	Cache    CacheConfig    `mapstructure:"cache"`
+	Hub      HubConfig      `mapstructure:"hub"`
+	Push     PushConfig     `mapstructure:"push"`
+	Chat     ChatConfig     `mapstructure:"chat"`
+	Phone    PhoneConfig    `mapstructure:"phone"`
+	Contacts ContactsConfig `mapstructure:"contacts"`
+	Export   ExportConfig   `mapstructure:"export"`
+}
+
+type DatabaseConfig struct {
+	URL string `mapstructure:"url"`
+}
+
+// GRPCConfig holds the listen ports of the gRPC servers.
+type GRPCConfig struct {
+	AuthPort     int `mapstructure:"auth_port"`
+	ChatPort     int `mapstructure:"chat_port"`
+	RealtimePort int `mapstructure:"realtime_port"`
+	AllInOnePort int `mapstructure:"all_in_one_port"`
+}
+
+// JWTConfig configures signing (auth_service) and validation (other services)
+// of tokens. See jwt.NewTokenManagerFromConfig and keysync.NewVerifierFromConfig.
+type JWTConfig struct {
+	Secret              string        `mapstructure:"secret"` // legacy HS256 key
+	SigningKeyFile      string        `mapstructure:"signing_key_file"`
+	SigningKeyID        string        `mapstructure:"signing_key_id"`
+	VerifyKeysFile      string        `mapstructure:"verify_keys_file"`
+	PublicKeysFile      string        `mapstructure:"public_keys_file"`
+	AuthServiceAddr     string        `mapstructure:"auth_service_addr"`
+	JWKSRefreshInterval time.Duration `mapstructure:"jwks_refresh_interval"`
+	AccessTokenTTL      time.Duration `mapstructure:"access_token_ttl"`
+	RefreshTokenTTL     time.Duration `mapstructure:"refresh_token_ttl"`
+}
+
+type OTPConfig struct {
+	Provider               string        `mapstructure:"provider"` // "twilio" or "local"
+	DevMode                bool          `mapstructure:"dev_mode"` // shortcut for provider "local"
+	LocalTTL               time.Duration `mapstructure:"local_ttl"`
+	LocalSinkFile          string        `mapstructure:"local_sink_file"`
+	TwilioAccountSID       string        `mapstructure:"twilio_account_sid"`
+	TwilioAuthToken        string        `mapstructure:"twilio_auth_token"`
+	TwilioVerifyServiceSID string        `mapstructure:"twilio_verify_service_sid"`
+}
+
+// ProviderName returns the selected OTP provider, applying the default.
+func (c OTPConfig) ProviderName() string {
+	switch {
+	case c.Provider != "":
+		return c.Provider
+	case c.DevMode:
+		return "local"
+	default:
+		return "twilio"
+	}
+}
+
+type MailConfig struct {
+	Provider     string `mapstructure:"provider"` // "smtp", "local" or empty to disable email
+	SMTPAddr     string `mapstructure:"smtp_addr"`
+	SMTPUsername string `mapstructure:"smtp_username"`
+	SMTPPassword string `mapstructure:"smtp_password"`
+	From         string `mapstructure:"from"`
+}
+
+// CacheConfig holds the per-instance cache lifetimes.
+type CacheConfig struct {
+	RevocationTTL time.Duration `mapstructure:"revocation_ttl"`
+	BlockTTL      time.Duration `mapstructure:"block_ttl"`
+	PrivacyTTL    time.Duration `mapstructure:"privacy_ttl"`
+}
+
+// HubConfig sizes the realtime hub's queues.
+type HubConfig struct {
+	BroadcastBuffer int `mapstructure:"broadcast_buffer"` // presence updates waiting to be fanned out
+	ClientBuffer    int `mapstructure:"client_buffer"`    // events waiting for one client stream
+}
+
+// PushConfig selects the push providers offline users are notified through.
+// FCM is enabled by FCMProjectID, APNs by APNsKeyFile.
+type PushConfig struct {
+	Local              bool          `mapstructure:"local"` // log notifications instead of sending them
+	CollapseWindow     time.Duration `mapstructure:"collapse_window"`
+	FCMProjectID       string        `mapstructure:"fcm_project_id"`
+	FCMCredentialsFile string        `mapstructure:"fcm_credentials_file"`
+	APNsKeyFile        string        `mapstructure:"apns_key_file"`
+	APNsKeyID          string        `mapstructure:"apns_key_id"`
+	APNsTeamID         string        `mapstructure:"apns_team_id"`
+	APNsTopic          string        `mapstructure:"apns_topic"`
+	APNsSandbox        bool          `mapstructure:"apns_sandbox"`
+}
+
+// ChatConfig limits what participants may do with messages already sent.
+type ChatConfig struct {
+	EditWindow   time.Duration `mapstructure:"edit_window"`   // how long after sending a message may be edited
+	DeleteWindow time.Duration `mapstructure:"delete_window"` // how long after sending a message may be deleted for everyone
+}
+
+type PhoneConfig struct {
+	DefaultRegion string `mapstructure:"default_region"`
 }
 
-// LoadConfig loads the configuration from a file.
-func LoadConfig(path string) (config Config, err error) {
-	viper.AddConfigPath(path)
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
+type ContactsConfig struct {
+	HashSalt string `mapstructure:"hash_salt"`
+}
 
-	viper.AutomaticEnv()
+type ExportConfig struct {
+	BlobDir string `mapstructure:"blob_dir"`
+}
 
-	err = viper.ReadInConfig()
-	if err != nil {
+// setting is one configuration key with its environment variable.
+type setting struct {
+	key   string
+	env   string
+	def   any
+	usage string
+}
+
+var settings = []setting{
+	{"env", "APP_ENV", "", "deployment environment; loads .env.<env> (default local, or docker with RUNNING_IN_DOCKER)"},
+	{"database.url", "DATABASE_URL", "", "PostgreSQL connection URL"},
+	{"grpc.auth_port", "AUTH_SERVICE_GRPC_PORT", 50051, "auth_service gRPC port"},
+	{"grpc.chat_port", "CHAT_SERVICE_GRPC_PORT", 50052, "chat_service gRPC port"},
+	{"grpc.realtime_port", "REALTIME_SERVICE_GRPC_PORT", 50053, "realtime_service gRPC port"},
+	{"grpc.all_in_one_port", "ALL_IN_ONE_GRPC_PORT", 50050, "all_in_one gRPC port"},
+	{"jwt.secret", "JWT_SECRET", "", "legacy HS256 key (min 32 chars)"},
+	{"jwt.signing_key_file", "JWT_SIGNING_KEY_FILE", "", "PEM private key used to sign tokens"},
+	{"jwt.signing_key_id", "JWT_SIGNING_KEY_ID", "", "kid of the signing key (default: thumbprint)"},
+	{"jwt.verify_keys_file", "JWT_VERIFY_KEYS_FILE", "", "JWKS of retired public keys still accepted"},
+	{"jwt.public_keys_file", "JWT_PUBLIC_KEYS_FILE", "", "JWKS with auth_service's public keys"},
+	{"jwt.auth_service_addr", "AUTH_SERVICE_ADDR", "", "fetch public keys from auth_service at this address"},
+	{"jwt.jwks_refresh_interval", "JWKS_REFRESH_INTERVAL", 5 * time.Minute, "how often fetched public keys are refreshed"},
+	{"jwt.access_token_ttl", "JWT_ACCESS_TOKEN_TTL", 15 * time.Minute, "access token lifetime"},
+	{"jwt.refresh_token_ttl", "JWT_REFRESH_TOKEN_TTL", 7 * 24 * time.Hour, "refresh token lifetime"},
+	{"otp.provider", "OTP_PROVIDER", "", "OTP provider: twilio or local (default twilio)"},
+	{"otp.dev_mode", "AUTH_DEV_MODE", false, "shortcut for the local OTP provider"},
+	{"otp.local_ttl", "OTP_LOCAL_TTL", 5 * time.Minute, "lifetime of locally generated codes"},
+	{"otp.local_sink_file", "OTP_LOCAL_SINK_FILE", "", "file the local OTP provider appends codes to"},
+	{"otp.twilio_account_sid", "TWILIO_ACCOUNT_SID", "", "Twilio account SID"},
+	{"otp.twilio_auth_token", "TWILIO_AUTH_TOKEN", "", "Twilio auth token"},
+	{"otp.twilio_verify_service_sid", "TWILIO_VERIFY_SERVICE_SID", "", "Twilio Verify service SID"},
+	{"mail.provider", "MAIL_PROVIDER", "", "mail provider: smtp or local; empty disables email"},
+	{"mail.smtp_addr", "SMTP_ADDR", "", "SMTP server host:port"},
+	{"mail.smtp_username", "SMTP_USERNAME", "", "SMTP username"},
+	{"mail.smtp_password", "SMTP_PASSWORD", "", "SMTP password"},
+	{"mail.from", "MAIL_FROM", "", "sender address of emails"},
+	{"cache.revocation_ttl", "REVOCATION_CACHE_TTL", 30 * time.Second, "how long revocation lookups are cached"},
+	{"cache.block_ttl", "BLOCK_CACHE_TTL", 30 * time.Second, "how long block lookups are cached"},
+	{"cache.privacy_ttl", "PRIVACY_CACHE_TTL", 30 * time.Second, "how long privacy settings are cached"},
+	{"hub.broadcast_buffer", "HUB_BROADCAST_BUFFER", 256, "realtime hub presence update queue size"},
+	{"hub.client_buffer", "HUB_CLIENT_BUFFER", 256, "per-stream realtime event queue size"},
+	{"push.local", "PUSH_LOCAL", false, "log push notifications instead of sending them"},
+	{"push.collapse_window", "PUSH_COLLAPSE_WINDOW", 3 * time.Second, "messages within this window are sent as one push notification"},
+	{"push.fcm_project_id", "FCM_PROJECT_ID", "", "Firebase project ID; enables FCM"},
+	{"push.fcm_credentials_file", "FCM_CREDENTIALS_FILE", "", "Google service account JSON key for FCM"},
+	{"push.apns_key_file", "APNS_KEY_FILE", "", "APNs .p8 token signing key; enables APNs"},
+	{"push.apns_key_id", "APNS_KEY_ID", "", "key ID of the APNs signing key"},
+	{"push.apns_team_id", "APNS_TEAM_ID", "", "Apple developer team ID"},
+	{"push.apns_topic", "APNS_TOPIC", "", "iOS app bundle ID"},
+	{"push.apns_sandbox", "APNS_SANDBOX", false, "use the APNs development environment"},
+	{"chat.edit_window", "CHAT_EDIT_WINDOW", 15 * time.Minute, "how long after sending a message may be edited"},
+	{"chat.delete_window", "CHAT_DELETE_WINDOW", 48 * time.Hour, "how long after sending a message may be deleted for everyone"},
+	{"phone.default_region", "PHONE_DEFAULT_REGION", "", "region national phone numbers are read in, e.g. TR"},
+	{"contacts.hash_salt", "CONTACT_HASH_SALT", "", "salt for hashed contact uploads; empty disables them"},
+	{"export.blob_dir", "EXPORT_BLOB_DIR", "", "directory data exports are written to; empty disables them"},
+}
+
+// Service names a binary; it decides which settings are required.
+type Service string
+
+const (
+	AuthService     Service = "auth_service"
+	ChatService     Service = "chat_service"
+	RealtimeService Service = "realtime_service"
+	AllInOne        Service = "all_in_one"
+	APIGateway      Service = "api_gateway"
+	MessageWorker   Service = "message_worker"
+	StatusService   Service = "status_service"
+)
+
+// issuesTokens reports whether the service signs tokens and sends OTPs.
+func (s Service) issuesTokens() bool { return s == AuthService || s == AllInOne }
+
+// verifiesTokens reports whether the service only validates tokens.
+func (s Service) verifiesTokens() bool { return s == ChatService || s == RealtimeService }
+
+// needsDatabase reports whether the service cannot start without database.url;
+// realtime_service runs without it, skipping revocation and privacy checks.
+func (s Service) needsDatabase() bool { return s == AuthService || s == ChatService || s == AllInOne }
+
+// Load reads the configuration of service from defaults, the config file,
+// .env files, the environment and args (flags, without the program name), and
+// validates it.
+func Load(service Service, args []string) (*Config, error) {
+	v := viper.New()
+	fs := pflag.NewFlagSet(string(service), pflag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML config file")
+	for _, s := range settings {
+		v.SetDefault(s.key, s.def)
+		if err := v.BindEnv(s.key, s.env); err != nil {
+			return nil, err
+		}
+		fs.String(flagName(s.key), "", s.usage+" ("+s.env+")")
+		if err := v.BindPFlag(s.key, fs.Lookup(flagName(s.key))); err != nil {
+			return nil, err
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		return nil, err
+	}
+
+	if *configFile != "" {
+		v.SetConfigFile(*configFile)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("read config file: %w", err)
+		}
+	}
+	loadDotEnv(v.GetString("env"))
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if cfg.Env == "" {
+		cfg.Env = defaultEnv()
+	}
+	if err := cfg.Validate(service); err != nil {
+		return nil, err
+	}
+	cfg.warnDisabled(service)
+	return &cfg, nil
+}
+
+// warnDisabled logs the optional features of service left unconfigured.
+func (c *Config) warnDisabled(service Service) {
+	if !service.issuesTokens() {
 		return
 	}
+	if c.Phone.DefaultRegion == "" {
+		log.Printf("Warning: PHONE_DEFAULT_REGION not set, accepting international phone numbers only")
+	}
+	if c.Export.BlobDir == "" {
+		log.Printf("Warning: EXPORT_BLOB_DIR not set, data export is disabled")
+	}
+}
+
+// loadDotEnv loads .env.<env> and then .env; variables already set win.
+func loadDotEnv(env string) {
+	if env == "" {
+		env = defaultEnv()
+	}
+	_ = godotenv.Load(".env." + env)
+	_ = godotenv.Load()
+}
+
+func defaultEnv() string {
+	if os.Getenv("RUNNING_IN_DOCKER") != "" {
+		return "docker"
+	}
+	return "local"
+}
+
+func flagName(key string) string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(key)
+}
 
-	err = viper.Unmarshal(&config)
-	return
+// name returns how a key is written in errors: "jwt.secret (JWT_SECRET)".
+func name(key string) string {
+	for _, s := range settings {
+		if s.key == key {
+			return key + " (" + s.env + ")"
+		}
+	}
+	return key
+}
+
+// Validate checks the settings service needs and returns every problem found.
+func (c *Config) Validate(service Service) error {
+	var errs []error
+	required := func(key, value string) {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", name(key)))
+		}
+	}
+	positive := func(key string, d time.Duration) {
+		if d <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be a positive duration", name(key)))
+		}
+	}
+
+	for _, p := range []struct {
+		key  string
+		port int
+	}{
+		{"grpc.auth_port", c.GRPC.AuthPort}, {"grpc.chat_port", c.GRPC.ChatPort},
+		{"grpc.realtime_port", c.GRPC.RealtimePort}, {"grpc.all_in_one_port", c.GRPC.AllInOnePort},
+	} {
+		if p.port < 1 || p.port > 65535 {
+			errs = append(errs, fmt.Errorf("%s must be a port between 1 and 65535", name(p.key)))
+		}
+	}
+	positive("jwt.jwks_refresh_interval", c.JWT.JWKSRefreshInterval)
+	positive("jwt.access_token_ttl", c.JWT.AccessTokenTTL)
+	positive("jwt.refresh_token_ttl", c.JWT.RefreshTokenTTL)
+	if c.JWT.RefreshTokenTTL > 0 && c.JWT.RefreshTokenTTL < c.JWT.AccessTokenTTL {
+		errs = append(errs, fmt.Errorf("%s must not be shorter than %s", name("jwt.refresh_token_ttl"), name("jwt.access_token_ttl")))
+	}
+	positive("cache.revocation_ttl", c.Cache.RevocationTTL)
+	positive("cache.block_ttl", c.Cache.BlockTTL)
+	positive("cache.privacy_ttl", c.Cache.PrivacyTTL)
+	if c.Hub.BroadcastBuffer < 1 {
+		errs = append(errs, fmt.Errorf("%s must be at least 1", name("hub.broadcast_buffer")))
+	}
+	if c.Hub.ClientBuffer < 1 {
+		errs = append(errs, fmt.Errorf("%s must be at least 1", name("hub.client_buffer")))
+	}
+	positive("push.collapse_window", c.Push.CollapseWindow)
+	if c.Push.FCMProjectID != "" {
+		required("push.fcm_credentials_file", c.Push.FCMCredentialsFile)
+	}
+	if c.Push.APNsKeyFile != "" {
+		required("push.apns_key_id", c.Push.APNsKeyID)
+		required("push.apns_team_id", c.Push.APNsTeamID)
+		required("push.apns_topic", c.Push.APNsTopic)
+	}
+	positive("chat.edit_window", c.Chat.EditWindow)
+	positive("chat.delete_window", c.Chat.DeleteWindow)
+	if _, err := phone.NewParser(c.Phone.DefaultRegion); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", name("phone.default_region"), err))
+	}
+
+	if service.needsDatabase() {
+		required("database.url", c.Database.URL)
+	}
+	if service.issuesTokens() {
+		if c.JWT.SigningKeyFile == "" && c.JWT.Secret == "" {
+			errs = append(errs, fmt.Errorf("%s or %s is required", name("jwt.signing_key_file"), name("jwt.secret")))
+		}
+		switch c.OTP.ProviderName() {
+		case "twilio":
+			required("otp.twilio_account_sid", c.OTP.TwilioAccountSID)
+			required("otp.twilio_auth_token", c.OTP.TwilioAuthToken)
+			required("otp.twilio_verify_service_sid", c.OTP.TwilioVerifyServiceSID)
+		case "local":
+			positive("otp.local_ttl", c.OTP.LocalTTL)
+		default:
+			errs = append(errs, fmt.Errorf("%s must be twilio or local, got %q", name("otp.provider"), c.OTP.Provider))
+		}
+		switch c.Mail.Provider {
+		case "", "local":
+		case "smtp":
+			required("mail.smtp_addr", c.Mail.SMTPAddr)
+			required("mail.from", c.Mail.From)
+		default:
+			errs = append(errs, fmt.Errorf("%s must be smtp or local, got %q", name("mail.provider"), c.Mail.Provider))
+		}
+	} else if service.verifiesTokens() {
+		if c.JWT.PublicKeysFile == "" && c.JWT.AuthServiceAddr == "" && c.JWT.Secret == "" {
+			errs = append(errs, fmt.Errorf("%s, %s or %s is required", name("jwt.public_keys_file"), name("jwt.auth_service_addr"), name("jwt.secret")))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid %s configuration:\n%w", service, errors.Join(errs...))
+	}
+	return nil
 }
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad_Precedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := "database:\n  url: postgres://file\ngrpc:\n  chat_port: 6000\njwt:\n  secret: file-secret\ncache:\n  block_ttl: 1m\n"
+	if err := os.WriteFile(file, []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DATABASE_URL", "postgres://env")
+	t.Setenv("BLOCK_CACHE_TTL", "2m")
+
+	cfg, err := Load(ChatService, []string{"--config", file, "--cache-block-ttl", "3m", "--hub-client-buffer=8"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Database.URL != "postgres://env" {
+		t.Errorf("database.url = %q, want the environment over the file", cfg.Database.URL)
+	}
+	if cfg.Cache.BlockTTL != 3*time.Minute {
+		t.Errorf("cache.block_ttl = %s, want the flag over the environment", cfg.Cache.BlockTTL)
+	}
+	if cfg.GRPC.ChatPort != 6000 || cfg.JWT.Secret != "file-secret" {
+		t.Errorf("file values not loaded: port %d, secret %q", cfg.GRPC.ChatPort, cfg.JWT.Secret)
+	}
+	if cfg.Hub.ClientBuffer != 8 || cfg.Hub.BroadcastBuffer != 256 || cfg.JWT.AccessTokenTTL != 15*time.Minute {
+		t.Errorf("hub %+v, access ttl %s; want flag and defaults", cfg.Hub, cfg.JWT.AccessTokenTTL)
+	}
+}
+
+func TestLoad_ReportsEveryProblem(t *testing.T) {
+	t.Setenv("OTP_PROVIDER", "twilio")
+	t.Setenv("HUB_BROADCAST_BUFFER", "0")
+
+	_, err := Load(AuthService, []string{"--phone-default-region", "XX", "--jwt-access-token-ttl=-1s"})
+	if err == nil {
+		t.Fatal("Load succeeded with an invalid configuration")
+	}
+	for _, want := range []string{
+		"database.url (DATABASE_URL) is required",
+		"jwt.signing_key_file (JWT_SIGNING_KEY_FILE) or jwt.secret (JWT_SECRET) is required",
+		"otp.twilio_account_sid (TWILIO_ACCOUNT_SID) is required",
+		"jwt.access_token_ttl (JWT_ACCESS_TOKEN_TTL) must be a positive duration",
+		"hub.broadcast_buffer (HUB_BROADCAST_BUFFER) must be at least 1",
+		"phone.default_region (PHONE_DEFAULT_REGION)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %q:\n%v", want, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthAttempt tracks attempts against a rate-limited auth action,
+// keyed by action and subject (e.g. "otp_send:phone:+90555...").
+type AuthAttempt struct {
+	Key         string     `json:"key" db:"key"`
+	Count       int        `json:"count" db:"count"`
+	WindowStart time.Time  `json:"window_start" db:"window_start"`
+	Lockouts    int        `json:"lockouts" db:"lockouts"`
+	LockedUntil *time.Time `json:"locked_until,omitempty" db:"locked_until"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// SecurityEventType defines the kind of security event recorded for a user.
+type SecurityEventType string
+
+const (
+	// RefreshTokenReuseEvent is recorded when an already-rotated refresh token is presented again.
+	RefreshTokenReuseEvent SecurityEventType = "refresh_token_reuse"
+	// PINResetEvent is recorded when a two-step verification PIN is reset at login.
+	PINResetEvent SecurityEventType = "pin_reset"
+	// PhoneNumberChangedEvent is recorded when a user moves the account to a new number.
+	PhoneNumberChangedEvent SecurityEventType = "phone_number_changed"
+	// DeviceLinkedEvent is recorded when a logged-in device approves a companion device.
+	DeviceLinkedEvent SecurityEventType = "device_linked"
+)
+
+// SecurityEvent is an audit record of a security-relevant auth event.
+type SecurityEvent struct {
+	ID        uuid.UUID         `json:"id" db:"id"`
+	UserID    uuid.UUID         `json:"user_id" db:"user_id"`
+	EventType SecurityEventType `json:"event_type" db:"event_type"`
+	FamilyID  *uuid.UUID        `json:"family_id,omitempty" db:"family_id"`
+	Details   string            `json:"details" db:"details"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+}
+
+// TwoStepPIN is a user's two-step verification PIN. Once set, logging in on a
+// new device needs the PIN in addition to the SMS code.
+type TwoStepPIN struct {
+	UserID             uuid.UUID  `json:"user_id" db:"user_id"`
+	PINHash            string     `json:"-" db:"pin_hash"`
+	RecoveryEmail      *string    `json:"recovery_email,omitempty" db:"recovery_email"`
+	ResetRequestedAt   *time.Time `json:"reset_requested_at,omitempty" db:"reset_requested_at"` // start of the reset cooldown
+	ResetCodeHash      *string    `json:"-" db:"reset_code_hash"`
+	ResetCodeExpiresAt *time.Time `json:"-" db:"reset_code_expires_at"`
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// PINChallenge is a login that passed the SMS code and waits for the PIN.
+// Only a hash of the challenge token is stored.
+type PINChallenge struct {
+	TokenHash string    `json:"-" db:"token_hash"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	DeviceID  string    `json:"device_id" db:"device_id"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// DeviceLink is a companion device waiting to be linked to an account. The
+// pairing token is shown as a QR code and approved by a logged-in device; the
+// device secret stays on the new device, which uses it to collect its tokens.
+// Only hashes of both are stored.
+type DeviceLink struct {
+	TokenHash  string     `json:"-" db:"token_hash"`
+	SecretHash string     `json:"-" db:"secret_hash"`
+	DeviceName string     `json:"device_name" db:"device_name"`
+	DeviceType string     `json:"device_type" db:"device_type"`
+	UserID     *uuid.UUID `json:"user_id,omitempty" db:"user_id"` // set on approval
+	ApprovedAt *time.Time `json:"approved_at,omitempty" db:"approved_at"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
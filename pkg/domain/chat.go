@@ -44,6 +44,20 @@ const (
 	KickedMembership MembershipStatus = "kicked"
 )
 
+// MembershipAction is a change to the membership of a group conversation, as
+// recorded in its membership history.
+type MembershipAction string
+
+const (
+	MemberCreated  MembershipAction = "created" // created the group
+	MemberAdded    MembershipAction = "added"
+	MemberJoined   MembershipAction = "joined" // through an invite link
+	MemberRemoved  MembershipAction = "removed"
+	MemberLeft     MembershipAction = "left"
+	MemberPromoted MembershipAction = "promoted"
+	MemberDemoted  MembershipAction = "demoted"
+)
+
 // ChatMember represents a user's membership in a chat.
 type ChatMember struct {
 	ChatID           uuid.UUID        `json:"chat_id" db:"chat_id"`
@@ -58,21 +72,89 @@ type ChatMember struct {
 
 // Conversation is a minimal alias to Chat for simple messaging threads.
 type Conversation struct {
-	ID             uuid.UUID   `json:"id" db:"id"`
-	ParticipantIDs []uuid.UUID `json:"participant_ids"`
-	IsGroup        bool        `json:"is_group" db:"is_group"`
-	GroupName      *string     `json:"group_name,omitempty" db:"group_name"`
-	CreatedAt      time.Time   `json:"created_at" db:"created_at"`
+	ID                 uuid.UUID   `json:"id" db:"id"`
+	ParticipantIDs     []uuid.UUID `json:"participant_ids"`
+	AdminIDs           []uuid.UUID `json:"admin_ids,omitempty"` // of groups
+	IsGroup            bool        `json:"is_group" db:"is_group"`
+	GroupName          *string     `json:"group_name,omitempty" db:"group_name"`
+	GroupDescription   *string     `json:"group_description,omitempty" db:"group_description"`
+	GroupIconURL       *string     `json:"group_icon_url,omitempty" db:"group_icon_url"`
+	OnlyAdminsEditInfo bool        `json:"only_admins_edit_info" db:"only_admins_edit_info"` // else any member may edit the group info
+	AnnouncementMode   bool        `json:"announcement_mode" db:"announcement_mode"`         // only admins may send
+	CreatedAt          time.Time   `json:"created_at" db:"created_at"`
+	MutedUntil         *time.Time  `json:"muted_until,omitempty" db:"muted_until"` // of the user the conversation was listed for
 }
 
+// GroupInvite is a link that lets users join a group.
+type GroupInvite struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	ConversationID   uuid.UUID  `json:"conversation_id" db:"conversation_id"`
+	Token            string     `json:"token" db:"token"`
+	CreatedBy        *uuid.UUID `json:"created_by,omitempty" db:"created_by"` // nil once the admin's account is deleted
+	ExpiresAt        *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	MaxUses          int        `json:"max_uses" db:"max_uses"` // 0 is unlimited
+	UseCount         int        `json:"use_count" db:"use_count"`
+	RequiresApproval bool       `json:"requires_approval" db:"requires_approval"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Usable reports whether the invite can still be used at t.
+func (i *GroupInvite) Usable(t time.Time) bool {
+	return i.RevokedAt == nil && (i.ExpiresAt == nil || t.Before(*i.ExpiresAt)) && (i.MaxUses == 0 || i.UseCount < i.MaxUses)
+}
+
+// JoinRequest is a pending request to join a group through an invite that
+// requires approval.
+type JoinRequest struct {
+	ConversationID uuid.UUID  `json:"conversation_id" db:"conversation_id"`
+	UserID         uuid.UUID  `json:"user_id" db:"user_id"`
+	InviteID       *uuid.UUID `json:"invite_id,omitempty" db:"invite_id"` // nil once the invite is gone
+	RequestedAt    time.Time  `json:"requested_at" db:"requested_at"`
+}
+
+// MutedForever is the muted_until of conversations muted without an end.
+var MutedForever = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
 // Message represents a chat message stored in messages table.
 type ChatMessage struct {
-	ID              uuid.UUID `json:"id" db:"id"`
-	ConversationID  uuid.UUID `json:"conversation_id" db:"conversation_id"`
-	SenderID        uuid.UUID `json:"sender_id" db:"sender_id"`
-	Content         string    `json:"content" db:"content"`
-	MediaURL        *string   `json:"media_url,omitempty" db:"media_url"`
-	MediaType       *string   `json:"media_type,omitempty" db:"media_type"`
-	ClientMessageID *string   `json:"client_message_id,omitempty" db:"client_message_id"`
-	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	ID               uuid.UUID        `json:"id" db:"id"`
+	ConversationID   uuid.UUID        `json:"conversation_id" db:"conversation_id"`
+	SenderID         uuid.UUID        `json:"sender_id" db:"sender_id"`
+	Content          string           `json:"content" db:"content"`
+	MediaURL         *string          `json:"media_url,omitempty" db:"media_url"`
+	MediaType        *string          `json:"media_type,omitempty" db:"media_type"`
+	ClientMessageID  *string          `json:"client_message_id,omitempty" db:"client_message_id"`
+	IsSystem         bool             `json:"is_system" db:"is_system"` // SenderID is the user the system message is about
+	CreatedAt        time.Time        `json:"created_at" db:"created_at"`
+	EditedAt         *time.Time       `json:"edited_at,omitempty" db:"edited_at"`
+	DeletedAt        *time.Time       `json:"deleted_at,omitempty" db:"deleted_at"` // deleted for everyone; content and media are cleared
+	DeletedBy        *uuid.UUID       `json:"deleted_by,omitempty" db:"deleted_by"`
+	ReplyToMessageID *uuid.UUID       `json:"reply_to_message_id,omitempty" db:"reply_to_message_id"`
+	ReplyTo          *QuotedMessage   `json:"reply_to,omitempty" db:"-"` // preview of ReplyToMessageID
+	Reactions        []*ReactionCount `json:"reactions,omitempty" db:"-"`
+}
+
+// QuotedMessage previews the message a reply quotes. Snippet and MediaType
+// are empty once the quoted message was deleted.
+type QuotedMessage struct {
+	MessageID uuid.UUID  `json:"message_id"`
+	SenderID  *uuid.UUID `json:"sender_id,omitempty"` // nil for deleted accounts
+	Snippet   string     `json:"snippet"`
+	MediaType string     `json:"media_type,omitempty"`
+	Deleted   bool       `json:"deleted"`
+}
+
+// ReactionCount aggregates the reactions to a message with one emoji.
+type ReactionCount struct {
+	Emoji   string      `json:"emoji"`
+	Count   int         `json:"count"`
+	UserIDs []uuid.UUID `json:"user_ids"`
+}
+
+// MessageEdit is a version of a message that an edit replaced.
+type MessageEdit struct {
+	MessageID  uuid.UUID `json:"message_id" db:"message_id"`
+	Content    string    `json:"content" db:"content"`
+	ReplacedAt time.Time `json:"replaced_at" db:"replaced_at"`
 }
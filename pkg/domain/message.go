@@ -54,9 +54,10 @@ type MessageStatus struct {
 	Timestamp time.Time         `json:"timestamp" db:"timestamp"`
 }
 
-// MessageReaction represents a reaction to a message.
+// MessageReaction is a user's reaction to a ChatMessage; a user has at most
+// one per message.
 type MessageReaction struct {
-	MessageID     int64     `json:"message_id" db:"message_id"`
+	MessageID     uuid.UUID `json:"message_id" db:"message_id"`
 	UserID        uuid.UUID `json:"user_id" db:"user_id"`
 	ReactionEmoji string    `json:"reaction_emoji" db:"reaction_emoji"`
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
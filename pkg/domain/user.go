@@ -22,18 +22,86 @@ type User struct {
 type UserDevice struct {
 	ID                    uuid.UUID  `json:"id" db:"id"`
 	UserID                uuid.UUID  `json:"user_id" db:"user_id"`
+	FamilyID              uuid.UUID  `json:"family_id" db:"family_id"` // shared by all rotations of one device session
 	RefreshTokenHash      string     `json:"-" db:"refresh_token_hash"`
 	DeviceName            string     `json:"device_name" db:"device_name"`
 	DeviceType            string     `json:"device_type" db:"device_type"`
 	PushNotificationToken string     `json:"push_notification_token" db:"push_notification_token"`
+	PushProvider          string     `json:"push_provider" db:"push_provider"` // PushProviderFCM or PushProviderAPNs
 	LastLoginAt           time.Time  `json:"last_login_at" db:"last_login_at"`
 	CreatedAt             time.Time  `json:"created_at" db:"created_at"`
 	RevokedAt             *time.Time `json:"revoked_at" db:"revoked_at"`
 }
 
+// Push providers a device can register its push token with.
+const (
+	PushProviderFCM  = "fcm"
+	PushProviderAPNs = "apns"
+)
+
+// PushToken is the push token of one active device session.
+type PushToken struct {
+	UserID   uuid.UUID `json:"user_id" db:"user_id"`
+	FamilyID uuid.UUID `json:"family_id" db:"family_id"`
+	Provider string    `json:"provider" db:"push_provider"`
+	Token    string    `json:"token" db:"push_notification_token"`
+}
+
 // BlockedUser represents a blocked user relationship.
 type BlockedUser struct {
 	BlockerUserID uuid.UUID `json:"blocker_user_id" db:"blocker_user_id"`
 	BlockedUserID uuid.UUID `json:"blocked_user_id" db:"blocked_user_id"`
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
 }
+
+// PrivacyVisibility is who may see a piece of a user's profile or presence.
+type PrivacyVisibility string
+
+const (
+	PrivacyEveryone PrivacyVisibility = "everyone"
+	PrivacyContacts PrivacyVisibility = "contacts" // users in the owner's address book
+	PrivacyNobody   PrivacyVisibility = "nobody"
+)
+
+// Valid reports whether v is one of the known visibilities.
+func (v PrivacyVisibility) Valid() bool {
+	return v == PrivacyEveryone || v == PrivacyContacts || v == PrivacyNobody
+}
+
+// PrivacySettings are a user's privacy choices. Users without a stored row
+// get DefaultPrivacySettings.
+type PrivacySettings struct {
+	UserID       uuid.UUID         `json:"user_id" db:"user_id"`
+	LastSeen     PrivacyVisibility `json:"last_seen" db:"last_seen"`
+	Online       PrivacyVisibility `json:"online" db:"online"`
+	ProfilePhoto PrivacyVisibility `json:"profile_photo" db:"profile_photo"`
+	About        PrivacyVisibility `json:"about" db:"about"`
+	UpdatedAt    time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultPrivacySettings returns the settings of a user who never changed them.
+func DefaultPrivacySettings(userID uuid.UUID) *PrivacySettings {
+	return &PrivacySettings{
+		UserID:       userID,
+		LastSeen:     PrivacyEveryone,
+		Online:       PrivacyEveryone,
+		ProfilePhoto: PrivacyEveryone,
+		About:        PrivacyEveryone,
+	}
+}
+
+// AccountExport is the personal data of a user, as returned by a data export.
+// Messages are the ones the user sent, MessageEdits their earlier versions;
+// Reactions are the user's reactions to any message.
+type AccountExport struct {
+	ExportedAt      time.Time          `json:"exported_at"`
+	User            *User              `json:"user"`
+	PrivacySettings *PrivacySettings   `json:"privacy_settings,omitempty"`
+	Contacts        []*Contact         `json:"contacts"`
+	Blocked         []*BlockedUser     `json:"blocked_users"`
+	Conversations   []*Conversation    `json:"conversations"`
+	Messages        []*ChatMessage     `json:"messages"`
+	MessageEdits    []*MessageEdit     `json:"message_edits"`
+	Reactions       []*MessageReaction `json:"reactions"`
+	Devices         []*UserDevice      `json:"devices"`
+}
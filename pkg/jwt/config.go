@@ -0,0 +1,109 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/dykethecreator/GoApp/pkg/config"
+)
+
+// Default token lifetimes, used when the config leaves them unset.
+const (
+	DefaultAccessTokenDuration  = 15 * time.Minute
+	DefaultRefreshTokenDuration = 7 * 24 * time.Hour
+)
+
+// NewTokenManagerFromConfig builds the token issuer used by the auth service.
+//
+//   - SigningKeyFile: PEM private key (RSA or Ed25519) used to sign tokens.
+//     SigningKeyID sets its 'kid' (default: key thumbprint).
+//   - VerifyKeysFile: optional JWKS with retired public keys that are still
+//     accepted; give each an "exp" to end its grace period.
+//   - Secret: legacy HS256 secret. Used for signing when no key file is set;
+//     otherwise tokens signed with it are accepted for one refresh token lifetime.
+func NewTokenManagerFromConfig(cfg config.JWTConfig) (*TokenManager, error) {
+	accessTTL, refreshTTL := tokenTTLs(cfg)
+	secret := cfg.Secret
+	keyFile := cfg.SigningKeyFile
+	if keyFile == "" {
+		if secret == "" {
+			return nil, errors.New("jwt.signing_key_file or jwt.secret must be set")
+		}
+		log.Printf("Warning: signing tokens with the shared JWT_SECRET (HS256); set JWT_SIGNING_KEY_FILE to use an asymmetric key")
+		return NewTokenManager(secret, accessTTL, refreshTTL)
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwt.signing_key_file: %w", err)
+	}
+	signing, err := ParsePrivateKeyPEM(cfg.SigningKeyID, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt.signing_key_file: %w", err)
+	}
+
+	var verifyKeys []*Key
+	if path := cfg.VerifyKeysFile; path != "" {
+		if verifyKeys, err = loadJWKSFile(path); err != nil {
+			return nil, fmt.Errorf("invalid jwt.verify_keys_file: %w", err)
+		}
+	}
+	if secret != "" {
+		legacy, err := NewHMACKey("", []byte(secret))
+		if err != nil {
+			return nil, err
+		}
+		legacy.ExpiresAt = time.Now().Add(refreshTTL)
+		verifyKeys = append(verifyKeys, legacy)
+	}
+
+	log.Printf("Signing tokens with %s key %q", signing.Algorithm, signing.ID)
+	return NewTokenManagerWithKey(signing, accessTTL, refreshTTL, verifyKeys...)
+}
+
+// NewVerifierFromConfig builds a verify-only TokenManager for services that
+// accept but never issue tokens.
+//
+//   - PublicKeysFile: JWKS with the auth service's public keys.
+//   - Secret: legacy HS256 secret, used only when no public keys are configured.
+func NewVerifierFromConfig(cfg config.JWTConfig) (*TokenManager, error) {
+	if path := cfg.PublicKeysFile; path != "" {
+		keys, err := loadJWKSFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwt.public_keys_file: %w", err)
+		}
+		return NewVerifier(keys...), nil
+	}
+	if secret := cfg.Secret; secret != "" {
+		log.Printf("Warning: validating tokens with the shared JWT_SECRET (HS256); set JWT_PUBLIC_KEYS_FILE so this service holds public keys only")
+		key, err := NewHMACKey("", []byte(secret))
+		if err != nil {
+			return nil, err
+		}
+		return NewVerifier(key), nil
+	}
+	return nil, errors.New("jwt.public_keys_file or jwt.secret must be set")
+}
+
+// tokenTTLs returns the configured token lifetimes, or the defaults.
+func tokenTTLs(cfg config.JWTConfig) (access, refresh time.Duration) {
+	access, refresh = cfg.AccessTokenTTL, cfg.RefreshTokenTTL
+	if access <= 0 {
+		access = DefaultAccessTokenDuration
+	}
+	if refresh <= 0 {
+		refresh = DefaultRefreshTokenDuration
+	}
+	return access, refresh
+}
+
+func loadJWKSFile(path string) ([]*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWKS(data)
+}
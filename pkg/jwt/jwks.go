@@ -0,0 +1,133 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// JWK is a public key in JSON Web Key format (RFC 7517). Only RSA and
+// Ed25519 (OKP) keys are supported.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use,omitempty"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// OKP (Ed25519)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	// Exp is the end of the key's grace period (Unix seconds), set on retired keys.
+	Exp int64 `json:"exp,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys services need to validate tokens from this
+// manager: the signing key and any retired key still in its grace period.
+// HMAC keys are never published.
+func (tm *TokenManager) JWKS() JWKS {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	now := tm.now()
+	set := JWKS{Keys: []JWK{}}
+	for _, k := range tm.keys {
+		if !k.IsPublic() || k.expired(now) {
+			continue
+		}
+		jwk, err := k.JWK()
+		if err != nil {
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
+
+// JWK returns the public half of the key in JWK format.
+func (k *Key) JWK() (JWK, error) {
+	jwk := JWK{Kid: k.ID, Alg: string(k.Algorithm), Use: "sig"}
+	if !k.ExpiresAt.IsZero() {
+		jwk.Exp = k.ExpiresAt.Unix()
+	}
+	switch pub := k.verifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	default:
+		return JWK{}, fmt.Errorf("key %q cannot be published", k.ID)
+	}
+	return jwk, nil
+}
+
+// ParseJWKS decodes a JWKS document into verification-only keys.
+func ParseJWKS(data []byte) ([]*Key, error) {
+	var set JWKS
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+	keys := make([]*Key, 0, len(set.Keys))
+	for _, jwk := range set.Keys {
+		k, err := jwk.Key()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// Key converts the JWK into a verification-only Key.
+func (j JWK) Key() (*Key, error) {
+	var (
+		k   *Key
+		err error
+	)
+	switch j.Kty {
+	case "RSA":
+		n, nErr := base64.RawURLEncoding.DecodeString(j.N)
+		e, eErr := base64.RawURLEncoding.DecodeString(j.E)
+		if nErr != nil || eErr != nil || len(n) == 0 || len(e) == 0 {
+			return nil, fmt.Errorf("invalid RSA key %q", j.Kid)
+		}
+		k, err = NewPublicKey(j.Kid, &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		})
+	case "OKP":
+		x, xErr := base64.RawURLEncoding.DecodeString(j.X)
+		if j.Crv != "Ed25519" || xErr != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key %q", j.Kid)
+		}
+		k, err = NewPublicKey(j.Kid, ed25519.PublicKey(x))
+	default:
+		return nil, fmt.Errorf("unsupported key type %q for key %q", j.Kty, j.Kid)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if j.Alg != "" && j.Alg != string(k.Algorithm) {
+		return nil, fmt.Errorf("key %q: alg %q does not match key type %s", j.Kid, j.Alg, j.Kty)
+	}
+	if j.Exp != 0 {
+		k.ExpiresAt = time.Unix(j.Exp, 0)
+	}
+	return k, nil
+}
@@ -0,0 +1,161 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Algorithm is a JWS signing algorithm supported by the TokenManager.
+type Algorithm string
+
+const (
+	AlgorithmHS256 Algorithm = "HS256" // shared secret (legacy)
+	AlgorithmRS256 Algorithm = "RS256"
+	AlgorithmEdDSA Algorithm = "EdDSA" // Ed25519
+)
+
+// Key is a signing or verification key identified by its 'kid'.
+// A Key built from a private key can sign; one built from a public key can only verify.
+type Key struct {
+	ID        string
+	Algorithm Algorithm
+	// ExpiresAt, when set, is the end of the key's grace period: tokens signed
+	// with it are rejected afterwards. Set on keys retired by RotateKey.
+	ExpiresAt time.Time
+
+	signKey   any // []byte, *rsa.PrivateKey or ed25519.PrivateKey
+	verifyKey any // []byte, *rsa.PublicKey or ed25519.PublicKey
+}
+
+// NewHMACKey creates an HS256 key. An empty id matches tokens without a 'kid' header,
+// which is how tokens issued before key rotation existed were signed.
+func NewHMACKey(id string, secret []byte) (*Key, error) {
+	// HS256 (SHA-256) expects a 256-bit (32 byte) key.
+	if len(secret) < 32 {
+		return nil, errors.New("JWT secret key must be at least 32 bytes for security")
+	}
+	return &Key{ID: id, Algorithm: AlgorithmHS256, signKey: secret, verifyKey: secret}, nil
+}
+
+// NewPrivateKey creates a signing key from an *rsa.PrivateKey (RS256) or an
+// ed25519.PrivateKey (EdDSA). If id is empty, the key's thumbprint is used.
+func NewPrivateKey(id string, priv crypto.PrivateKey) (*Key, error) {
+	var k *Key
+	switch p := priv.(type) {
+	case *rsa.PrivateKey:
+		if p.N.BitLen() < 2048 {
+			return nil, errors.New("RSA signing keys must be at least 2048 bits")
+		}
+		k = &Key{Algorithm: AlgorithmRS256, signKey: p, verifyKey: &p.PublicKey}
+	case ed25519.PrivateKey:
+		k = &Key{Algorithm: AlgorithmEdDSA, signKey: p, verifyKey: p.Public()}
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
+	}
+	k.ID = id
+	if k.ID == "" {
+		k.ID = thumbprint(k.verifyKey)
+	}
+	return k, nil
+}
+
+// NewPublicKey creates a verification-only key from an *rsa.PublicKey or an ed25519.PublicKey.
+func NewPublicKey(id string, pub crypto.PublicKey) (*Key, error) {
+	var k *Key
+	switch p := pub.(type) {
+	case *rsa.PublicKey:
+		k = &Key{Algorithm: AlgorithmRS256, verifyKey: p}
+	case ed25519.PublicKey:
+		k = &Key{Algorithm: AlgorithmEdDSA, verifyKey: p}
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+	k.ID = id
+	if k.ID == "" {
+		k.ID = thumbprint(k.verifyKey)
+	}
+	return k, nil
+}
+
+// GenerateKey creates a new random RS256 or EdDSA signing key.
+func GenerateKey(alg Algorithm, id string) (*Key, error) {
+	switch alg {
+	case AlgorithmRS256:
+		priv, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		return NewPrivateKey(id, priv)
+	case AlgorithmEdDSA:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return NewPrivateKey(id, priv)
+	default:
+		return nil, fmt.Errorf("cannot generate key for algorithm %q", alg)
+	}
+}
+
+// ParsePrivateKeyPEM parses a PKCS#8 (RSA or Ed25519) or PKCS#1 (RSA) private key.
+func ParsePrivateKeyPEM(id string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found in private key")
+	}
+	if priv, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return NewPrivateKey(id, priv)
+	}
+	if priv, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return NewPrivateKey(id, priv)
+	}
+	return nil, errors.New("unsupported private key format (expected PKCS#8 or PKCS#1)")
+}
+
+// CanSign reports whether the key holds private material.
+func (k *Key) CanSign() bool { return k.signKey != nil }
+
+// IsPublic reports whether the key can be published (RS256/EdDSA); HMAC keys never are.
+func (k *Key) IsPublic() bool { return k.Algorithm != AlgorithmHS256 }
+
+// expired reports whether the key's grace period has ended.
+func (k *Key) expired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && now.After(k.ExpiresAt)
+}
+
+func (k *Key) signingMethod() jwt.SigningMethod {
+	switch k.Algorithm {
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256
+	case AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return jwt.SigningMethodHS256
+	}
+}
+
+// publicOnly returns a copy of the key without private material.
+func (k *Key) publicOnly() *Key {
+	return &Key{ID: k.ID, Algorithm: k.Algorithm, ExpiresAt: k.ExpiresAt, verifyKey: k.verifyKey}
+}
+
+// thumbprint derives a short, stable key ID from the public key.
+func thumbprint(pub any) string {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(der)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
@@ -3,6 +3,8 @@ package jwt
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,11 +14,20 @@ import (
 // ErrInvalidToken is a standard error for token validation failures.
 var ErrInvalidToken = errors.New("invalid or expired token")
 
+// ErrCannotSign is returned by verify-only TokenManagers (see NewVerifier).
+var ErrCannotSign = errors.New("token manager has no signing key")
+
 // TokenManager manages JWT creation and validation operations.
+// Tokens are signed with the current signing key and carry its 'kid' header;
+// validation accepts any known key that has not passed its grace period.
 type TokenManager struct {
-	secretKey            []byte
+	mu         sync.RWMutex
+	signingKey *Key            // nil for verify-only managers
+	keys       map[string]*Key // kid -> key, includes signingKey
+
 	accessTokenDuration  time.Duration
 	refreshTokenDuration time.Duration
+	now                  func() time.Time
 }
 
 // --- NEW: Token Type Constants ---
@@ -32,44 +43,162 @@ const (
 // UserID is now carried within the 'sub' (Subject) standard.
 type CustomClaims struct {
 	Type TokenType `json:"type"` // 'access' or 'refresh'
+	// SessionID identifies the device session (refresh token family) the token belongs to.
+	SessionID string `json:"sid,omitempty"`
+	// Scope is a space-separated list of extra permissions, e.g. "admin".
+	// Tokens issued at login carry none.
+	Scope string `json:"scope,omitempty"`
+	// IssuedAtMicro is 'iat' in Unix microseconds. Revocation watermarks are
+	// compared with it: with whole seconds a token issued just after "log out
+	// all devices", in the same second, would count as issued before it.
+	IssuedAtMicro int64 `json:"iat_us,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IssuedAtTime returns when the token was issued, to the microsecond if it
+// carries 'iat_us'. It is the zero time when the token has no 'iat'.
+func (c *CustomClaims) IssuedAtTime() time.Time {
+	if c.IssuedAtMicro != 0 {
+		return time.UnixMicro(c.IssuedAtMicro)
+	}
+	if c.IssuedAt == nil {
+		return time.Time{}
+	}
+	return c.IssuedAt.Time
+}
+
+// HasScope reports whether the token was granted scope.
+func (c *CustomClaims) HasScope(scope string) bool {
+	for _, s := range strings.Fields(c.Scope) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
 // --- UPDATED: NewTokenManager ---
 // Creates a new TokenManager instance.
+// Creates a new TokenManager instance that signs with a shared HS256 secret.
+// Every service holding the secret can mint tokens; prefer NewTokenManagerWithKey.
 func NewTokenManager(secret string, accessDuration, refreshDuration time.Duration) (*TokenManager, error) {
-	// HS256 (SHA-256) expects a 256-bit (32 byte) key.
-	// Enforcing minimum length for security.
-	if len(secret) < 32 {
-		return nil, errors.New("JWT secret key must be at least 32 bytes for security")
+	key, err := NewHMACKey("", []byte(secret))
+	if err != nil {
+		return nil, err
 	}
+	return NewTokenManagerWithKey(key, accessDuration, refreshDuration)
+}
 
+// NewTokenManagerWithKey creates a TokenManager that signs with the given key.
+// verifyKeys are extra keys (e.g. retired ones still in their grace period)
+// accepted when validating tokens.
+func NewTokenManagerWithKey(signing *Key, accessDuration, refreshDuration time.Duration, verifyKeys ...*Key) (*TokenManager, error) {
+	if signing == nil || !signing.CanSign() {
+		return nil, errors.New("signing key must hold private key material")
+	}
 	if accessDuration <= 0 || refreshDuration <= 0 {
 		return nil, errors.New("token durations must be positive values")
 	}
 
-	return &TokenManager{
-		secretKey:            []byte(secret),
+	tm := &TokenManager{
+		signingKey:           signing,
+		keys:                 make(map[string]*Key),
 		accessTokenDuration:  accessDuration,
 		refreshTokenDuration: refreshDuration,
-	}, nil
+		now:                  time.Now,
+	}
+	for _, k := range verifyKeys {
+		tm.keys[k.ID] = k
+	}
+	tm.keys[signing.ID] = signing
+	return tm, nil
+}
+
+// NewVerifier creates a verify-only TokenManager, used by services that
+// accept tokens but never issue them. Generating tokens returns ErrCannotSign.
+func NewVerifier(keys ...*Key) *TokenManager {
+	tm := &TokenManager{keys: make(map[string]*Key), now: time.Now}
+	tm.SetVerificationKeys(keys...)
+	return tm
+}
+
+// RotateKey makes newKey the signing key. The previous signing key stays valid
+// for verification until grace has passed; grace should be at least the
+// refresh token lifetime so existing sessions survive the rotation.
+func (tm *TokenManager) RotateKey(newKey *Key, grace time.Duration) error {
+	if newKey == nil || !newKey.CanSign() {
+		return errors.New("signing key must hold private key material")
+	}
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if old := tm.signingKey; old != nil && old.ID != newKey.ID {
+		old.ExpiresAt = tm.now().Add(grace)
+	}
+	tm.signingKey = newKey
+	tm.keys[newKey.ID] = newKey
+	tm.pruneLocked()
+	return nil
+}
+
+// SetVerificationKeys replaces the set of keys accepted when validating tokens.
+// The signing key, if any, is always kept.
+func (tm *TokenManager) SetVerificationKeys(keys ...*Key) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tm.keys = make(map[string]*Key, len(keys)+1)
+	for _, k := range keys {
+		tm.keys[k.ID] = k
+	}
+	if tm.signingKey != nil {
+		tm.keys[tm.signingKey.ID] = tm.signingKey
+	}
+}
+
+// pruneLocked drops keys whose grace period has ended. Caller holds tm.mu.
+func (tm *TokenManager) pruneLocked() {
+	now := tm.now()
+	for id, k := range tm.keys {
+		if k != tm.signingKey && k.expired(now) {
+			delete(tm.keys, id)
+		}
+	}
 }
 
 // generateToken signs a new token with the given claims.
 func (tm *TokenManager) generateToken(claims CustomClaims) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(tm.secretKey)
+	tm.mu.RLock()
+	key := tm.signingKey
+	tm.mu.RUnlock()
+	if key == nil {
+		return "", ErrCannotSign
+	}
+
+	token := jwt.NewWithClaims(key.signingMethod(), claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.signKey)
 }
 
 // --- UPDATED: GenerateTokens ---
 // Generates a new access and refresh token pair for a user ID
 // containing standard claims (sub, jti, iat, etc.).
 func (tm *TokenManager) GenerateTokens(userID string) (string, string, error) {
+	return tm.GenerateSessionTokens(userID, "")
+}
+
+// GenerateSessionTokens is like GenerateTokens but also stamps both tokens
+// with the device session ID ('sid'), so services can tell sessions apart.
+func (tm *TokenManager) GenerateSessionTokens(userID, sessionID string) (string, string, error) {
 	now := time.Now()
 
 	// Access Token Claims
 	accessClaims := CustomClaims{
-		Type: TokenTypeAccess,
+		Type:          TokenTypeAccess,
+		SessionID:     sessionID,
+		IssuedAtMicro: now.UnixMicro(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			// Using 'sub' (Subject) standard for UserID
 			Subject: userID,
@@ -91,7 +220,9 @@ func (tm *TokenManager) GenerateTokens(userID string) (string, string, error) {
 
 	// Refresh Token Claims
 	refreshClaims := CustomClaims{
-		Type: TokenTypeRefresh,
+		Type:          TokenTypeRefresh,
+		SessionID:     sessionID,
+		IssuedAtMicro: now.UnixMicro(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject: userID,
 			ID:      uuid.NewString(), // Unique ID for refresh token as well
@@ -116,14 +247,7 @@ func (tm *TokenManager) GenerateTokens(userID string) (string, string, error) {
 func (tm *TokenManager) ValidateToken(tokenString string) (*CustomClaims, error) {
 
 	// Parse the token according to our CustomClaims structure
-	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// Verify the signing method (alg)
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing algorithm: %v", token.Header["alg"])
-		}
-		// Return our secret key
-		return tm.secretKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, tm.keyFunc)
 
 	// Error Handling:
 	if err != nil {
@@ -148,3 +272,27 @@ func (tm *TokenManager) ValidateToken(tokenString string) (*CustomClaims, error)
 
 	return nil, ErrInvalidToken
 }
+
+// keyFunc picks the verification key by the token's 'kid' header and checks
+// that the token's alg matches the key, so an HMAC token can never be
+// verified with a public key (alg confusion).
+func (tm *TokenManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	// RotateKey sets ExpiresAt under the write lock, so read it under ours
+	tm.mu.RLock()
+	key, ok := tm.keys[kid]
+	retired := ok && key.expired(tm.now())
+	tm.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if retired {
+		return nil, fmt.Errorf("signing key %q has been retired", kid)
+	}
+	// Verify the signing method (alg)
+	if token.Method.Alg() != string(key.Algorithm) {
+		return nil, fmt.Errorf("unexpected signing algorithm: %v", token.Header["alg"])
+	}
+	return key.verifyKey, nil
+}
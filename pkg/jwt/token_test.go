@@ -1,6 +1,8 @@
 package jwt
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 )
@@ -72,3 +74,135 @@ func TestValidateToken_WrongSecret(t *testing.T) {
 		t.Fatalf("expected signature validation error with wrong secret")
 	}
 }
+
+func TestAsymmetricKeys_VerifierUsesPublishedKeys(t *testing.T) {
+	for _, alg := range []Algorithm{AlgorithmRS256, AlgorithmEdDSA} {
+		key, err := GenerateKey(alg, "key-1")
+		if err != nil {
+			t.Fatalf("GenerateKey(%s) error: %v", alg, err)
+		}
+		issuer, err := NewTokenManagerWithKey(key, 15*time.Minute, 24*time.Hour)
+		if err != nil {
+			t.Fatalf("NewTokenManagerWithKey error: %v", err)
+		}
+
+		data, err := json.Marshal(issuer.JWKS())
+		if err != nil {
+			t.Fatalf("marshal JWKS: %v", err)
+		}
+		pub, err := ParseJWKS(data)
+		if err != nil {
+			t.Fatalf("ParseJWKS error: %v", err)
+		}
+		verifier := NewVerifier(pub...)
+
+		access, _, err := issuer.GenerateTokens("user-123")
+		if err != nil {
+			t.Fatalf("GenerateTokens error: %v", err)
+		}
+		if c, err := verifier.ValidateToken(access); err != nil || c.Subject != "user-123" {
+			t.Fatalf("%s: verifier rejected token: %v", alg, err)
+		}
+		if _, _, err := verifier.GenerateTokens("user-123"); !errors.Is(err, ErrCannotSign) {
+			t.Fatalf("%s: expected ErrCannotSign from verifier, got %v", alg, err)
+		}
+	}
+}
+
+func TestRotateKey_GracePeriod(t *testing.T) {
+	oldKey, _ := GenerateKey(AlgorithmEdDSA, "old")
+	newKey, _ := GenerateKey(AlgorithmEdDSA, "new")
+	tm, err := NewTokenManagerWithKey(oldKey, 15*time.Minute, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewTokenManagerWithKey error: %v", err)
+	}
+	oldAccess, _, _ := tm.GenerateTokens("user-1")
+
+	if err := tm.RotateKey(newKey, time.Hour); err != nil {
+		t.Fatalf("RotateKey error: %v", err)
+	}
+	if _, err := tm.ValidateToken(oldAccess); err != nil {
+		t.Fatalf("token signed with retired key rejected during grace period: %v", err)
+	}
+	if n := len(tm.JWKS().Keys); n != 2 {
+		t.Fatalf("expected 2 published keys during grace period, got %d", n)
+	}
+
+	// After the grace period only the new key is accepted
+	tm.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := tm.ValidateToken(oldAccess); err == nil {
+		t.Fatalf("expected token signed with expired key to be rejected")
+	}
+	newAccess, _, _ := tm.GenerateTokens("user-1")
+	if _, err := tm.ValidateToken(newAccess); err != nil {
+		t.Fatalf("token signed with new key rejected: %v", err)
+	}
+}
+
+// Run with -race: validation reads the retired key's expiry while RotateKey sets it.
+func TestRotateKey_ConcurrentWithValidate(t *testing.T) {
+	keyA, _ := GenerateKey(AlgorithmEdDSA, "a")
+	keyB, _ := GenerateKey(AlgorithmEdDSA, "b")
+	tm, err := NewTokenManagerWithKey(keyA, 15*time.Minute, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewTokenManagerWithKey error: %v", err)
+	}
+	access, _, _ := tm.GenerateTokens("user-1")
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// Each rotation retires the other key, setting its expiry
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			next := keyA
+			if i%2 == 0 {
+				next = keyB
+			}
+			if err := tm.RotateKey(next, time.Hour); err != nil {
+				t.Errorf("RotateKey error: %v", err)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		if _, err := tm.ValidateToken(access); err != nil {
+			t.Errorf("token signed with retired key rejected during grace period: %v", err)
+			break
+		}
+	}
+	close(stop)
+	<-done
+}
+
+func TestGenerateTokens_IssuedAtKeepsSubSecondPrecision(t *testing.T) {
+	tm := mustManager(t)
+	before := time.Now()
+	access, _, _ := tm.GenerateTokens("user-1")
+	after := time.Now()
+
+	claims, err := tm.ValidateToken(access)
+	if err != nil {
+		t.Fatalf("ValidateToken error: %v", err)
+	}
+	// Revocation compares iat with sub-second watermarks
+	if iat := claims.IssuedAtTime(); iat.Before(before.Add(-time.Microsecond)) || iat.After(after) {
+		t.Fatalf("iat = %v, want between %v and %v", iat, before, after)
+	}
+}
+
+func TestValidateToken_RejectsHMACWithPublicKey(t *testing.T) {
+	key, _ := GenerateKey(AlgorithmRS256, "")
+	verifier := NewVerifier(key.publicOnly())
+
+	// A legacy HS256 token (no kid) must not be accepted by an asymmetric verifier
+	access, _, _ := mustManager(t).GenerateTokens("user-1")
+	if _, err := verifier.ValidateToken(access); err == nil {
+		t.Fatalf("expected HS256 token to be rejected")
+	}
+}
@@ -0,0 +1,198 @@
+// Package phone normalizes phone numbers to E.164 (+<country code><number>).
+//
+// Numbers in international format ("+90 555 123 45 67", "0090 555 ...") are
+// accepted for every assigned country calling code. Numbers in national format
+// ("0555 123 45 67") are read as numbers of the parser's default region, which
+// must be one of the regions in the regions table below.
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalid is returned for input that is not a valid phone number.
+var ErrInvalid = errors.New("invalid phone number")
+
+const (
+	minE164Digits = 8  // shortest numbers in use, e.g. small island states
+	maxE164Digits = 15 // ITU-T E.164 limit, country code included
+)
+
+// region describes how numbers are written nationally in one region.
+type region struct {
+	callingCode string
+	trunkPrefix string // dialled before national numbers, dropped in E.164
+	intlPrefix  string // dialled before international numbers besides "00"
+	minLen      int    // national significant number length
+	maxLen      int
+}
+
+// regions are the regions a parser can default to. Numbers with their calling
+// code are also checked against the national number length.
+var regions = map[string]region{
+	"AE": {"971", "0", "00", 8, 9},
+	"AZ": {"994", "0", "00", 9, 9},
+	"BR": {"55", "0", "00", 10, 11},
+	"CA": {"1", "1", "011", 10, 10},
+	"DE": {"49", "0", "00", 6, 13},
+	"ES": {"34", "", "00", 9, 9},
+	"FR": {"33", "0", "00", 9, 9},
+	"GB": {"44", "0", "00", 9, 10},
+	"IN": {"91", "0", "00", 10, 10},
+	"IT": {"39", "", "00", 6, 11},
+	"KZ": {"7", "8", "810", 10, 10},
+	"NL": {"31", "0", "00", 9, 9},
+	"RU": {"7", "8", "810", 10, 10},
+	"SA": {"966", "0", "00", 9, 9},
+	"TR": {"90", "0", "00", 10, 10},
+	"US": {"1", "1", "011", 10, 10},
+}
+
+// callingCodes are the assigned country calling codes. They form a prefix
+// code, so at most one of a number's first one to three digits matches.
+var callingCodes = func() map[string]bool {
+	codes := map[string]bool{}
+	for _, c := range strings.Fields(`
+		1 7 20 27 30 31 32 33 34 36 39 40 41 43 44 45 46 47 48 49
+		51 52 53 54 55 56 57 58 60 61 62 63 64 65 66 81 82 84 86
+		90 91 92 93 94 95 98
+		211 212 213 216 218 220 221 222 223 224 225 226 227 228 229
+		230 231 232 233 234 235 236 237 238 239 240 241 242 243 244 245
+		246 247 248 249 250 251 252 253 254 255 256 257 258 260 261 262
+		263 264 265 266 267 268 269 290 291 297 298 299
+		350 351 352 353 354 355 356 357 358 359 370 371 372 373 374 375
+		376 377 378 379 380 381 382 383 385 386 387 389 420 421 423
+		500 501 502 503 504 505 506 507 508 509 590 591 592 593 594 595
+		596 597 598 599 670 672 673 674 675 676 677 678 679 680 681 682
+		683 685 686 687 688 689 690 691 692
+		800 808 850 852 853 855 856 870 878 880 881 882 883 886 888
+		960 961 962 963 964 965 966 967 968 970 971 972 973 974 975 976
+		977 979 992 993 994 995 996 998`) {
+		codes[c] = true
+	}
+	return codes
+}()
+
+// lengthByCallingCode is the national number length check of the regions
+// table, keyed by calling code. Regions sharing a code share their rules.
+var lengthByCallingCode = func() map[string]region {
+	out := map[string]region{}
+	for _, r := range regions {
+		out[r.callingCode] = r
+	}
+	return out
+}()
+
+// formatting is removed before parsing.
+var formatting = strings.NewReplacer(" ", "", "\u00a0", "", "-", "", ".", "", "(", "", ")", "", "/", "")
+
+// Parser normalizes phone numbers, reading national numbers as numbers of its
+// default region. A nil or zero Parser accepts international numbers only.
+type Parser struct {
+	region string
+}
+
+// NewParser returns a parser for the given ISO 3166-1 alpha-2 region, e.g.
+// "TR". An empty region accepts international numbers only.
+func NewParser(defaultRegion string) (*Parser, error) {
+	defaultRegion = strings.ToUpper(strings.TrimSpace(defaultRegion))
+	if defaultRegion == "" {
+		return &Parser{}, nil
+	}
+	if _, ok := regions[defaultRegion]; !ok {
+		return nil, fmt.Errorf("unsupported default phone region %q", defaultRegion)
+	}
+	return &Parser{region: defaultRegion}, nil
+}
+
+// Region returns the default region, or "" for none.
+func (p *Parser) Region() string {
+	if p == nil {
+		return ""
+	}
+	return p.region
+}
+
+// Normalize returns raw in E.164 form, or an error wrapping ErrInvalid.
+func (p *Parser) Normalize(raw string) (string, error) {
+	s := formatting.Replace(strings.TrimSpace(raw))
+	if s == "" {
+		return "", fmt.Errorf("%w: empty", ErrInvalid)
+	}
+
+	var def *region
+	if r, ok := regions[p.Region()]; ok {
+		def = &r
+	}
+	switch {
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	case strings.HasPrefix(s, "00"):
+		s = s[2:]
+	case def != nil && def.intlPrefix != "00" && strings.HasPrefix(s, def.intlPrefix):
+		s = s[len(def.intlPrefix):]
+	case def != nil:
+		if !digitsOnly(s) {
+			return "", fmt.Errorf("%w: %q", ErrInvalid, raw)
+		}
+		national := s
+		if def.trunkPrefix != "" && len(national) > def.maxLen && strings.HasPrefix(national, def.trunkPrefix) {
+			national = national[len(def.trunkPrefix):]
+		}
+		if len(national) < def.minLen || len(national) > def.maxLen {
+			return "", fmt.Errorf("%w: %q has the wrong length for region %s", ErrInvalid, raw, p.region)
+		}
+		return "+" + def.callingCode + national, nil
+	default:
+		return "", fmt.Errorf("%w: %q has no country code", ErrInvalid, raw)
+	}
+	return international(raw, s)
+}
+
+// international validates digits, a number after its international prefix.
+func international(raw, digits string) (string, error) {
+	if !digitsOnly(digits) || digits == "" || digits[0] == '0' {
+		return "", fmt.Errorf("%w: %q", ErrInvalid, raw)
+	}
+	code := ""
+	for n := 1; n <= 3 && n <= len(digits); n++ {
+		if callingCodes[digits[:n]] {
+			code = digits[:n]
+			break
+		}
+	}
+	if code == "" {
+		return "", fmt.Errorf("%w: %q has an unknown country code", ErrInvalid, raw)
+	}
+	national := digits[len(code):]
+	if r, ok := lengthByCallingCode[code]; ok {
+		// "+90 0555 ..." is a common way of writing the trunk prefix twice
+		if r.trunkPrefix == "0" && len(national) == r.maxLen+1 && national[0] == '0' {
+			national = national[1:]
+		}
+		if len(national) < r.minLen || len(national) > r.maxLen {
+			return "", fmt.Errorf("%w: %q has the wrong length for +%s", ErrInvalid, raw, code)
+		}
+	}
+	if n := len(code) + len(national); n < minE164Digits || n > maxE164Digits {
+		return "", fmt.Errorf("%w: %q has the wrong length", ErrInvalid, raw)
+	}
+	return "+" + code + national, nil
+}
+
+func digitsOnly(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Canonical validates a number already in international format and returns
+// it in E.164 form; it is Normalize of a parser without a default region.
+func Canonical(raw string) (string, error) {
+	return (*Parser)(nil).Normalize(raw)
+}
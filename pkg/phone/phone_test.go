@@ -0,0 +1,47 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tr, err := NewParser("tr")
+	if err != nil {
+		t.Fatalf("NewParser: %v", err)
+	}
+	us, _ := NewParser("US")
+
+	cases := []struct {
+		p    *Parser
+		raw  string
+		want string
+	}{
+		{tr, "+90 555 123 45 67", "+905551234567"},
+		{tr, "0555 123 45 67", "+905551234567"},
+		{tr, "(555) 123-4567", "+905551234567"},
+		{tr, "0090 555 123 4567", "+905551234567"},
+		{tr, "+90 0555 123 45 67", "+905551234567"},
+		{tr, "+44 20 7946 0958", "+442079460958"},
+		{us, "1 (415) 555-2671", "+14155552671"},
+		{us, "415.555.2671", "+14155552671"},
+		{us, "011 44 20 7946 0958", "+442079460958"},
+		{nil, "+1 415 555 2671", "+14155552671"},
+		{nil, "+372 5123 4567", "+37251234567"},
+	}
+	for _, c := range cases {
+		got, err := c.p.Normalize(c.raw)
+		if err != nil || got != c.want {
+			t.Errorf("Normalize(%q) in %q = %q, %v; want %q", c.raw, c.p.Region(), got, err, c.want)
+		}
+	}
+
+	for _, raw := range []string{"", "0555 123 45 67", "+0 555", "+90 555 123", "+999 1234567", "+90 555 12a 45 67", "+1234567890123456"} {
+		if got, err := Canonical(raw); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Canonical(%q) = %q, %v; want ErrInvalid", raw, got, err)
+		}
+	}
+	if _, err := NewParser("XX"); err == nil {
+		t.Fatal("expected an error for an unsupported region")
+	}
+}
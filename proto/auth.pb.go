@@ -273,11 +273,15 @@ func (x *VerifyOTPRequest) GetDeviceId() string {
 type VerifyOTPResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Keep field numbers aligned with server's generated code (auth.pb.go)
-	User          *User  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`                                     // optional in response; server may omit setting it
-	AccessToken   string `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`    // access token string (JWT)
-	RefreshToken  string `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // refresh token string (JWT)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	User         *User  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`                                     // optional in response; server may omit setting it
+	AccessToken  string `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`    // access token string (JWT)
+	RefreshToken string `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // refresh token string (JWT)
+	// Two-step verification: when true no tokens are returned; finish with VerifyPIN using pin_challenge_token
+	PinRequired           bool   `protobuf:"varint,4,opt,name=pin_required,json=pinRequired,proto3" json:"pin_required,omitempty"`
+	PinChallengeToken     string `protobuf:"bytes,5,opt,name=pin_challenge_token,json=pinChallengeToken,proto3" json:"pin_challenge_token,omitempty"`
+	PinChallengeExpiresAt string `protobuf:"bytes,6,opt,name=pin_challenge_expires_at,json=pinChallengeExpiresAt,proto3" json:"pin_challenge_expires_at,omitempty"` // RFC3339
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *VerifyOTPResponse) Reset() {
@@ -331,6 +335,27 @@ func (x *VerifyOTPResponse) GetRefreshToken() string {
 	return ""
 }
 
+func (x *VerifyOTPResponse) GetPinRequired() bool {
+	if x != nil {
+		return x.PinRequired
+	}
+	return false
+}
+
+func (x *VerifyOTPResponse) GetPinChallengeToken() string {
+	if x != nil {
+		return x.PinChallengeToken
+	}
+	return ""
+}
+
+func (x *VerifyOTPResponse) GetPinChallengeExpiresAt() string {
+	if x != nil {
+		return x.PinChallengeExpiresAt
+	}
+	return ""
+}
+
 type ValidateTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
@@ -570,8 +595,11 @@ func (x *RevokeCurrentDeviceRequest) GetRefreshToken() string {
 }
 
 type LogoutAllDevicesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ignored: the "authorization: Bearer" token is logged out.
+	//
+	// Deprecated: Marked as deprecated in proto/auth.proto.
+	AccessToken   string `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -606,6 +634,7 @@ func (*LogoutAllDevicesRequest) Descriptor() ([]byte, []int) {
 	return file_proto_auth_proto_rawDescGZIP(), []int{10}
 }
 
+// Deprecated: Marked as deprecated in proto/auth.proto.
 func (x *LogoutAllDevicesRequest) GetAccessToken() string {
 	if x != nil {
 		return x.AccessToken
@@ -657,60 +686,1644 @@ func (x *RevokeResponse) GetSuccess() bool {
 	return false
 }
 
-var File_proto_auth_proto protoreflect.FileDescriptor
+// DeviceSession is one logged-in device. device_id stays the same across refresh token rotations.
+type DeviceSession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeviceName    string                 `protobuf:"bytes,2,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
+	DeviceType    string                 `protobuf:"bytes,3,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`       // 'mobile', 'web', 'desktop'
+	LastLoginAt   string                 `protobuf:"bytes,4,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"`  // RFC3339
+	IsCurrent     bool                   `protobuf:"varint,5,opt,name=is_current,json=isCurrent,proto3" json:"is_current,omitempty"`         // true for the session of the calling access token
+	PushProvider  string                 `protobuf:"bytes,6,opt,name=push_provider,json=pushProvider,proto3" json:"push_provider,omitempty"` // 'fcm' or 'apns' when a push token is registered
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_auth_proto_rawDesc = "" +
-	"\n" +
-	"\x10proto/auth.proto\x12\x04auth\"\x8b\x02\n" +
-	"\x04User\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
-	"\fphone_number\x18\x02 \x01(\tR\vphoneNumber\x12!\n" +
-	"\fdisplay_name\x18\x03 \x01(\tR\vdisplayName\x12.\n" +
-	"\x13profile_picture_url\x18\x04 \x01(\tR\x11profilePictureUrl\x12\x1d\n" +
-	"\n" +
-	"about_text\x18\x05 \x01(\tR\taboutText\x12 \n" +
-	"\flast_seen_at\x18\x06 \x01(\tR\n" +
-	"lastSeenAt\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\b \x01(\tR\tupdatedAt\"3\n" +
-	"\x0eSendOTPRequest\x12!\n" +
-	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\"+\n" +
-	"\x0fSendOTPResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"m\n" +
-	"\x10VerifyOTPRequest\x12!\n" +
-	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\x12\x19\n" +
-	"\botp_code\x18\x02 \x01(\tR\aotpCode\x12\x1b\n" +
-	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\"{\n" +
-	"\x11VerifyOTPResponse\x12\x1e\n" +
-	"\x04user\x18\x01 \x01(\v2\n" +
-	".auth.UserR\x04user\x12!\n" +
-	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\x12#\n" +
-	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\"9\n" +
-	"\x14ValidateTokenRequest\x12!\n" +
-	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"K\n" +
-	"\x15ValidateTokenResponse\x12\x19\n" +
-	"\bis_valid\x18\x01 \x01(\bR\aisValid\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\":\n" +
-	"\x13RefreshTokenRequest\x12#\n" +
-	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"^\n" +
-	"\x14RefreshTokenResponse\x12!\n" +
-	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
-	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"A\n" +
-	"\x1aRevokeCurrentDeviceRequest\x12#\n" +
-	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"<\n" +
-	"\x17LogoutAllDevicesRequest\x12!\n" +
-	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"*\n" +
-	"\x0eRevokeResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xac\x03\n" +
-	"\vAuthService\x126\n" +
-	"\aSendOTP\x12\x14.auth.SendOTPRequest\x1a\x15.auth.SendOTPResponse\x12<\n" +
-	"\tVerifyOTP\x12\x16.auth.VerifyOTPRequest\x1a\x17.auth.VerifyOTPResponse\x12H\n" +
-	"\rValidateToken\x12\x1a.auth.ValidateTokenRequest\x1a\x1b.auth.ValidateTokenResponse\x12E\n" +
-	"\fRefreshToken\x12\x19.auth.RefreshTokenRequest\x1a\x1a.auth.RefreshTokenResponse\x12M\n" +
-	"\x13RevokeCurrentDevice\x12 .auth.RevokeCurrentDeviceRequest\x1a\x14.auth.RevokeResponse\x12G\n" +
-	"\x10LogoutAllDevices\x12\x1d.auth.LogoutAllDevicesRequest\x1a\x14.auth.RevokeResponseB'Z%github.com/dykethecreator/GoApp/protob\x06proto3"
+func (x *DeviceSession) Reset() {
+	*x = DeviceSession{}
+	mi := &file_proto_auth_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceSession) ProtoMessage() {}
+
+func (x *DeviceSession) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceSession.ProtoReflect.Descriptor instead.
+func (*DeviceSession) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeviceSession) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *DeviceSession) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *DeviceSession) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
+	}
+	return ""
+}
+
+func (x *DeviceSession) GetLastLoginAt() string {
+	if x != nil {
+		return x.LastLoginAt
+	}
+	return ""
+}
+
+func (x *DeviceSession) GetIsCurrent() bool {
+	if x != nil {
+		return x.IsCurrent
+	}
+	return false
+}
+
+func (x *DeviceSession) GetPushProvider() string {
+	if x != nil {
+		return x.PushProvider
+	}
+	return ""
+}
+
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_proto_auth_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{13}
+}
+
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*DeviceSession       `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_proto_auth_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*DeviceSession {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type RevokeDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeDeviceRequest) Reset() {
+	*x = RevokeDeviceRequest{}
+	mi := &file_proto_auth_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeDeviceRequest) ProtoMessage() {}
+
+func (x *RevokeDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RevokeDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RevokeDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type UpdateDeviceRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"` // empty = current session
+	// Only fields that are set are changed
+	DeviceName    *string `protobuf:"bytes,2,opt,name=device_name,json=deviceName,proto3,oneof" json:"device_name,omitempty"`
+	DeviceType    *string `protobuf:"bytes,3,opt,name=device_type,json=deviceType,proto3,oneof" json:"device_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDeviceRequest) Reset() {
+	*x = UpdateDeviceRequest{}
+	mi := &file_proto_auth_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDeviceRequest) ProtoMessage() {}
+
+func (x *UpdateDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDeviceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *UpdateDeviceRequest) GetDeviceName() string {
+	if x != nil && x.DeviceName != nil {
+		return *x.DeviceName
+	}
+	return ""
+}
+
+func (x *UpdateDeviceRequest) GetDeviceType() string {
+	if x != nil && x.DeviceType != nil {
+		return *x.DeviceType
+	}
+	return ""
+}
+
+type UpdateDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *DeviceSession         `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDeviceResponse) Reset() {
+	*x = UpdateDeviceResponse{}
+	mi := &file_proto_auth_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDeviceResponse) ProtoMessage() {}
+
+func (x *UpdateDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDeviceResponse.ProtoReflect.Descriptor instead.
+func (*UpdateDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateDeviceResponse) GetDevice() *DeviceSession {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+type RegisterPushTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"` // 'fcm' or 'apns'
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`       // empty = stop push notifications to this device
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPushTokenRequest) Reset() {
+	*x = RegisterPushTokenRequest{}
+	mi := &file_proto_auth_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPushTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPushTokenRequest) ProtoMessage() {}
+
+func (x *RegisterPushTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPushTokenRequest.ProtoReflect.Descriptor instead.
+func (*RegisterPushTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RegisterPushTokenRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *RegisterPushTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type RegisterPushTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *DeviceSession         `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPushTokenResponse) Reset() {
+	*x = RegisterPushTokenResponse{}
+	mi := &file_proto_auth_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPushTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPushTokenResponse) ProtoMessage() {}
+
+func (x *RegisterPushTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPushTokenResponse.ProtoReflect.Descriptor instead.
+func (*RegisterPushTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RegisterPushTokenResponse) GetDevice() *DeviceSession {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+type GetPublicKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeysRequest) Reset() {
+	*x = GetPublicKeysRequest{}
+	mi := &file_proto_auth_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeysRequest) ProtoMessage() {}
+
+func (x *GetPublicKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeysRequest.ProtoReflect.Descriptor instead.
+func (*GetPublicKeysRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{20}
+}
+
+type GetPublicKeysResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RFC 7517 JSON Web Key Set with the current signing key and retired keys still in their grace period
+	JwksJson      string `protobuf:"bytes,1,opt,name=jwks_json,json=jwksJson,proto3" json:"jwks_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeysResponse) Reset() {
+	*x = GetPublicKeysResponse{}
+	mi := &file_proto_auth_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeysResponse) ProtoMessage() {}
+
+func (x *GetPublicKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeysResponse.ProtoReflect.Descriptor instead.
+func (*GetPublicKeysResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetPublicKeysResponse) GetJwksJson() string {
+	if x != nil {
+		return x.JwksJson
+	}
+	return ""
+}
+
+type VerifyPINRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PinChallengeToken string                 `protobuf:"bytes,1,opt,name=pin_challenge_token,json=pinChallengeToken,proto3" json:"pin_challenge_token,omitempty"`
+	Pin               string                 `protobuf:"bytes,2,opt,name=pin,proto3" json:"pin,omitempty"` // 6 digits
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *VerifyPINRequest) Reset() {
+	*x = VerifyPINRequest{}
+	mi := &file_proto_auth_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPINRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPINRequest) ProtoMessage() {}
+
+func (x *VerifyPINRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPINRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPINRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VerifyPINRequest) GetPinChallengeToken() string {
+	if x != nil {
+		return x.PinChallengeToken
+	}
+	return ""
+}
+
+func (x *VerifyPINRequest) GetPin() string {
+	if x != nil {
+		return x.Pin
+	}
+	return ""
+}
+
+type VerifyPINResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPINResponse) Reset() {
+	*x = VerifyPINResponse{}
+	mi := &file_proto_auth_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPINResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPINResponse) ProtoMessage() {}
+
+func (x *VerifyPINResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPINResponse.ProtoReflect.Descriptor instead.
+func (*VerifyPINResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *VerifyPINResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *VerifyPINResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *VerifyPINResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RequestPINResetRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PinChallengeToken string                 `protobuf:"bytes,1,opt,name=pin_challenge_token,json=pinChallengeToken,proto3" json:"pin_challenge_token,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RequestPINResetRequest) Reset() {
+	*x = RequestPINResetRequest{}
+	mi := &file_proto_auth_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPINResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPINResetRequest) ProtoMessage() {}
+
+func (x *RequestPINResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPINResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPINResetRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RequestPINResetRequest) GetPinChallengeToken() string {
+	if x != nil {
+		return x.PinChallengeToken
+	}
+	return ""
+}
+
+type RequestPINResetResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	EmailSent        bool                   `protobuf:"varint,1,opt,name=email_sent,json=emailSent,proto3" json:"email_sent,omitempty"`                       // a reset code was sent to the recovery email
+	MaskedEmail      string                 `protobuf:"bytes,2,opt,name=masked_email,json=maskedEmail,proto3" json:"masked_email,omitempty"`                  // e.g. "a***@example.com"
+	ResetAvailableAt string                 `protobuf:"bytes,3,opt,name=reset_available_at,json=resetAvailableAt,proto3" json:"reset_available_at,omitempty"` // RFC3339; ResetPIN without a code works from then on
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RequestPINResetResponse) Reset() {
+	*x = RequestPINResetResponse{}
+	mi := &file_proto_auth_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPINResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPINResetResponse) ProtoMessage() {}
+
+func (x *RequestPINResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPINResetResponse.ProtoReflect.Descriptor instead.
+func (*RequestPINResetResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RequestPINResetResponse) GetEmailSent() bool {
+	if x != nil {
+		return x.EmailSent
+	}
+	return false
+}
+
+func (x *RequestPINResetResponse) GetMaskedEmail() string {
+	if x != nil {
+		return x.MaskedEmail
+	}
+	return ""
+}
+
+func (x *RequestPINResetResponse) GetResetAvailableAt() string {
+	if x != nil {
+		return x.ResetAvailableAt
+	}
+	return ""
+}
+
+type ResetPINRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PinChallengeToken string                 `protobuf:"bytes,1,opt,name=pin_challenge_token,json=pinChallengeToken,proto3" json:"pin_challenge_token,omitempty"`
+	ResetCode         string                 `protobuf:"bytes,2,opt,name=reset_code,json=resetCode,proto3" json:"reset_code,omitempty"` // from the recovery email; empty = cooldown reset
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ResetPINRequest) Reset() {
+	*x = ResetPINRequest{}
+	mi := &file_proto_auth_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPINRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPINRequest) ProtoMessage() {}
+
+func (x *ResetPINRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPINRequest.ProtoReflect.Descriptor instead.
+func (*ResetPINRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ResetPINRequest) GetPinChallengeToken() string {
+	if x != nil {
+		return x.PinChallengeToken
+	}
+	return ""
+}
+
+func (x *ResetPINRequest) GetResetCode() string {
+	if x != nil {
+		return x.ResetCode
+	}
+	return ""
+}
+
+type SetPINRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pin           string                 `protobuf:"bytes,1,opt,name=pin,proto3" json:"pin,omitempty"`                                                // 6 digits
+	RecoveryEmail *string                `protobuf:"bytes,2,opt,name=recovery_email,json=recoveryEmail,proto3,oneof" json:"recovery_email,omitempty"` // unset = keep, empty = remove
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPINRequest) Reset() {
+	*x = SetPINRequest{}
+	mi := &file_proto_auth_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPINRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPINRequest) ProtoMessage() {}
+
+func (x *SetPINRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPINRequest.ProtoReflect.Descriptor instead.
+func (*SetPINRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SetPINRequest) GetPin() string {
+	if x != nil {
+		return x.Pin
+	}
+	return ""
+}
+
+func (x *SetPINRequest) GetRecoveryEmail() string {
+	if x != nil && x.RecoveryEmail != nil {
+		return *x.RecoveryEmail
+	}
+	return ""
+}
+
+type SetPINResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	RecoveryEmail string                 `protobuf:"bytes,2,opt,name=recovery_email,json=recoveryEmail,proto3" json:"recovery_email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPINResponse) Reset() {
+	*x = SetPINResponse{}
+	mi := &file_proto_auth_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPINResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPINResponse) ProtoMessage() {}
+
+func (x *SetPINResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPINResponse.ProtoReflect.Descriptor instead.
+func (*SetPINResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetPINResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetPINResponse) GetRecoveryEmail() string {
+	if x != nil {
+		return x.RecoveryEmail
+	}
+	return ""
+}
+
+type DisablePINRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisablePINRequest) Reset() {
+	*x = DisablePINRequest{}
+	mi := &file_proto_auth_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisablePINRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisablePINRequest) ProtoMessage() {}
+
+func (x *DisablePINRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisablePINRequest.ProtoReflect.Descriptor instead.
+func (*DisablePINRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{29}
+}
+
+type RequestNumberChangeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NewPhoneNumber string                 `protobuf:"bytes,1,opt,name=new_phone_number,json=newPhoneNumber,proto3" json:"new_phone_number,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RequestNumberChangeRequest) Reset() {
+	*x = RequestNumberChangeRequest{}
+	mi := &file_proto_auth_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestNumberChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestNumberChangeRequest) ProtoMessage() {}
+
+func (x *RequestNumberChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestNumberChangeRequest.ProtoReflect.Descriptor instead.
+func (*RequestNumberChangeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RequestNumberChangeRequest) GetNewPhoneNumber() string {
+	if x != nil {
+		return x.NewPhoneNumber
+	}
+	return ""
+}
+
+type RequestNumberChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestNumberChangeResponse) Reset() {
+	*x = RequestNumberChangeResponse{}
+	mi := &file_proto_auth_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestNumberChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestNumberChangeResponse) ProtoMessage() {}
+
+func (x *RequestNumberChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestNumberChangeResponse.ProtoReflect.Descriptor instead.
+func (*RequestNumberChangeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{31}
+}
+
+type ChangeNumberRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NewPhoneNumber string                 `protobuf:"bytes,1,opt,name=new_phone_number,json=newPhoneNumber,proto3" json:"new_phone_number,omitempty"`
+	OldOtpCode     string                 `protobuf:"bytes,2,opt,name=old_otp_code,json=oldOtpCode,proto3" json:"old_otp_code,omitempty"`            // code sent to the current number
+	NewOtpCode     string                 `protobuf:"bytes,3,opt,name=new_otp_code,json=newOtpCode,proto3" json:"new_otp_code,omitempty"`            // code sent to the new number
+	NotifyContacts bool                   `protobuf:"varint,4,opt,name=notify_contacts,json=notifyContacts,proto3" json:"notify_contacts,omitempty"` // post a system message in the caller's conversations
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ChangeNumberRequest) Reset() {
+	*x = ChangeNumberRequest{}
+	mi := &file_proto_auth_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeNumberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeNumberRequest) ProtoMessage() {}
+
+func (x *ChangeNumberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeNumberRequest.ProtoReflect.Descriptor instead.
+func (*ChangeNumberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ChangeNumberRequest) GetNewPhoneNumber() string {
+	if x != nil {
+		return x.NewPhoneNumber
+	}
+	return ""
+}
+
+func (x *ChangeNumberRequest) GetOldOtpCode() string {
+	if x != nil {
+		return x.OldOtpCode
+	}
+	return ""
+}
+
+func (x *ChangeNumberRequest) GetNewOtpCode() string {
+	if x != nil {
+		return x.NewOtpCode
+	}
+	return ""
+}
+
+func (x *ChangeNumberRequest) GetNotifyContacts() bool {
+	if x != nil {
+		return x.NotifyContacts
+	}
+	return false
+}
+
+type ChangeNumberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeNumberResponse) Reset() {
+	*x = ChangeNumberResponse{}
+	mi := &file_proto_auth_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeNumberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeNumberResponse) ProtoMessage() {}
+
+func (x *ChangeNumberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeNumberResponse.ProtoReflect.Descriptor instead.
+func (*ChangeNumberResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ChangeNumberResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DisablePINResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisablePINResponse) Reset() {
+	*x = DisablePINResponse{}
+	mi := &file_proto_auth_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisablePINResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisablePINResponse) ProtoMessage() {}
+
+func (x *DisablePINResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisablePINResponse.ProtoReflect.Descriptor instead.
+func (*DisablePINResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{34}
+}
+
+type StartDeviceLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceName    string                 `protobuf:"bytes,1,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"` // e.g. "Chrome on macOS"
+	DeviceType    string                 `protobuf:"bytes,2,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"` // "web" or "desktop"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartDeviceLinkRequest) Reset() {
+	*x = StartDeviceLinkRequest{}
+	mi := &file_proto_auth_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartDeviceLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartDeviceLinkRequest) ProtoMessage() {}
+
+func (x *StartDeviceLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartDeviceLinkRequest.ProtoReflect.Descriptor instead.
+func (*StartDeviceLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *StartDeviceLinkRequest) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *StartDeviceLinkRequest) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
+	}
+	return ""
+}
+
+type StartDeviceLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PairingToken  string                 `protobuf:"bytes,1,opt,name=pairing_token,json=pairingToken,proto3" json:"pairing_token,omitempty"`
+	DeviceSecret  string                 `protobuf:"bytes,2,opt,name=device_secret,json=deviceSecret,proto3" json:"device_secret,omitempty"` // kept on the new device, never shown
+	QrPayload     string                 `protobuf:"bytes,3,opt,name=qr_payload,json=qrPayload,proto3" json:"qr_payload,omitempty"`          // "goapp-link:<pairing_token>"
+	ExpiresAt     string                 `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`          // RFC3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartDeviceLinkResponse) Reset() {
+	*x = StartDeviceLinkResponse{}
+	mi := &file_proto_auth_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartDeviceLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartDeviceLinkResponse) ProtoMessage() {}
+
+func (x *StartDeviceLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartDeviceLinkResponse.ProtoReflect.Descriptor instead.
+func (*StartDeviceLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *StartDeviceLinkResponse) GetPairingToken() string {
+	if x != nil {
+		return x.PairingToken
+	}
+	return ""
+}
+
+func (x *StartDeviceLinkResponse) GetDeviceSecret() string {
+	if x != nil {
+		return x.DeviceSecret
+	}
+	return ""
+}
+
+func (x *StartDeviceLinkResponse) GetQrPayload() string {
+	if x != nil {
+		return x.QrPayload
+	}
+	return ""
+}
+
+func (x *StartDeviceLinkResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type ApproveDeviceLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PairingToken  string                 `protobuf:"bytes,1,opt,name=pairing_token,json=pairingToken,proto3" json:"pairing_token,omitempty"` // the token or the whole QR payload
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceLinkRequest) Reset() {
+	*x = ApproveDeviceLinkRequest{}
+	mi := &file_proto_auth_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceLinkRequest) ProtoMessage() {}
+
+func (x *ApproveDeviceLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceLinkRequest.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ApproveDeviceLinkRequest) GetPairingToken() string {
+	if x != nil {
+		return x.PairingToken
+	}
+	return ""
+}
+
+type ApproveDeviceLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceName    string                 `protobuf:"bytes,1,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
+	DeviceType    string                 `protobuf:"bytes,2,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceLinkResponse) Reset() {
+	*x = ApproveDeviceLinkResponse{}
+	mi := &file_proto_auth_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceLinkResponse) ProtoMessage() {}
+
+func (x *ApproveDeviceLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceLinkResponse.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ApproveDeviceLinkResponse) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *ApproveDeviceLinkResponse) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
+	}
+	return ""
+}
+
+type CompleteDeviceLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PairingToken  string                 `protobuf:"bytes,1,opt,name=pairing_token,json=pairingToken,proto3" json:"pairing_token,omitempty"`
+	DeviceSecret  string                 `protobuf:"bytes,2,opt,name=device_secret,json=deviceSecret,proto3" json:"device_secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteDeviceLinkRequest) Reset() {
+	*x = CompleteDeviceLinkRequest{}
+	mi := &file_proto_auth_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteDeviceLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteDeviceLinkRequest) ProtoMessage() {}
+
+func (x *CompleteDeviceLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteDeviceLinkRequest.ProtoReflect.Descriptor instead.
+func (*CompleteDeviceLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CompleteDeviceLinkRequest) GetPairingToken() string {
+	if x != nil {
+		return x.PairingToken
+	}
+	return ""
+}
+
+func (x *CompleteDeviceLinkRequest) GetDeviceSecret() string {
+	if x != nil {
+		return x.DeviceSecret
+	}
+	return ""
+}
+
+type CompleteDeviceLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pending       bool                   `protobuf:"varint,1,opt,name=pending,proto3" json:"pending,omitempty"` // not approved yet; poll again
+	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,3,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,4,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteDeviceLinkResponse) Reset() {
+	*x = CompleteDeviceLinkResponse{}
+	mi := &file_proto_auth_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteDeviceLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteDeviceLinkResponse) ProtoMessage() {}
+
+func (x *CompleteDeviceLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteDeviceLinkResponse.ProtoReflect.Descriptor instead.
+func (*CompleteDeviceLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CompleteDeviceLinkResponse) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *CompleteDeviceLinkResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *CompleteDeviceLinkResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *CompleteDeviceLinkResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+var File_proto_auth_proto protoreflect.FileDescriptor
+
+const file_proto_auth_proto_rawDesc = "" +
+	"\n" +
+	"\x10proto/auth.proto\x12\x04auth\"\x8b\x02\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fphone_number\x18\x02 \x01(\tR\vphoneNumber\x12!\n" +
+	"\fdisplay_name\x18\x03 \x01(\tR\vdisplayName\x12.\n" +
+	"\x13profile_picture_url\x18\x04 \x01(\tR\x11profilePictureUrl\x12\x1d\n" +
+	"\n" +
+	"about_text\x18\x05 \x01(\tR\taboutText\x12 \n" +
+	"\flast_seen_at\x18\x06 \x01(\tR\n" +
+	"lastSeenAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\"3\n" +
+	"\x0eSendOTPRequest\x12!\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\"+\n" +
+	"\x0fSendOTPResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"m\n" +
+	"\x10VerifyOTPRequest\x12!\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\x12\x19\n" +
+	"\botp_code\x18\x02 \x01(\tR\aotpCode\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\"\x87\x02\n" +
+	"\x11VerifyOTPResponse\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".auth.UserR\x04user\x12!\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\x12!\n" +
+	"\fpin_required\x18\x04 \x01(\bR\vpinRequired\x12.\n" +
+	"\x13pin_challenge_token\x18\x05 \x01(\tR\x11pinChallengeToken\x127\n" +
+	"\x18pin_challenge_expires_at\x18\x06 \x01(\tR\x15pinChallengeExpiresAt\"9\n" +
+	"\x14ValidateTokenRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"K\n" +
+	"\x15ValidateTokenResponse\x12\x19\n" +
+	"\bis_valid\x18\x01 \x01(\bR\aisValid\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\":\n" +
+	"\x13RefreshTokenRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"^\n" +
+	"\x14RefreshTokenResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"A\n" +
+	"\x1aRevokeCurrentDeviceRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"@\n" +
+	"\x17LogoutAllDevicesRequest\x12%\n" +
+	"\faccess_token\x18\x01 \x01(\tB\x02\x18\x01R\vaccessToken\"*\n" +
+	"\x0eRevokeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xd6\x01\n" +
+	"\rDeviceSession\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1f\n" +
+	"\vdevice_name\x18\x02 \x01(\tR\n" +
+	"deviceName\x12\x1f\n" +
+	"\vdevice_type\x18\x03 \x01(\tR\n" +
+	"deviceType\x12\"\n" +
+	"\rlast_login_at\x18\x04 \x01(\tR\vlastLoginAt\x12\x1d\n" +
+	"\n" +
+	"is_current\x18\x05 \x01(\bR\tisCurrent\x12#\n" +
+	"\rpush_provider\x18\x06 \x01(\tR\fpushProvider\"\x14\n" +
+	"\x12ListDevicesRequest\"D\n" +
+	"\x13ListDevicesResponse\x12-\n" +
+	"\adevices\x18\x01 \x03(\v2\x13.auth.DeviceSessionR\adevices\"2\n" +
+	"\x13RevokeDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"\xbd\x01\n" +
+	"\x13UpdateDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12$\n" +
+	"\vdevice_name\x18\x02 \x01(\tH\x00R\n" +
+	"deviceName\x88\x01\x01\x12$\n" +
+	"\vdevice_type\x18\x03 \x01(\tH\x01R\n" +
+	"deviceType\x88\x01\x01B\x0e\n" +
+	"\f_device_nameB\x0e\n" +
+	"\f_device_typeJ\x04\b\x04\x10\x05R\x17push_notification_token\"C\n" +
+	"\x14UpdateDeviceResponse\x12+\n" +
+	"\x06device\x18\x01 \x01(\v2\x13.auth.DeviceSessionR\x06device\"L\n" +
+	"\x18RegisterPushTokenRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"H\n" +
+	"\x19RegisterPushTokenResponse\x12+\n" +
+	"\x06device\x18\x01 \x01(\v2\x13.auth.DeviceSessionR\x06device\"\x16\n" +
+	"\x14GetPublicKeysRequest\"4\n" +
+	"\x15GetPublicKeysResponse\x12\x1b\n" +
+	"\tjwks_json\x18\x01 \x01(\tR\bjwksJson\"T\n" +
+	"\x10VerifyPINRequest\x12.\n" +
+	"\x13pin_challenge_token\x18\x01 \x01(\tR\x11pinChallengeToken\x12\x10\n" +
+	"\x03pin\x18\x02 \x01(\tR\x03pin\"{\n" +
+	"\x11VerifyPINResponse\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".auth.UserR\x04user\x12!\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\"H\n" +
+	"\x16RequestPINResetRequest\x12.\n" +
+	"\x13pin_challenge_token\x18\x01 \x01(\tR\x11pinChallengeToken\"\x89\x01\n" +
+	"\x17RequestPINResetResponse\x12\x1d\n" +
+	"\n" +
+	"email_sent\x18\x01 \x01(\bR\temailSent\x12!\n" +
+	"\fmasked_email\x18\x02 \x01(\tR\vmaskedEmail\x12,\n" +
+	"\x12reset_available_at\x18\x03 \x01(\tR\x10resetAvailableAt\"`\n" +
+	"\x0fResetPINRequest\x12.\n" +
+	"\x13pin_challenge_token\x18\x01 \x01(\tR\x11pinChallengeToken\x12\x1d\n" +
+	"\n" +
+	"reset_code\x18\x02 \x01(\tR\tresetCode\"`\n" +
+	"\rSetPINRequest\x12\x10\n" +
+	"\x03pin\x18\x01 \x01(\tR\x03pin\x12*\n" +
+	"\x0erecovery_email\x18\x02 \x01(\tH\x00R\rrecoveryEmail\x88\x01\x01B\x11\n" +
+	"\x0f_recovery_email\"Q\n" +
+	"\x0eSetPINResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12%\n" +
+	"\x0erecovery_email\x18\x02 \x01(\tR\rrecoveryEmail\"\x13\n" +
+	"\x11DisablePINRequest\"F\n" +
+	"\x1aRequestNumberChangeRequest\x12(\n" +
+	"\x10new_phone_number\x18\x01 \x01(\tR\x0enewPhoneNumber\"\x1d\n" +
+	"\x1bRequestNumberChangeResponse\"\xac\x01\n" +
+	"\x13ChangeNumberRequest\x12(\n" +
+	"\x10new_phone_number\x18\x01 \x01(\tR\x0enewPhoneNumber\x12 \n" +
+	"\fold_otp_code\x18\x02 \x01(\tR\n" +
+	"oldOtpCode\x12 \n" +
+	"\fnew_otp_code\x18\x03 \x01(\tR\n" +
+	"newOtpCode\x12'\n" +
+	"\x0fnotify_contacts\x18\x04 \x01(\bR\x0enotifyContacts\"6\n" +
+	"\x14ChangeNumberResponse\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".auth.UserR\x04user\"\x14\n" +
+	"\x12DisablePINResponse\"Z\n" +
+	"\x16StartDeviceLinkRequest\x12\x1f\n" +
+	"\vdevice_name\x18\x01 \x01(\tR\n" +
+	"deviceName\x12\x1f\n" +
+	"\vdevice_type\x18\x02 \x01(\tR\n" +
+	"deviceType\"\xa1\x01\n" +
+	"\x17StartDeviceLinkResponse\x12#\n" +
+	"\rpairing_token\x18\x01 \x01(\tR\fpairingToken\x12#\n" +
+	"\rdevice_secret\x18\x02 \x01(\tR\fdeviceSecret\x12\x1d\n" +
+	"\n" +
+	"qr_payload\x18\x03 \x01(\tR\tqrPayload\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\tR\texpiresAt\"?\n" +
+	"\x18ApproveDeviceLinkRequest\x12#\n" +
+	"\rpairing_token\x18\x01 \x01(\tR\fpairingToken\"]\n" +
+	"\x19ApproveDeviceLinkResponse\x12\x1f\n" +
+	"\vdevice_name\x18\x01 \x01(\tR\n" +
+	"deviceName\x12\x1f\n" +
+	"\vdevice_type\x18\x02 \x01(\tR\n" +
+	"deviceType\"e\n" +
+	"\x19CompleteDeviceLinkRequest\x12#\n" +
+	"\rpairing_token\x18\x01 \x01(\tR\fpairingToken\x12#\n" +
+	"\rdevice_secret\x18\x02 \x01(\tR\fdeviceSecret\"\x9e\x01\n" +
+	"\x1aCompleteDeviceLinkResponse\x12\x18\n" +
+	"\apending\x18\x01 \x01(\bR\apending\x12\x1e\n" +
+	"\x04user\x18\x02 \x01(\v2\n" +
+	".auth.UserR\x04user\x12!\n" +
+	"\faccess_token\x18\x03 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x04 \x01(\tR\frefreshToken2\xfa\v\n" +
+	"\vAuthService\x126\n" +
+	"\aSendOTP\x12\x14.auth.SendOTPRequest\x1a\x15.auth.SendOTPResponse\x12<\n" +
+	"\tVerifyOTP\x12\x16.auth.VerifyOTPRequest\x1a\x17.auth.VerifyOTPResponse\x12H\n" +
+	"\rValidateToken\x12\x1a.auth.ValidateTokenRequest\x1a\x1b.auth.ValidateTokenResponse\x12E\n" +
+	"\fRefreshToken\x12\x19.auth.RefreshTokenRequest\x1a\x1a.auth.RefreshTokenResponse\x12M\n" +
+	"\x13RevokeCurrentDevice\x12 .auth.RevokeCurrentDeviceRequest\x1a\x14.auth.RevokeResponse\x12G\n" +
+	"\x10LogoutAllDevices\x12\x1d.auth.LogoutAllDevicesRequest\x1a\x14.auth.RevokeResponse\x12B\n" +
+	"\vListDevices\x12\x18.auth.ListDevicesRequest\x1a\x19.auth.ListDevicesResponse\x12?\n" +
+	"\fRevokeDevice\x12\x19.auth.RevokeDeviceRequest\x1a\x14.auth.RevokeResponse\x12E\n" +
+	"\fUpdateDevice\x12\x19.auth.UpdateDeviceRequest\x1a\x1a.auth.UpdateDeviceResponse\x12T\n" +
+	"\x11RegisterPushToken\x12\x1e.auth.RegisterPushTokenRequest\x1a\x1f.auth.RegisterPushTokenResponse\x12<\n" +
+	"\tVerifyPIN\x12\x16.auth.VerifyPINRequest\x1a\x17.auth.VerifyPINResponse\x12N\n" +
+	"\x0fRequestPINReset\x12\x1c.auth.RequestPINResetRequest\x1a\x1d.auth.RequestPINResetResponse\x12:\n" +
+	"\bResetPIN\x12\x15.auth.ResetPINRequest\x1a\x17.auth.VerifyPINResponse\x123\n" +
+	"\x06SetPIN\x12\x13.auth.SetPINRequest\x1a\x14.auth.SetPINResponse\x12?\n" +
+	"\n" +
+	"DisablePIN\x12\x17.auth.DisablePINRequest\x1a\x18.auth.DisablePINResponse\x12Z\n" +
+	"\x13RequestNumberChange\x12 .auth.RequestNumberChangeRequest\x1a!.auth.RequestNumberChangeResponse\x12E\n" +
+	"\fChangeNumber\x12\x19.auth.ChangeNumberRequest\x1a\x1a.auth.ChangeNumberResponse\x12N\n" +
+	"\x0fStartDeviceLink\x12\x1c.auth.StartDeviceLinkRequest\x1a\x1d.auth.StartDeviceLinkResponse\x12T\n" +
+	"\x11ApproveDeviceLink\x12\x1e.auth.ApproveDeviceLinkRequest\x1a\x1f.auth.ApproveDeviceLinkResponse\x12W\n" +
+	"\x12CompleteDeviceLink\x12\x1f.auth.CompleteDeviceLinkRequest\x1a .auth.CompleteDeviceLinkResponse\x12H\n" +
+	"\rGetPublicKeys\x12\x1a.auth.GetPublicKeysRequest\x1a\x1b.auth.GetPublicKeysResponseB'Z%github.com/dykethecreator/GoApp/protob\x06proto3"
 
 var (
 	file_proto_auth_proto_rawDescOnce sync.Once
@@ -724,40 +2337,105 @@ func file_proto_auth_proto_rawDescGZIP() []byte {
 	return file_proto_auth_proto_rawDescData
 }
 
-var file_proto_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_proto_auth_proto_goTypes = []any{
-	(*User)(nil),                       // 0: auth.User
-	(*SendOTPRequest)(nil),             // 1: auth.SendOTPRequest
-	(*SendOTPResponse)(nil),            // 2: auth.SendOTPResponse
-	(*VerifyOTPRequest)(nil),           // 3: auth.VerifyOTPRequest
-	(*VerifyOTPResponse)(nil),          // 4: auth.VerifyOTPResponse
-	(*ValidateTokenRequest)(nil),       // 5: auth.ValidateTokenRequest
-	(*ValidateTokenResponse)(nil),      // 6: auth.ValidateTokenResponse
-	(*RefreshTokenRequest)(nil),        // 7: auth.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),       // 8: auth.RefreshTokenResponse
-	(*RevokeCurrentDeviceRequest)(nil), // 9: auth.RevokeCurrentDeviceRequest
-	(*LogoutAllDevicesRequest)(nil),    // 10: auth.LogoutAllDevicesRequest
-	(*RevokeResponse)(nil),             // 11: auth.RevokeResponse
+	(*User)(nil),                        // 0: auth.User
+	(*SendOTPRequest)(nil),              // 1: auth.SendOTPRequest
+	(*SendOTPResponse)(nil),             // 2: auth.SendOTPResponse
+	(*VerifyOTPRequest)(nil),            // 3: auth.VerifyOTPRequest
+	(*VerifyOTPResponse)(nil),           // 4: auth.VerifyOTPResponse
+	(*ValidateTokenRequest)(nil),        // 5: auth.ValidateTokenRequest
+	(*ValidateTokenResponse)(nil),       // 6: auth.ValidateTokenResponse
+	(*RefreshTokenRequest)(nil),         // 7: auth.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),        // 8: auth.RefreshTokenResponse
+	(*RevokeCurrentDeviceRequest)(nil),  // 9: auth.RevokeCurrentDeviceRequest
+	(*LogoutAllDevicesRequest)(nil),     // 10: auth.LogoutAllDevicesRequest
+	(*RevokeResponse)(nil),              // 11: auth.RevokeResponse
+	(*DeviceSession)(nil),               // 12: auth.DeviceSession
+	(*ListDevicesRequest)(nil),          // 13: auth.ListDevicesRequest
+	(*ListDevicesResponse)(nil),         // 14: auth.ListDevicesResponse
+	(*RevokeDeviceRequest)(nil),         // 15: auth.RevokeDeviceRequest
+	(*UpdateDeviceRequest)(nil),         // 16: auth.UpdateDeviceRequest
+	(*UpdateDeviceResponse)(nil),        // 17: auth.UpdateDeviceResponse
+	(*RegisterPushTokenRequest)(nil),    // 18: auth.RegisterPushTokenRequest
+	(*RegisterPushTokenResponse)(nil),   // 19: auth.RegisterPushTokenResponse
+	(*GetPublicKeysRequest)(nil),        // 20: auth.GetPublicKeysRequest
+	(*GetPublicKeysResponse)(nil),       // 21: auth.GetPublicKeysResponse
+	(*VerifyPINRequest)(nil),            // 22: auth.VerifyPINRequest
+	(*VerifyPINResponse)(nil),           // 23: auth.VerifyPINResponse
+	(*RequestPINResetRequest)(nil),      // 24: auth.RequestPINResetRequest
+	(*RequestPINResetResponse)(nil),     // 25: auth.RequestPINResetResponse
+	(*ResetPINRequest)(nil),             // 26: auth.ResetPINRequest
+	(*SetPINRequest)(nil),               // 27: auth.SetPINRequest
+	(*SetPINResponse)(nil),              // 28: auth.SetPINResponse
+	(*DisablePINRequest)(nil),           // 29: auth.DisablePINRequest
+	(*RequestNumberChangeRequest)(nil),  // 30: auth.RequestNumberChangeRequest
+	(*RequestNumberChangeResponse)(nil), // 31: auth.RequestNumberChangeResponse
+	(*ChangeNumberRequest)(nil),         // 32: auth.ChangeNumberRequest
+	(*ChangeNumberResponse)(nil),        // 33: auth.ChangeNumberResponse
+	(*DisablePINResponse)(nil),          // 34: auth.DisablePINResponse
+	(*StartDeviceLinkRequest)(nil),      // 35: auth.StartDeviceLinkRequest
+	(*StartDeviceLinkResponse)(nil),     // 36: auth.StartDeviceLinkResponse
+	(*ApproveDeviceLinkRequest)(nil),    // 37: auth.ApproveDeviceLinkRequest
+	(*ApproveDeviceLinkResponse)(nil),   // 38: auth.ApproveDeviceLinkResponse
+	(*CompleteDeviceLinkRequest)(nil),   // 39: auth.CompleteDeviceLinkRequest
+	(*CompleteDeviceLinkResponse)(nil),  // 40: auth.CompleteDeviceLinkResponse
 }
 var file_proto_auth_proto_depIdxs = []int32{
 	0,  // 0: auth.VerifyOTPResponse.user:type_name -> auth.User
-	1,  // 1: auth.AuthService.SendOTP:input_type -> auth.SendOTPRequest
-	3,  // 2: auth.AuthService.VerifyOTP:input_type -> auth.VerifyOTPRequest
-	5,  // 3: auth.AuthService.ValidateToken:input_type -> auth.ValidateTokenRequest
-	7,  // 4: auth.AuthService.RefreshToken:input_type -> auth.RefreshTokenRequest
-	9,  // 5: auth.AuthService.RevokeCurrentDevice:input_type -> auth.RevokeCurrentDeviceRequest
-	10, // 6: auth.AuthService.LogoutAllDevices:input_type -> auth.LogoutAllDevicesRequest
-	2,  // 7: auth.AuthService.SendOTP:output_type -> auth.SendOTPResponse
-	4,  // 8: auth.AuthService.VerifyOTP:output_type -> auth.VerifyOTPResponse
-	6,  // 9: auth.AuthService.ValidateToken:output_type -> auth.ValidateTokenResponse
-	8,  // 10: auth.AuthService.RefreshToken:output_type -> auth.RefreshTokenResponse
-	11, // 11: auth.AuthService.RevokeCurrentDevice:output_type -> auth.RevokeResponse
-	11, // 12: auth.AuthService.LogoutAllDevices:output_type -> auth.RevokeResponse
-	7,  // [7:13] is the sub-list for method output_type
-	1,  // [1:7] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	12, // 1: auth.ListDevicesResponse.devices:type_name -> auth.DeviceSession
+	12, // 2: auth.UpdateDeviceResponse.device:type_name -> auth.DeviceSession
+	12, // 3: auth.RegisterPushTokenResponse.device:type_name -> auth.DeviceSession
+	0,  // 4: auth.VerifyPINResponse.user:type_name -> auth.User
+	0,  // 5: auth.ChangeNumberResponse.user:type_name -> auth.User
+	0,  // 6: auth.CompleteDeviceLinkResponse.user:type_name -> auth.User
+	1,  // 7: auth.AuthService.SendOTP:input_type -> auth.SendOTPRequest
+	3,  // 8: auth.AuthService.VerifyOTP:input_type -> auth.VerifyOTPRequest
+	5,  // 9: auth.AuthService.ValidateToken:input_type -> auth.ValidateTokenRequest
+	7,  // 10: auth.AuthService.RefreshToken:input_type -> auth.RefreshTokenRequest
+	9,  // 11: auth.AuthService.RevokeCurrentDevice:input_type -> auth.RevokeCurrentDeviceRequest
+	10, // 12: auth.AuthService.LogoutAllDevices:input_type -> auth.LogoutAllDevicesRequest
+	13, // 13: auth.AuthService.ListDevices:input_type -> auth.ListDevicesRequest
+	15, // 14: auth.AuthService.RevokeDevice:input_type -> auth.RevokeDeviceRequest
+	16, // 15: auth.AuthService.UpdateDevice:input_type -> auth.UpdateDeviceRequest
+	18, // 16: auth.AuthService.RegisterPushToken:input_type -> auth.RegisterPushTokenRequest
+	22, // 17: auth.AuthService.VerifyPIN:input_type -> auth.VerifyPINRequest
+	24, // 18: auth.AuthService.RequestPINReset:input_type -> auth.RequestPINResetRequest
+	26, // 19: auth.AuthService.ResetPIN:input_type -> auth.ResetPINRequest
+	27, // 20: auth.AuthService.SetPIN:input_type -> auth.SetPINRequest
+	29, // 21: auth.AuthService.DisablePIN:input_type -> auth.DisablePINRequest
+	30, // 22: auth.AuthService.RequestNumberChange:input_type -> auth.RequestNumberChangeRequest
+	32, // 23: auth.AuthService.ChangeNumber:input_type -> auth.ChangeNumberRequest
+	35, // 24: auth.AuthService.StartDeviceLink:input_type -> auth.StartDeviceLinkRequest
+	37, // 25: auth.AuthService.ApproveDeviceLink:input_type -> auth.ApproveDeviceLinkRequest
+	39, // 26: auth.AuthService.CompleteDeviceLink:input_type -> auth.CompleteDeviceLinkRequest
+	20, // 27: auth.AuthService.GetPublicKeys:input_type -> auth.GetPublicKeysRequest
+	2,  // 28: auth.AuthService.SendOTP:output_type -> auth.SendOTPResponse
+	4,  // 29: auth.AuthService.VerifyOTP:output_type -> auth.VerifyOTPResponse
+	6,  // 30: auth.AuthService.ValidateToken:output_type -> auth.ValidateTokenResponse
+	8,  // 31: auth.AuthService.RefreshToken:output_type -> auth.RefreshTokenResponse
+	11, // 32: auth.AuthService.RevokeCurrentDevice:output_type -> auth.RevokeResponse
+	11, // 33: auth.AuthService.LogoutAllDevices:output_type -> auth.RevokeResponse
+	14, // 34: auth.AuthService.ListDevices:output_type -> auth.ListDevicesResponse
+	11, // 35: auth.AuthService.RevokeDevice:output_type -> auth.RevokeResponse
+	17, // 36: auth.AuthService.UpdateDevice:output_type -> auth.UpdateDeviceResponse
+	19, // 37: auth.AuthService.RegisterPushToken:output_type -> auth.RegisterPushTokenResponse
+	23, // 38: auth.AuthService.VerifyPIN:output_type -> auth.VerifyPINResponse
+	25, // 39: auth.AuthService.RequestPINReset:output_type -> auth.RequestPINResetResponse
+	23, // 40: auth.AuthService.ResetPIN:output_type -> auth.VerifyPINResponse
+	28, // 41: auth.AuthService.SetPIN:output_type -> auth.SetPINResponse
+	34, // 42: auth.AuthService.DisablePIN:output_type -> auth.DisablePINResponse
+	31, // 43: auth.AuthService.RequestNumberChange:output_type -> auth.RequestNumberChangeResponse
+	33, // 44: auth.AuthService.ChangeNumber:output_type -> auth.ChangeNumberResponse
+	36, // 45: auth.AuthService.StartDeviceLink:output_type -> auth.StartDeviceLinkResponse
+	38, // 46: auth.AuthService.ApproveDeviceLink:output_type -> auth.ApproveDeviceLinkResponse
+	40, // 47: auth.AuthService.CompleteDeviceLink:output_type -> auth.CompleteDeviceLinkResponse
+	21, // 48: auth.AuthService.GetPublicKeys:output_type -> auth.GetPublicKeysResponse
+	28, // [28:49] is the sub-list for method output_type
+	7,  // [7:28] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_proto_auth_proto_init() }
@@ -765,13 +2443,15 @@ func file_proto_auth_proto_init() {
 	if File_proto_auth_proto != nil {
 		return
 	}
+	file_proto_auth_proto_msgTypes[16].OneofWrappers = []any{}
+	file_proto_auth_proto_msgTypes[27].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_auth_proto_rawDesc), len(file_proto_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,6 +25,21 @@ const (
 	AuthService_RefreshToken_FullMethodName        = "/auth.AuthService/RefreshToken"
 	AuthService_RevokeCurrentDevice_FullMethodName = "/auth.AuthService/RevokeCurrentDevice"
 	AuthService_LogoutAllDevices_FullMethodName    = "/auth.AuthService/LogoutAllDevices"
+	AuthService_ListDevices_FullMethodName         = "/auth.AuthService/ListDevices"
+	AuthService_RevokeDevice_FullMethodName        = "/auth.AuthService/RevokeDevice"
+	AuthService_UpdateDevice_FullMethodName        = "/auth.AuthService/UpdateDevice"
+	AuthService_RegisterPushToken_FullMethodName   = "/auth.AuthService/RegisterPushToken"
+	AuthService_VerifyPIN_FullMethodName           = "/auth.AuthService/VerifyPIN"
+	AuthService_RequestPINReset_FullMethodName     = "/auth.AuthService/RequestPINReset"
+	AuthService_ResetPIN_FullMethodName            = "/auth.AuthService/ResetPIN"
+	AuthService_SetPIN_FullMethodName              = "/auth.AuthService/SetPIN"
+	AuthService_DisablePIN_FullMethodName          = "/auth.AuthService/DisablePIN"
+	AuthService_RequestNumberChange_FullMethodName = "/auth.AuthService/RequestNumberChange"
+	AuthService_ChangeNumber_FullMethodName        = "/auth.AuthService/ChangeNumber"
+	AuthService_StartDeviceLink_FullMethodName     = "/auth.AuthService/StartDeviceLink"
+	AuthService_ApproveDeviceLink_FullMethodName   = "/auth.AuthService/ApproveDeviceLink"
+	AuthService_CompleteDeviceLink_FullMethodName  = "/auth.AuthService/CompleteDeviceLink"
+	AuthService_GetPublicKeys_FullMethodName       = "/auth.AuthService/GetPublicKeys"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -39,8 +54,39 @@ type AuthServiceClient interface {
 	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
 	// Revoke current device by refresh token (single-session logout)
 	RevokeCurrentDevice(ctx context.Context, in *RevokeCurrentDeviceRequest, opts ...grpc.CallOption) (*RevokeResponse, error)
-	// Logout all devices by access token (global logout)
+	// Logout all devices of the caller (global logout). Requires an access token.
 	LogoutAllDevices(ctx context.Context, in *LogoutAllDevicesRequest, opts ...grpc.CallOption) (*RevokeResponse, error)
+	// List the caller's active device sessions
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	// Revoke one of the caller's device sessions and close its realtime stream
+	RevokeDevice(ctx context.Context, in *RevokeDeviceRequest, opts ...grpc.CallOption) (*RevokeResponse, error)
+	// Update name, type or push token of one of the caller's device sessions
+	UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error)
+	// Register the push token of the caller's current device session, used to
+	// notify it of new messages while it has no realtime stream open
+	RegisterPushToken(ctx context.Context, in *RegisterPushTokenRequest, opts ...grpc.CallOption) (*RegisterPushTokenResponse, error)
+	// Finish a login that VerifyOTP answered with pin_required. Public; the challenge token proves the SMS code.
+	VerifyPIN(ctx context.Context, in *VerifyPINRequest, opts ...grpc.CallOption) (*VerifyPINResponse, error)
+	// Forgot the PIN during a challenge: email a reset code to the recovery email and start the reset cooldown. Public.
+	RequestPINReset(ctx context.Context, in *RequestPINResetRequest, opts ...grpc.CallOption) (*RequestPINResetResponse, error)
+	// Turn the PIN off with the emailed code, or without a code once the cooldown has passed, and finish the login. Public.
+	ResetPIN(ctx context.Context, in *ResetPINRequest, opts ...grpc.CallOption) (*VerifyPINResponse, error)
+	// Turn two-step verification on or change the PIN / recovery email. Requires an access token.
+	SetPIN(ctx context.Context, in *SetPINRequest, opts ...grpc.CallOption) (*SetPINResponse, error)
+	// Turn two-step verification off. Requires an access token.
+	DisablePIN(ctx context.Context, in *DisablePINRequest, opts ...grpc.CallOption) (*DisablePINResponse, error)
+	// Send codes to the caller's current number and to the new one. Requires an access token.
+	RequestNumberChange(ctx context.Context, in *RequestNumberChangeRequest, opts ...grpc.CallOption) (*RequestNumberChangeResponse, error)
+	// Move the caller's account to the new number once both codes are verified. Requires an access token.
+	ChangeNumber(ctx context.Context, in *ChangeNumberRequest, opts ...grpc.CallOption) (*ChangeNumberResponse, error)
+	// Start linking a web or desktop device; show qr_payload as a QR code. Public.
+	StartDeviceLink(ctx context.Context, in *StartDeviceLinkRequest, opts ...grpc.CallOption) (*StartDeviceLinkResponse, error)
+	// Approve the device showing the scanned QR code. Requires an access token of a mobile session.
+	ApproveDeviceLink(ctx context.Context, in *ApproveDeviceLinkRequest, opts ...grpc.CallOption) (*ApproveDeviceLinkResponse, error)
+	// Collect the new device's tokens once approved; answers pending until then. Public; the device secret proves the request.
+	CompleteDeviceLink(ctx context.Context, in *CompleteDeviceLinkRequest, opts ...grpc.CallOption) (*CompleteDeviceLinkResponse, error)
+	// Public keys (JWKS) other services use to validate access tokens. Public, no auth required.
+	GetPublicKeys(ctx context.Context, in *GetPublicKeysRequest, opts ...grpc.CallOption) (*GetPublicKeysResponse, error)
 }
 
 type authServiceClient struct {
@@ -111,6 +157,156 @@ func (c *authServiceClient) LogoutAllDevices(ctx context.Context, in *LogoutAllD
 	return out, nil
 }
 
+func (c *authServiceClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeDevice(ctx context.Context, in *RevokeDeviceRequest, opts ...grpc.CallOption) (*RevokeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeResponse)
+	err := c.cc.Invoke(ctx, AuthService_RevokeDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateDeviceResponse)
+	err := c.cc.Invoke(ctx, AuthService_UpdateDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RegisterPushToken(ctx context.Context, in *RegisterPushTokenRequest, opts ...grpc.CallOption) (*RegisterPushTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterPushTokenResponse)
+	err := c.cc.Invoke(ctx, AuthService_RegisterPushToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) VerifyPIN(ctx context.Context, in *VerifyPINRequest, opts ...grpc.CallOption) (*VerifyPINResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyPINResponse)
+	err := c.cc.Invoke(ctx, AuthService_VerifyPIN_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RequestPINReset(ctx context.Context, in *RequestPINResetRequest, opts ...grpc.CallOption) (*RequestPINResetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestPINResetResponse)
+	err := c.cc.Invoke(ctx, AuthService_RequestPINReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ResetPIN(ctx context.Context, in *ResetPINRequest, opts ...grpc.CallOption) (*VerifyPINResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyPINResponse)
+	err := c.cc.Invoke(ctx, AuthService_ResetPIN_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetPIN(ctx context.Context, in *SetPINRequest, opts ...grpc.CallOption) (*SetPINResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPINResponse)
+	err := c.cc.Invoke(ctx, AuthService_SetPIN_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DisablePIN(ctx context.Context, in *DisablePINRequest, opts ...grpc.CallOption) (*DisablePINResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisablePINResponse)
+	err := c.cc.Invoke(ctx, AuthService_DisablePIN_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RequestNumberChange(ctx context.Context, in *RequestNumberChangeRequest, opts ...grpc.CallOption) (*RequestNumberChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestNumberChangeResponse)
+	err := c.cc.Invoke(ctx, AuthService_RequestNumberChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ChangeNumber(ctx context.Context, in *ChangeNumberRequest, opts ...grpc.CallOption) (*ChangeNumberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeNumberResponse)
+	err := c.cc.Invoke(ctx, AuthService_ChangeNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StartDeviceLink(ctx context.Context, in *StartDeviceLinkRequest, opts ...grpc.CallOption) (*StartDeviceLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartDeviceLinkResponse)
+	err := c.cc.Invoke(ctx, AuthService_StartDeviceLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ApproveDeviceLink(ctx context.Context, in *ApproveDeviceLinkRequest, opts ...grpc.CallOption) (*ApproveDeviceLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveDeviceLinkResponse)
+	err := c.cc.Invoke(ctx, AuthService_ApproveDeviceLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CompleteDeviceLink(ctx context.Context, in *CompleteDeviceLinkRequest, opts ...grpc.CallOption) (*CompleteDeviceLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteDeviceLinkResponse)
+	err := c.cc.Invoke(ctx, AuthService_CompleteDeviceLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetPublicKeys(ctx context.Context, in *GetPublicKeysRequest, opts ...grpc.CallOption) (*GetPublicKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPublicKeysResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetPublicKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility.
@@ -123,8 +319,39 @@ type AuthServiceServer interface {
 	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
 	// Revoke current device by refresh token (single-session logout)
 	RevokeCurrentDevice(context.Context, *RevokeCurrentDeviceRequest) (*RevokeResponse, error)
-	// Logout all devices by access token (global logout)
+	// Logout all devices of the caller (global logout). Requires an access token.
 	LogoutAllDevices(context.Context, *LogoutAllDevicesRequest) (*RevokeResponse, error)
+	// List the caller's active device sessions
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	// Revoke one of the caller's device sessions and close its realtime stream
+	RevokeDevice(context.Context, *RevokeDeviceRequest) (*RevokeResponse, error)
+	// Update name, type or push token of one of the caller's device sessions
+	UpdateDevice(context.Context, *UpdateDeviceRequest) (*UpdateDeviceResponse, error)
+	// Register the push token of the caller's current device session, used to
+	// notify it of new messages while it has no realtime stream open
+	RegisterPushToken(context.Context, *RegisterPushTokenRequest) (*RegisterPushTokenResponse, error)
+	// Finish a login that VerifyOTP answered with pin_required. Public; the challenge token proves the SMS code.
+	VerifyPIN(context.Context, *VerifyPINRequest) (*VerifyPINResponse, error)
+	// Forgot the PIN during a challenge: email a reset code to the recovery email and start the reset cooldown. Public.
+	RequestPINReset(context.Context, *RequestPINResetRequest) (*RequestPINResetResponse, error)
+	// Turn the PIN off with the emailed code, or without a code once the cooldown has passed, and finish the login. Public.
+	ResetPIN(context.Context, *ResetPINRequest) (*VerifyPINResponse, error)
+	// Turn two-step verification on or change the PIN / recovery email. Requires an access token.
+	SetPIN(context.Context, *SetPINRequest) (*SetPINResponse, error)
+	// Turn two-step verification off. Requires an access token.
+	DisablePIN(context.Context, *DisablePINRequest) (*DisablePINResponse, error)
+	// Send codes to the caller's current number and to the new one. Requires an access token.
+	RequestNumberChange(context.Context, *RequestNumberChangeRequest) (*RequestNumberChangeResponse, error)
+	// Move the caller's account to the new number once both codes are verified. Requires an access token.
+	ChangeNumber(context.Context, *ChangeNumberRequest) (*ChangeNumberResponse, error)
+	// Start linking a web or desktop device; show qr_payload as a QR code. Public.
+	StartDeviceLink(context.Context, *StartDeviceLinkRequest) (*StartDeviceLinkResponse, error)
+	// Approve the device showing the scanned QR code. Requires an access token of a mobile session.
+	ApproveDeviceLink(context.Context, *ApproveDeviceLinkRequest) (*ApproveDeviceLinkResponse, error)
+	// Collect the new device's tokens once approved; answers pending until then. Public; the device secret proves the request.
+	CompleteDeviceLink(context.Context, *CompleteDeviceLinkRequest) (*CompleteDeviceLinkResponse, error)
+	// Public keys (JWKS) other services use to validate access tokens. Public, no auth required.
+	GetPublicKeys(context.Context, *GetPublicKeysRequest) (*GetPublicKeysResponse, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -153,6 +380,51 @@ func (UnimplementedAuthServiceServer) RevokeCurrentDevice(context.Context, *Revo
 func (UnimplementedAuthServiceServer) LogoutAllDevices(context.Context, *LogoutAllDevicesRequest) (*RevokeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LogoutAllDevices not implemented")
 }
+func (UnimplementedAuthServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeDevice(context.Context, *RevokeDeviceRequest) (*RevokeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeDevice not implemented")
+}
+func (UnimplementedAuthServiceServer) UpdateDevice(context.Context, *UpdateDeviceRequest) (*UpdateDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDevice not implemented")
+}
+func (UnimplementedAuthServiceServer) RegisterPushToken(context.Context, *RegisterPushTokenRequest) (*RegisterPushTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterPushToken not implemented")
+}
+func (UnimplementedAuthServiceServer) VerifyPIN(context.Context, *VerifyPINRequest) (*VerifyPINResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyPIN not implemented")
+}
+func (UnimplementedAuthServiceServer) RequestPINReset(context.Context, *RequestPINResetRequest) (*RequestPINResetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPINReset not implemented")
+}
+func (UnimplementedAuthServiceServer) ResetPIN(context.Context, *ResetPINRequest) (*VerifyPINResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPIN not implemented")
+}
+func (UnimplementedAuthServiceServer) SetPIN(context.Context, *SetPINRequest) (*SetPINResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPIN not implemented")
+}
+func (UnimplementedAuthServiceServer) DisablePIN(context.Context, *DisablePINRequest) (*DisablePINResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisablePIN not implemented")
+}
+func (UnimplementedAuthServiceServer) RequestNumberChange(context.Context, *RequestNumberChangeRequest) (*RequestNumberChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestNumberChange not implemented")
+}
+func (UnimplementedAuthServiceServer) ChangeNumber(context.Context, *ChangeNumberRequest) (*ChangeNumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeNumber not implemented")
+}
+func (UnimplementedAuthServiceServer) StartDeviceLink(context.Context, *StartDeviceLinkRequest) (*StartDeviceLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartDeviceLink not implemented")
+}
+func (UnimplementedAuthServiceServer) ApproveDeviceLink(context.Context, *ApproveDeviceLinkRequest) (*ApproveDeviceLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveDeviceLink not implemented")
+}
+func (UnimplementedAuthServiceServer) CompleteDeviceLink(context.Context, *CompleteDeviceLinkRequest) (*CompleteDeviceLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteDeviceLink not implemented")
+}
+func (UnimplementedAuthServiceServer) GetPublicKeys(context.Context, *GetPublicKeysRequest) (*GetPublicKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKeys not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
@@ -282,6 +554,276 @@ func _AuthService_LogoutAllDevices_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeDevice(ctx, req.(*RevokeDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_UpdateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).UpdateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_UpdateDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).UpdateDevice(ctx, req.(*UpdateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RegisterPushToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterPushTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RegisterPushToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RegisterPushToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RegisterPushToken(ctx, req.(*RegisterPushTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_VerifyPIN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyPINRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifyPIN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_VerifyPIN_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifyPIN(ctx, req.(*VerifyPINRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RequestPINReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPINResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RequestPINReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RequestPINReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RequestPINReset(ctx, req.(*RequestPINResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ResetPIN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPINRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ResetPIN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ResetPIN_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ResetPIN(ctx, req.(*ResetPINRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SetPIN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPINRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SetPIN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SetPIN_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SetPIN(ctx, req.(*SetPINRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DisablePIN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisablePINRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DisablePIN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DisablePIN_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DisablePIN(ctx, req.(*DisablePINRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RequestNumberChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestNumberChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RequestNumberChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RequestNumberChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RequestNumberChange(ctx, req.(*RequestNumberChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ChangeNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ChangeNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ChangeNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ChangeNumber(ctx, req.(*ChangeNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StartDeviceLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartDeviceLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StartDeviceLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_StartDeviceLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StartDeviceLink(ctx, req.(*StartDeviceLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ApproveDeviceLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveDeviceLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ApproveDeviceLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ApproveDeviceLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ApproveDeviceLink(ctx, req.(*ApproveDeviceLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CompleteDeviceLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteDeviceLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CompleteDeviceLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CompleteDeviceLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CompleteDeviceLink(ctx, req.(*CompleteDeviceLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetPublicKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetPublicKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetPublicKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetPublicKeys(ctx, req.(*GetPublicKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -313,6 +855,66 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LogoutAllDevices",
 			Handler:    _AuthService_LogoutAllDevices_Handler,
 		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _AuthService_ListDevices_Handler,
+		},
+		{
+			MethodName: "RevokeDevice",
+			Handler:    _AuthService_RevokeDevice_Handler,
+		},
+		{
+			MethodName: "UpdateDevice",
+			Handler:    _AuthService_UpdateDevice_Handler,
+		},
+		{
+			MethodName: "RegisterPushToken",
+			Handler:    _AuthService_RegisterPushToken_Handler,
+		},
+		{
+			MethodName: "VerifyPIN",
+			Handler:    _AuthService_VerifyPIN_Handler,
+		},
+		{
+			MethodName: "RequestPINReset",
+			Handler:    _AuthService_RequestPINReset_Handler,
+		},
+		{
+			MethodName: "ResetPIN",
+			Handler:    _AuthService_ResetPIN_Handler,
+		},
+		{
+			MethodName: "SetPIN",
+			Handler:    _AuthService_SetPIN_Handler,
+		},
+		{
+			MethodName: "DisablePIN",
+			Handler:    _AuthService_DisablePIN_Handler,
+		},
+		{
+			MethodName: "RequestNumberChange",
+			Handler:    _AuthService_RequestNumberChange_Handler,
+		},
+		{
+			MethodName: "ChangeNumber",
+			Handler:    _AuthService_ChangeNumber_Handler,
+		},
+		{
+			MethodName: "StartDeviceLink",
+			Handler:    _AuthService_StartDeviceLink_Handler,
+		},
+		{
+			MethodName: "ApproveDeviceLink",
+			Handler:    _AuthService_ApproveDeviceLink_Handler,
+		},
+		{
+			MethodName: "CompleteDeviceLink",
+			Handler:    _AuthService_CompleteDeviceLink_Handler,
+		},
+		{
+			MethodName: "GetPublicKeys",
+			Handler:    _AuthService_GetPublicKeys_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/auth.proto",
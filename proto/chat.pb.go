@@ -22,14 +22,20 @@ const (
 )
 
 type Conversation struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ParticipantIds []string               `protobuf:"bytes,2,rep,name=participant_ids,json=participantIds,proto3" json:"participant_ids,omitempty"`
-	CreatedAt      string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	IsGroup        bool                   `protobuf:"varint,4,opt,name=is_group,json=isGroup,proto3" json:"is_group,omitempty"`      // Whether this is a group conversation
-	GroupName      string                 `protobuf:"bytes,5,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"` // Optional group name
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParticipantIds     []string               `protobuf:"bytes,2,rep,name=participant_ids,json=participantIds,proto3" json:"participant_ids,omitempty"`
+	CreatedAt          string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	IsGroup            bool                   `protobuf:"varint,4,opt,name=is_group,json=isGroup,proto3" json:"is_group,omitempty"`         // Whether this is a group conversation
+	GroupName          string                 `protobuf:"bytes,5,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`    // Optional group name
+	MutedUntil         string                 `protobuf:"bytes,6,opt,name=muted_until,json=mutedUntil,proto3" json:"muted_until,omitempty"` // RFC3339; set while the caller has the conversation muted
+	AdminIds           []string               `protobuf:"bytes,7,rep,name=admin_ids,json=adminIds,proto3" json:"admin_ids,omitempty"`       // group admins, longest-standing first
+	GroupDescription   string                 `protobuf:"bytes,8,opt,name=group_description,json=groupDescription,proto3" json:"group_description,omitempty"`
+	GroupIconUrl       string                 `protobuf:"bytes,9,opt,name=group_icon_url,json=groupIconUrl,proto3" json:"group_icon_url,omitempty"`
+	OnlyAdminsEditInfo bool                   `protobuf:"varint,10,opt,name=only_admins_edit_info,json=onlyAdminsEditInfo,proto3" json:"only_admins_edit_info,omitempty"` // else any member may change the group info
+	AnnouncementMode   bool                   `protobuf:"varint,11,opt,name=announcement_mode,json=announcementMode,proto3" json:"announcement_mode,omitempty"`           // only admins may send messages
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *Conversation) Reset() {
@@ -97,6 +103,48 @@ func (x *Conversation) GetGroupName() string {
 	return ""
 }
 
+func (x *Conversation) GetMutedUntil() string {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return ""
+}
+
+func (x *Conversation) GetAdminIds() []string {
+	if x != nil {
+		return x.AdminIds
+	}
+	return nil
+}
+
+func (x *Conversation) GetGroupDescription() string {
+	if x != nil {
+		return x.GroupDescription
+	}
+	return ""
+}
+
+func (x *Conversation) GetGroupIconUrl() string {
+	if x != nil {
+		return x.GroupIconUrl
+	}
+	return ""
+}
+
+func (x *Conversation) GetOnlyAdminsEditInfo() bool {
+	if x != nil {
+		return x.OnlyAdminsEditInfo
+	}
+	return false
+}
+
+func (x *Conversation) GetAnnouncementMode() bool {
+	if x != nil {
+		return x.AnnouncementMode
+	}
+	return false
+}
+
 type Message struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -107,6 +155,12 @@ type Message struct {
 	MediaType       string                 `protobuf:"bytes,6,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
 	ClientMessageId string                 `protobuf:"bytes,7,opt,name=client_message_id,json=clientMessageId,proto3" json:"client_message_id,omitempty"`
 	CreatedAt       string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	IsSystem        bool                   `protobuf:"varint,9,opt,name=is_system,json=isSystem,proto3" json:"is_system,omitempty"`    // system message: sender_id is the user it is about, content says what happened
+	EditedAt        string                 `protobuf:"bytes,10,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`    // RFC3339; set once the content was edited
+	DeletedAt       string                 `protobuf:"bytes,11,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"` // RFC3339; set when deleted for everyone, content and media are then empty
+	DeletedBy       string                 `protobuf:"bytes,12,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"` // the sender or the group admin who deleted it
+	Reactions       []*Reaction            `protobuf:"bytes,13,rep,name=reactions,proto3" json:"reactions,omitempty"`                  // most frequent first
+	ReplyTo         *QuotedMessage         `protobuf:"bytes,14,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`       // set on replies
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -197,88 +251,76 @@ func (x *Message) GetCreatedAt() string {
 	return ""
 }
 
-type CreateConversationRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	ParticipantIds []string               `protobuf:"bytes,1,rep,name=participant_ids,json=participantIds,proto3" json:"participant_ids,omitempty"` // include caller
-	IsGroup        bool                   `protobuf:"varint,2,opt,name=is_group,json=isGroup,proto3" json:"is_group,omitempty"`                     // Whether to create a group conversation
-	GroupName      string                 `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`                // Optional group name for group conversations
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
-}
-
-func (x *CreateConversationRequest) Reset() {
-	*x = CreateConversationRequest{}
-	mi := &file_proto_chat_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CreateConversationRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Message) GetIsSystem() bool {
+	if x != nil {
+		return x.IsSystem
+	}
+	return false
 }
 
-func (*CreateConversationRequest) ProtoMessage() {}
-
-func (x *CreateConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_proto_msgTypes[2]
+func (x *Message) GetEditedAt() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.EditedAt
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use CreateConversationRequest.ProtoReflect.Descriptor instead.
-func (*CreateConversationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_chat_proto_rawDescGZIP(), []int{2}
+func (x *Message) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
 }
 
-func (x *CreateConversationRequest) GetParticipantIds() []string {
+func (x *Message) GetDeletedBy() string {
 	if x != nil {
-		return x.ParticipantIds
+		return x.DeletedBy
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateConversationRequest) GetIsGroup() bool {
+func (x *Message) GetReactions() []*Reaction {
 	if x != nil {
-		return x.IsGroup
+		return x.Reactions
 	}
-	return false
+	return nil
 }
 
-func (x *CreateConversationRequest) GetGroupName() string {
+func (x *Message) GetReplyTo() *QuotedMessage {
 	if x != nil {
-		return x.GroupName
+		return x.ReplyTo
 	}
-	return ""
+	return nil
 }
 
-type CreateConversationResponse struct {
+// QuotedMessage previews the message a reply quotes. snippet and media_type
+// are empty once the quoted message was deleted.
+type QuotedMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Conversation  *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	SenderId      string                 `protobuf:"bytes,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"` // empty for deleted accounts
+	Snippet       string                 `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"`                   // start of the content
+	MediaType     string                 `protobuf:"bytes,4,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	Deleted       bool                   `protobuf:"varint,5,opt,name=deleted,proto3" json:"deleted,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateConversationResponse) Reset() {
-	*x = CreateConversationResponse{}
-	mi := &file_proto_chat_proto_msgTypes[3]
+func (x *QuotedMessage) Reset() {
+	*x = QuotedMessage{}
+	mi := &file_proto_chat_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateConversationResponse) String() string {
+func (x *QuotedMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateConversationResponse) ProtoMessage() {}
+func (*QuotedMessage) ProtoMessage() {}
 
-func (x *CreateConversationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_proto_msgTypes[3]
+func (x *QuotedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -289,44 +331,76 @@ func (x *CreateConversationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateConversationResponse.ProtoReflect.Descriptor instead.
-func (*CreateConversationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_chat_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use QuotedMessage.ProtoReflect.Descriptor instead.
+func (*QuotedMessage) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CreateConversationResponse) GetConversation() *Conversation {
+func (x *QuotedMessage) GetMessageId() string {
 	if x != nil {
-		return x.Conversation
+		return x.MessageId
 	}
-	return nil
+	return ""
 }
 
-type SendMessageRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ConversationId  string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
-	Content         string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	MediaUrl        string                 `protobuf:"bytes,3,opt,name=media_url,json=mediaUrl,proto3" json:"media_url,omitempty"`
-	MediaType       string                 `protobuf:"bytes,4,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
-	ClientMessageId string                 `protobuf:"bytes,5,opt,name=client_message_id,json=clientMessageId,proto3" json:"client_message_id,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *QuotedMessage) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
 }
 
-func (x *SendMessageRequest) Reset() {
-	*x = SendMessageRequest{}
-	mi := &file_proto_chat_proto_msgTypes[4]
+func (x *QuotedMessage) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *QuotedMessage) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *QuotedMessage) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// GroupInvite is an invite link to a group; clients build the link from token.
+type GroupInvite struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Token            string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ConversationId   string                 `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	CreatedBy        string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"` // empty once the admin's account is deleted
+	ExpiresAt        string                 `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC3339; empty if it never expires
+	MaxUses          int32                  `protobuf:"varint,5,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`      // 0 is unlimited
+	UseCount         int32                  `protobuf:"varint,6,opt,name=use_count,json=useCount,proto3" json:"use_count,omitempty"`
+	RequiresApproval bool                   `protobuf:"varint,7,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval,omitempty"` // joining files a join request for the admins
+	CreatedAt        string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GroupInvite) Reset() {
+	*x = GroupInvite{}
+	mi := &file_proto_chat_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendMessageRequest) String() string {
+func (x *GroupInvite) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendMessageRequest) ProtoMessage() {}
+func (*GroupInvite) ProtoMessage() {}
 
-func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_proto_msgTypes[4]
+func (x *GroupInvite) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -337,68 +411,91 @@ func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
-func (*SendMessageRequest) Descriptor() ([]byte, []int) {
-	return file_proto_chat_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GroupInvite.ProtoReflect.Descriptor instead.
+func (*GroupInvite) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *SendMessageRequest) GetConversationId() string {
+func (x *GroupInvite) GetToken() string {
 	if x != nil {
-		return x.ConversationId
+		return x.Token
 	}
 	return ""
 }
 
-func (x *SendMessageRequest) GetContent() string {
+func (x *GroupInvite) GetConversationId() string {
 	if x != nil {
-		return x.Content
+		return x.ConversationId
 	}
 	return ""
 }
 
-func (x *SendMessageRequest) GetMediaUrl() string {
+func (x *GroupInvite) GetCreatedBy() string {
 	if x != nil {
-		return x.MediaUrl
+		return x.CreatedBy
 	}
 	return ""
 }
 
-func (x *SendMessageRequest) GetMediaType() string {
+func (x *GroupInvite) GetExpiresAt() string {
 	if x != nil {
-		return x.MediaType
+		return x.ExpiresAt
 	}
 	return ""
 }
 
-func (x *SendMessageRequest) GetClientMessageId() string {
+func (x *GroupInvite) GetMaxUses() int32 {
 	if x != nil {
-		return x.ClientMessageId
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *GroupInvite) GetUseCount() int32 {
+	if x != nil {
+		return x.UseCount
+	}
+	return 0
+}
+
+func (x *GroupInvite) GetRequiresApproval() bool {
+	if x != nil {
+		return x.RequiresApproval
+	}
+	return false
+}
+
+func (x *GroupInvite) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return ""
 }
 
-type SendMessageResponse struct {
+// JoinRequest is a pending request to join a group through an invite.
+type JoinRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RequestedAt   string                 `protobuf:"bytes,2,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"` // RFC3339
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SendMessageResponse) Reset() {
-	*x = SendMessageResponse{}
-	mi := &file_proto_chat_proto_msgTypes[5]
+func (x *JoinRequest) Reset() {
+	*x = JoinRequest{}
+	mi := &file_proto_chat_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendMessageResponse) String() string {
+func (x *JoinRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendMessageResponse) ProtoMessage() {}
+func (*JoinRequest) ProtoMessage() {}
 
-func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_proto_msgTypes[5]
+func (x *JoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -409,42 +506,50 @@ func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
-func (*SendMessageResponse) Descriptor() ([]byte, []int) {
-	return file_proto_chat_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
+func (*JoinRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *SendMessageResponse) GetMessage() *Message {
+func (x *JoinRequest) GetUserId() string {
 	if x != nil {
-		return x.Message
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-type ListMessagesRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ConversationId  string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
-	BeforeMessageId string                 `protobuf:"bytes,2,opt,name=before_message_id,json=beforeMessageId,proto3" json:"before_message_id,omitempty"` // optional
-	Limit           int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                             // default 50
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *JoinRequest) GetRequestedAt() string {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return ""
 }
 
-func (x *ListMessagesRequest) Reset() {
-	*x = ListMessagesRequest{}
-	mi := &file_proto_chat_proto_msgTypes[6]
+// Reaction counts the participants who reacted to a message with one emoji.
+type Reaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emoji         string                 `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	UserIds       []string               `protobuf:"bytes,3,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reaction) Reset() {
+	*x = Reaction{}
+	mi := &file_proto_chat_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListMessagesRequest) String() string {
+func (x *Reaction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListMessagesRequest) ProtoMessage() {}
+func (*Reaction) ProtoMessage() {}
 
-func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_proto_msgTypes[6]
+func (x *Reaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -455,54 +560,56 @@ func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListMessagesRequest.ProtoReflect.Descriptor instead.
-func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_chat_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use Reaction.ProtoReflect.Descriptor instead.
+func (*Reaction) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ListMessagesRequest) GetConversationId() string {
+func (x *Reaction) GetEmoji() string {
 	if x != nil {
-		return x.ConversationId
+		return x.Emoji
 	}
 	return ""
 }
 
-func (x *ListMessagesRequest) GetBeforeMessageId() string {
+func (x *Reaction) GetCount() int32 {
 	if x != nil {
-		return x.BeforeMessageId
+		return x.Count
 	}
-	return ""
+	return 0
 }
 
-func (x *ListMessagesRequest) GetLimit() int32 {
+func (x *Reaction) GetUserIds() []string {
 	if x != nil {
-		return x.Limit
+		return x.UserIds
 	}
-	return 0
+	return nil
 }
 
-type ListMessagesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type CreateConversationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ParticipantIds []string               `protobuf:"bytes,1,rep,name=participant_ids,json=participantIds,proto3" json:"participant_ids,omitempty"` // include caller
+	IsGroup        bool                   `protobuf:"varint,2,opt,name=is_group,json=isGroup,proto3" json:"is_group,omitempty"`                     // Whether to create a group conversation
+	GroupName      string                 `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`                // Optional group name for group conversations
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *ListMessagesResponse) Reset() {
-	*x = ListMessagesResponse{}
-	mi := &file_proto_chat_proto_msgTypes[7]
+func (x *CreateConversationRequest) Reset() {
+	*x = CreateConversationRequest{}
+	mi := &file_proto_chat_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListMessagesResponse) String() string {
+func (x *CreateConversationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListMessagesResponse) ProtoMessage() {}
+func (*CreateConversationRequest) ProtoMessage() {}
 
-func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_proto_msgTypes[7]
+func (x *CreateConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -513,39 +620,1844 @@ func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListMessagesResponse.ProtoReflect.Descriptor instead.
-func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_chat_proto_rawDescGZIP(), []int{7}
-}
+// Deprecated: Use CreateConversationRequest.ProtoReflect.Descriptor instead.
+func (*CreateConversationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateConversationRequest) GetParticipantIds() []string {
+	if x != nil {
+		return x.ParticipantIds
+	}
+	return nil
+}
+
+func (x *CreateConversationRequest) GetIsGroup() bool {
+	if x != nil {
+		return x.IsGroup
+	}
+	return false
+}
+
+func (x *CreateConversationRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+type CreateConversationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversation  *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConversationResponse) Reset() {
+	*x = CreateConversationResponse{}
+	mi := &file_proto_chat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConversationResponse) ProtoMessage() {}
+
+func (x *CreateConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConversationResponse.ProtoReflect.Descriptor instead.
+func (*CreateConversationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateConversationResponse) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+type SendMessageRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId   string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Content          string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	MediaUrl         string                 `protobuf:"bytes,3,opt,name=media_url,json=mediaUrl,proto3" json:"media_url,omitempty"`
+	MediaType        string                 `protobuf:"bytes,4,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	ClientMessageId  string                 `protobuf:"bytes,5,opt,name=client_message_id,json=clientMessageId,proto3" json:"client_message_id,omitempty"`
+	ReplyToMessageId string                 `protobuf:"bytes,6,opt,name=reply_to_message_id,json=replyToMessageId,proto3" json:"reply_to_message_id,omitempty"` // optional; a message of the same conversation
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SendMessageRequest) Reset() {
+	*x = SendMessageRequest{}
+	mi := &file_proto_chat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageRequest) ProtoMessage() {}
+
+func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SendMessageRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetMediaUrl() string {
+	if x != nil {
+		return x.MediaUrl
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetClientMessageId() string {
+	if x != nil {
+		return x.ClientMessageId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetReplyToMessageId() string {
+	if x != nil {
+		return x.ReplyToMessageId
+	}
+	return ""
+}
+
+type SendMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageResponse) Reset() {
+	*x = SendMessageResponse{}
+	mi := &file_proto_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageResponse) ProtoMessage() {}
+
+func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
+func (*SendMessageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SendMessageResponse) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type ListMessagesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId  string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	BeforeMessageId string                 `protobuf:"bytes,2,opt,name=before_message_id,json=beforeMessageId,proto3" json:"before_message_id,omitempty"` // optional
+	Limit           int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                             // default 50
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListMessagesRequest) Reset() {
+	*x = ListMessagesRequest{}
+	mi := &file_proto_chat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesRequest) ProtoMessage() {}
+
+func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListMessagesRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ListMessagesRequest) GetBeforeMessageId() string {
+	if x != nil {
+		return x.BeforeMessageId
+	}
+	return ""
+}
+
+func (x *ListMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessagesResponse) Reset() {
+	*x = ListMessagesResponse{}
+	mi := &file_proto_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesResponse) ProtoMessage() {}
+
+func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type GetConversationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConversationsRequest) Reset() {
+	*x = GetConversationsRequest{}
+	mi := &file_proto_chat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConversationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConversationsRequest) ProtoMessage() {}
+
+func (x *GetConversationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConversationsRequest.ProtoReflect.Descriptor instead.
+func (*GetConversationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{12}
+}
+
+type GetConversationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversations []*Conversation        `protobuf:"bytes,1,rep,name=conversations,proto3" json:"conversations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConversationsResponse) Reset() {
+	*x = GetConversationsResponse{}
+	mi := &file_proto_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConversationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConversationsResponse) ProtoMessage() {}
+
+func (x *GetConversationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConversationsResponse.ProtoReflect.Descriptor instead.
+func (*GetConversationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetConversationsResponse) GetConversations() []*Conversation {
+	if x != nil {
+		return x.Conversations
+	}
+	return nil
+}
+
+type MuteConversationRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId  string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	DurationSeconds int64                  `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // 0 unmutes unless forever is set
+	Forever         bool                   `protobuf:"varint,3,opt,name=forever,proto3" json:"forever,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MuteConversationRequest) Reset() {
+	*x = MuteConversationRequest{}
+	mi := &file_proto_chat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteConversationRequest) ProtoMessage() {}
+
+func (x *MuteConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteConversationRequest.ProtoReflect.Descriptor instead.
+func (*MuteConversationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MuteConversationRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *MuteConversationRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *MuteConversationRequest) GetForever() bool {
+	if x != nil {
+		return x.Forever
+	}
+	return false
+}
+
+type MuteConversationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MutedUntil    string                 `protobuf:"bytes,1,opt,name=muted_until,json=mutedUntil,proto3" json:"muted_until,omitempty"` // RFC3339; empty when not muted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteConversationResponse) Reset() {
+	*x = MuteConversationResponse{}
+	mi := &file_proto_chat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteConversationResponse) ProtoMessage() {}
+
+func (x *MuteConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteConversationResponse.ProtoReflect.Descriptor instead.
+func (*MuteConversationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MuteConversationResponse) GetMutedUntil() string {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return ""
+}
+
+type EditMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageRequest) Reset() {
+	*x = EditMessageRequest{}
+	mi := &file_proto_chat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageRequest) ProtoMessage() {}
+
+func (x *EditMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageRequest.ProtoReflect.Descriptor instead.
+func (*EditMessageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EditMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type EditMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageResponse) Reset() {
+	*x = EditMessageResponse{}
+	mi := &file_proto_chat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageResponse) ProtoMessage() {}
+
+func (x *EditMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageResponse.ProtoReflect.Descriptor instead.
+func (*EditMessageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EditMessageResponse) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type DeleteMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ForEveryone   bool                   `protobuf:"varint,2,opt,name=for_everyone,json=forEveryone,proto3" json:"for_everyone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageRequest) Reset() {
+	*x = DeleteMessageRequest{}
+	mi := &file_proto_chat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageRequest) ProtoMessage() {}
+
+func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *DeleteMessageRequest) GetForEveryone() bool {
+	if x != nil {
+		return x.ForEveryone
+	}
+	return false
+}
+
+type DeleteMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageResponse) Reset() {
+	*x = DeleteMessageResponse{}
+	mi := &file_proto_chat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageResponse) ProtoMessage() {}
+
+func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMessageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{19}
+}
+
+type ReactToMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,2,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactToMessageRequest) Reset() {
+	*x = ReactToMessageRequest{}
+	mi := &file_proto_chat_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactToMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactToMessageRequest) ProtoMessage() {}
+
+func (x *ReactToMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactToMessageRequest.ProtoReflect.Descriptor instead.
+func (*ReactToMessageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReactToMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReactToMessageRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type ReactToMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reactions     []*Reaction            `protobuf:"bytes,1,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactToMessageResponse) Reset() {
+	*x = ReactToMessageResponse{}
+	mi := &file_proto_chat_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactToMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactToMessageResponse) ProtoMessage() {}
+
+func (x *ReactToMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactToMessageResponse.ProtoReflect.Descriptor instead.
+func (*ReactToMessageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReactToMessageResponse) GetReactions() []*Reaction {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+type RemoveReactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveReactionRequest) Reset() {
+	*x = RemoveReactionRequest{}
+	mi := &file_proto_chat_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReactionRequest) ProtoMessage() {}
+
+func (x *RemoveReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReactionRequest.ProtoReflect.Descriptor instead.
+func (*RemoveReactionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RemoveReactionRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type RemoveReactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reactions     []*Reaction            `protobuf:"bytes,1,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveReactionResponse) Reset() {
+	*x = RemoveReactionResponse{}
+	mi := &file_proto_chat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveReactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReactionResponse) ProtoMessage() {}
+
+func (x *RemoveReactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReactionResponse.ProtoReflect.Descriptor instead.
+func (*RemoveReactionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RemoveReactionResponse) GetReactions() []*Reaction {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+type AddMembersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserIds        []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AddMembersRequest) Reset() {
+	*x = AddMembersRequest{}
+	mi := &file_proto_chat_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMembersRequest) ProtoMessage() {}
+
+func (x *AddMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMembersRequest.ProtoReflect.Descriptor instead.
+func (*AddMembersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AddMembersRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *AddMembersRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type AddMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AddedUserIds  []string               `protobuf:"bytes,1,rep,name=added_user_ids,json=addedUserIds,proto3" json:"added_user_ids,omitempty"` // unknown users and existing members are skipped
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMembersResponse) Reset() {
+	*x = AddMembersResponse{}
+	mi := &file_proto_chat_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMembersResponse) ProtoMessage() {}
+
+func (x *AddMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMembersResponse.ProtoReflect.Descriptor instead.
+func (*AddMembersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AddMembersResponse) GetAddedUserIds() []string {
+	if x != nil {
+		return x.AddedUserIds
+	}
+	return nil
+}
+
+type RemoveMemberRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
+	mi := &file_proto_chat_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberRequest) ProtoMessage() {}
+
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RemoveMemberRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *RemoveMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveMemberResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PromotedUserId string                 `protobuf:"bytes,1,opt,name=promoted_user_id,json=promotedUserId,proto3" json:"promoted_user_id,omitempty"` // set when the caller removed themselves as the last admin
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RemoveMemberResponse) Reset() {
+	*x = RemoveMemberResponse{}
+	mi := &file_proto_chat_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberResponse) ProtoMessage() {}
+
+func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RemoveMemberResponse) GetPromotedUserId() string {
+	if x != nil {
+		return x.PromotedUserId
+	}
+	return ""
+}
+
+type LeaveGroupRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LeaveGroupRequest) Reset() {
+	*x = LeaveGroupRequest{}
+	mi := &file_proto_chat_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveGroupRequest) ProtoMessage() {}
+
+func (x *LeaveGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveGroupRequest.ProtoReflect.Descriptor instead.
+func (*LeaveGroupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *LeaveGroupRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type LeaveGroupResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PromotedUserId string                 `protobuf:"bytes,1,opt,name=promoted_user_id,json=promotedUserId,proto3" json:"promoted_user_id,omitempty"` // set when the caller was the last admin
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LeaveGroupResponse) Reset() {
+	*x = LeaveGroupResponse{}
+	mi := &file_proto_chat_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveGroupResponse) ProtoMessage() {}
+
+func (x *LeaveGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveGroupResponse.ProtoReflect.Descriptor instead.
+func (*LeaveGroupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LeaveGroupResponse) GetPromotedUserId() string {
+	if x != nil {
+		return x.PromotedUserId
+	}
+	return ""
+}
+
+type PromoteAdminRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PromoteAdminRequest) Reset() {
+	*x = PromoteAdminRequest{}
+	mi := &file_proto_chat_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteAdminRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteAdminRequest) ProtoMessage() {}
+
+func (x *PromoteAdminRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteAdminRequest.ProtoReflect.Descriptor instead.
+func (*PromoteAdminRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PromoteAdminRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *PromoteAdminRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type PromoteAdminResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteAdminResponse) Reset() {
+	*x = PromoteAdminResponse{}
+	mi := &file_proto_chat_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteAdminResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteAdminResponse) ProtoMessage() {}
+
+func (x *PromoteAdminResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteAdminResponse.ProtoReflect.Descriptor instead.
+func (*PromoteAdminResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{31}
+}
+
+type DemoteAdminRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DemoteAdminRequest) Reset() {
+	*x = DemoteAdminRequest{}
+	mi := &file_proto_chat_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DemoteAdminRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DemoteAdminRequest) ProtoMessage() {}
+
+func (x *DemoteAdminRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DemoteAdminRequest.ProtoReflect.Descriptor instead.
+func (*DemoteAdminRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DemoteAdminRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *DemoteAdminRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DemoteAdminResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DemoteAdminResponse) Reset() {
+	*x = DemoteAdminResponse{}
+	mi := &file_proto_chat_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DemoteAdminResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DemoteAdminResponse) ProtoMessage() {}
+
+func (x *DemoteAdminResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DemoteAdminResponse.ProtoReflect.Descriptor instead.
+func (*DemoteAdminResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{33}
+}
+
+// Unset fields are left unchanged.
+type UpdateGroupInfoRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId   string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	GroupName        *string                `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3,oneof" json:"group_name,omitempty"`                      // 1-100 characters
+	GroupDescription *string                `protobuf:"bytes,3,opt,name=group_description,json=groupDescription,proto3,oneof" json:"group_description,omitempty"` // up to 512 characters, or empty to remove
+	GroupIconUrl     *string                `protobuf:"bytes,4,opt,name=group_icon_url,json=groupIconUrl,proto3,oneof" json:"group_icon_url,omitempty"`           // http(s) URL, or empty to remove
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateGroupInfoRequest) Reset() {
+	*x = UpdateGroupInfoRequest{}
+	mi := &file_proto_chat_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGroupInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGroupInfoRequest) ProtoMessage() {}
+
+func (x *UpdateGroupInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGroupInfoRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGroupInfoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateGroupInfoRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *UpdateGroupInfoRequest) GetGroupName() string {
+	if x != nil && x.GroupName != nil {
+		return *x.GroupName
+	}
+	return ""
+}
+
+func (x *UpdateGroupInfoRequest) GetGroupDescription() string {
+	if x != nil && x.GroupDescription != nil {
+		return *x.GroupDescription
+	}
+	return ""
+}
+
+func (x *UpdateGroupInfoRequest) GetGroupIconUrl() string {
+	if x != nil && x.GroupIconUrl != nil {
+		return *x.GroupIconUrl
+	}
+	return ""
+}
+
+type UpdateGroupInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversation  *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGroupInfoResponse) Reset() {
+	*x = UpdateGroupInfoResponse{}
+	mi := &file_proto_chat_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGroupInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGroupInfoResponse) ProtoMessage() {}
+
+func (x *UpdateGroupInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGroupInfoResponse.ProtoReflect.Descriptor instead.
+func (*UpdateGroupInfoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpdateGroupInfoResponse) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+// Unset fields are left unchanged.
+type UpdateGroupSettingsRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId     string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	OnlyAdminsEditInfo *bool                  `protobuf:"varint,2,opt,name=only_admins_edit_info,json=onlyAdminsEditInfo,proto3,oneof" json:"only_admins_edit_info,omitempty"`
+	AnnouncementMode   *bool                  `protobuf:"varint,3,opt,name=announcement_mode,json=announcementMode,proto3,oneof" json:"announcement_mode,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UpdateGroupSettingsRequest) Reset() {
+	*x = UpdateGroupSettingsRequest{}
+	mi := &file_proto_chat_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGroupSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGroupSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateGroupSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGroupSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGroupSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UpdateGroupSettingsRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *UpdateGroupSettingsRequest) GetOnlyAdminsEditInfo() bool {
+	if x != nil && x.OnlyAdminsEditInfo != nil {
+		return *x.OnlyAdminsEditInfo
+	}
+	return false
+}
+
+func (x *UpdateGroupSettingsRequest) GetAnnouncementMode() bool {
+	if x != nil && x.AnnouncementMode != nil {
+		return *x.AnnouncementMode
+	}
+	return false
+}
+
+type UpdateGroupSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversation  *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGroupSettingsResponse) Reset() {
+	*x = UpdateGroupSettingsResponse{}
+	mi := &file_proto_chat_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGroupSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGroupSettingsResponse) ProtoMessage() {}
+
+func (x *UpdateGroupSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGroupSettingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateGroupSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UpdateGroupSettingsResponse) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+type CreateInviteRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId   string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	ExpiresInSeconds int64                  `protobuf:"varint,2,opt,name=expires_in_seconds,json=expiresInSeconds,proto3" json:"expires_in_seconds,omitempty"` // 0 never expires
+	MaxUses          int32                  `protobuf:"varint,3,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`                              // 0 is unlimited
+	RequiresApproval bool                   `protobuf:"varint,4,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateInviteRequest) Reset() {
+	*x = CreateInviteRequest{}
+	mi := &file_proto_chat_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteRequest) ProtoMessage() {}
+
+func (x *CreateInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInviteRequest.ProtoReflect.Descriptor instead.
+func (*CreateInviteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateInviteRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *CreateInviteRequest) GetExpiresInSeconds() int64 {
+	if x != nil {
+		return x.ExpiresInSeconds
+	}
+	return 0
+}
+
+func (x *CreateInviteRequest) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *CreateInviteRequest) GetRequiresApproval() bool {
+	if x != nil {
+		return x.RequiresApproval
+	}
+	return false
+}
+
+type CreateInviteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invite        *GroupInvite           `protobuf:"bytes,1,opt,name=invite,proto3" json:"invite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInviteResponse) Reset() {
+	*x = CreateInviteResponse{}
+	mi := &file_proto_chat_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteResponse) ProtoMessage() {}
+
+func (x *CreateInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInviteResponse.ProtoReflect.Descriptor instead.
+func (*CreateInviteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateInviteResponse) GetInvite() *GroupInvite {
+	if x != nil {
+		return x.Invite
+	}
+	return nil
+}
+
+type ListInvitesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListInvitesRequest) Reset() {
+	*x = ListInvitesRequest{}
+	mi := &file_proto_chat_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitesRequest) ProtoMessage() {}
+
+func (x *ListInvitesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitesRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListInvitesRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type ListInvitesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invites       []*GroupInvite         `protobuf:"bytes,1,rep,name=invites,proto3" json:"invites,omitempty"` // not revoked, newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitesResponse) Reset() {
+	*x = ListInvitesResponse{}
+	mi := &file_proto_chat_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitesResponse) ProtoMessage() {}
+
+func (x *ListInvitesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitesResponse.ProtoReflect.Descriptor instead.
+func (*ListInvitesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListInvitesResponse) GetInvites() []*GroupInvite {
+	if x != nil {
+		return x.Invites
+	}
+	return nil
+}
+
+type RevokeInviteRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Token          string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RevokeInviteRequest) Reset() {
+	*x = RevokeInviteRequest{}
+	mi := &file_proto_chat_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInviteRequest) ProtoMessage() {}
+
+func (x *RevokeInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInviteRequest.ProtoReflect.Descriptor instead.
+func (*RevokeInviteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RevokeInviteRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *RevokeInviteRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type RevokeInviteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-func (x *ListMessagesResponse) GetMessages() []*Message {
+func (x *RevokeInviteResponse) Reset() {
+	*x = RevokeInviteResponse{}
+	mi := &file_proto_chat_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInviteResponse) ProtoMessage() {}
+
+func (x *RevokeInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[43]
 	if x != nil {
-		return x.Messages
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type GetConversationsRequest struct {
+// Deprecated: Use RevokeInviteResponse.ProtoReflect.Descriptor instead.
+func (*RevokeInviteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{43}
+}
+
+type JoinViaInviteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConversationsRequest) Reset() {
-	*x = GetConversationsRequest{}
-	mi := &file_proto_chat_proto_msgTypes[8]
+func (x *JoinViaInviteRequest) Reset() {
+	*x = JoinViaInviteRequest{}
+	mi := &file_proto_chat_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConversationsRequest) String() string {
+func (x *JoinViaInviteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConversationsRequest) ProtoMessage() {}
+func (*JoinViaInviteRequest) ProtoMessage() {}
 
-func (x *GetConversationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_proto_msgTypes[8]
+func (x *JoinViaInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -556,33 +2468,41 @@ func (x *GetConversationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConversationsRequest.ProtoReflect.Descriptor instead.
-func (*GetConversationsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_chat_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use JoinViaInviteRequest.ProtoReflect.Descriptor instead.
+func (*JoinViaInviteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{44}
 }
 
-type GetConversationsResponse struct {
+func (x *JoinViaInviteRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type JoinViaInviteResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Conversations []*Conversation        `protobuf:"bytes,1,rep,name=conversations,proto3" json:"conversations,omitempty"`
+	Conversation  *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"` // without participants while pending
+	Pending       bool                   `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`          // a join request awaits an admin's approval
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConversationsResponse) Reset() {
-	*x = GetConversationsResponse{}
-	mi := &file_proto_chat_proto_msgTypes[9]
+func (x *JoinViaInviteResponse) Reset() {
+	*x = JoinViaInviteResponse{}
+	mi := &file_proto_chat_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConversationsResponse) String() string {
+func (x *JoinViaInviteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConversationsResponse) ProtoMessage() {}
+func (*JoinViaInviteResponse) ProtoMessage() {}
 
-func (x *GetConversationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_proto_msgTypes[9]
+func (x *JoinViaInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -593,23 +2513,294 @@ func (x *GetConversationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConversationsResponse.ProtoReflect.Descriptor instead.
-func (*GetConversationsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_chat_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use JoinViaInviteResponse.ProtoReflect.Descriptor instead.
+func (*JoinViaInviteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetConversationsResponse) GetConversations() []*Conversation {
+func (x *JoinViaInviteResponse) GetConversation() *Conversation {
 	if x != nil {
-		return x.Conversations
+		return x.Conversation
+	}
+	return nil
+}
+
+func (x *JoinViaInviteResponse) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+type ListJoinRequestsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListJoinRequestsRequest) Reset() {
+	*x = ListJoinRequestsRequest{}
+	mi := &file_proto_chat_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJoinRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJoinRequestsRequest) ProtoMessage() {}
+
+func (x *ListJoinRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJoinRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListJoinRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListJoinRequestsRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type ListJoinRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*JoinRequest         `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"` // oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJoinRequestsResponse) Reset() {
+	*x = ListJoinRequestsResponse{}
+	mi := &file_proto_chat_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJoinRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJoinRequestsResponse) ProtoMessage() {}
+
+func (x *ListJoinRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJoinRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListJoinRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListJoinRequestsResponse) GetRequests() []*JoinRequest {
+	if x != nil {
+		return x.Requests
 	}
 	return nil
 }
 
+type ApproveJoinRequestRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ApproveJoinRequestRequest) Reset() {
+	*x = ApproveJoinRequestRequest{}
+	mi := &file_proto_chat_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveJoinRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveJoinRequestRequest) ProtoMessage() {}
+
+func (x *ApproveJoinRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveJoinRequestRequest.ProtoReflect.Descriptor instead.
+func (*ApproveJoinRequestRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ApproveJoinRequestRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ApproveJoinRequestRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ApproveJoinRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveJoinRequestResponse) Reset() {
+	*x = ApproveJoinRequestResponse{}
+	mi := &file_proto_chat_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveJoinRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveJoinRequestResponse) ProtoMessage() {}
+
+func (x *ApproveJoinRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveJoinRequestResponse.ProtoReflect.Descriptor instead.
+func (*ApproveJoinRequestResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{49}
+}
+
+type DeclineJoinRequestRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeclineJoinRequestRequest) Reset() {
+	*x = DeclineJoinRequestRequest{}
+	mi := &file_proto_chat_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineJoinRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineJoinRequestRequest) ProtoMessage() {}
+
+func (x *DeclineJoinRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineJoinRequestRequest.ProtoReflect.Descriptor instead.
+func (*DeclineJoinRequestRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DeclineJoinRequestRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *DeclineJoinRequestRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeclineJoinRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclineJoinRequestResponse) Reset() {
+	*x = DeclineJoinRequestResponse{}
+	mi := &file_proto_chat_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineJoinRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineJoinRequestResponse) ProtoMessage() {}
+
+func (x *DeclineJoinRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineJoinRequestResponse.ProtoReflect.Descriptor instead.
+func (*DeclineJoinRequestResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_proto_rawDescGZIP(), []int{51}
+}
+
 var File_proto_chat_proto protoreflect.FileDescriptor
 
 const file_proto_chat_proto_rawDesc = "" +
 	"\n" +
-	"\x10proto/chat.proto\x12\x04chat\"\xa0\x01\n" +
+	"\x10proto/chat.proto\x12\x04chat\"\x91\x03\n" +
 	"\fConversation\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
 	"\x0fparticipant_ids\x18\x02 \x03(\tR\x0eparticipantIds\x12\x1d\n" +
@@ -617,7 +2808,15 @@ const file_proto_chat_proto_rawDesc = "" +
 	"created_at\x18\x03 \x01(\tR\tcreatedAt\x12\x19\n" +
 	"\bis_group\x18\x04 \x01(\bR\aisGroup\x12\x1d\n" +
 	"\n" +
-	"group_name\x18\x05 \x01(\tR\tgroupName\"\x80\x02\n" +
+	"group_name\x18\x05 \x01(\tR\tgroupName\x12\x1f\n" +
+	"\vmuted_until\x18\x06 \x01(\tR\n" +
+	"mutedUntil\x12\x1b\n" +
+	"\tadmin_ids\x18\a \x03(\tR\badminIds\x12+\n" +
+	"\x11group_description\x18\b \x01(\tR\x10groupDescription\x12$\n" +
+	"\x0egroup_icon_url\x18\t \x01(\tR\fgroupIconUrl\x121\n" +
+	"\x15only_admins_edit_info\x18\n" +
+	" \x01(\bR\x12onlyAdminsEditInfo\x12+\n" +
+	"\x11announcement_mode\x18\v \x01(\bR\x10announcementMode\"\xd6\x03\n" +
 	"\aMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
 	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x1b\n" +
@@ -628,21 +2827,58 @@ const file_proto_chat_proto_rawDesc = "" +
 	"media_type\x18\x06 \x01(\tR\tmediaType\x12*\n" +
 	"\x11client_message_id\x18\a \x01(\tR\x0fclientMessageId\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\b \x01(\tR\tcreatedAt\"~\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1b\n" +
+	"\tis_system\x18\t \x01(\bR\bisSystem\x12\x1b\n" +
+	"\tedited_at\x18\n" +
+	" \x01(\tR\beditedAt\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\v \x01(\tR\tdeletedAt\x12\x1d\n" +
+	"\n" +
+	"deleted_by\x18\f \x01(\tR\tdeletedBy\x12,\n" +
+	"\treactions\x18\r \x03(\v2\x0e.chat.ReactionR\treactions\x12.\n" +
+	"\breply_to\x18\x0e \x01(\v2\x13.chat.QuotedMessageR\areplyTo\"\x9e\x01\n" +
+	"\rQuotedMessage\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1b\n" +
+	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12\x18\n" +
+	"\asnippet\x18\x03 \x01(\tR\asnippet\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\x04 \x01(\tR\tmediaType\x12\x18\n" +
+	"\adeleted\x18\x05 \x01(\bR\adeleted\"\x8e\x02\n" +
+	"\vGroupInvite\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\tR\texpiresAt\x12\x19\n" +
+	"\bmax_uses\x18\x05 \x01(\x05R\amaxUses\x12\x1b\n" +
+	"\tuse_count\x18\x06 \x01(\x05R\buseCount\x12+\n" +
+	"\x11requires_approval\x18\a \x01(\bR\x10requiresApproval\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\"I\n" +
+	"\vJoinRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\frequested_at\x18\x02 \x01(\tR\vrequestedAt\"Q\n" +
+	"\bReaction\x12\x14\n" +
+	"\x05emoji\x18\x01 \x01(\tR\x05emoji\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x19\n" +
+	"\buser_ids\x18\x03 \x03(\tR\auserIds\"~\n" +
 	"\x19CreateConversationRequest\x12'\n" +
 	"\x0fparticipant_ids\x18\x01 \x03(\tR\x0eparticipantIds\x12\x19\n" +
 	"\bis_group\x18\x02 \x01(\bR\aisGroup\x12\x1d\n" +
 	"\n" +
 	"group_name\x18\x03 \x01(\tR\tgroupName\"T\n" +
 	"\x1aCreateConversationResponse\x126\n" +
-	"\fconversation\x18\x01 \x01(\v2\x12.chat.ConversationR\fconversation\"\xbf\x01\n" +
+	"\fconversation\x18\x01 \x01(\v2\x12.chat.ConversationR\fconversation\"\xee\x01\n" +
 	"\x12SendMessageRequest\x12'\n" +
 	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1b\n" +
 	"\tmedia_url\x18\x03 \x01(\tR\bmediaUrl\x12\x1d\n" +
 	"\n" +
 	"media_type\x18\x04 \x01(\tR\tmediaType\x12*\n" +
-	"\x11client_message_id\x18\x05 \x01(\tR\x0fclientMessageId\">\n" +
+	"\x11client_message_id\x18\x05 \x01(\tR\x0fclientMessageId\x12-\n" +
+	"\x13reply_to_message_id\x18\x06 \x01(\tR\x10replyToMessageId\">\n" +
 	"\x13SendMessageResponse\x12'\n" +
 	"\amessage\x18\x01 \x01(\v2\r.chat.MessageR\amessage\"\x80\x01\n" +
 	"\x13ListMessagesRequest\x12'\n" +
@@ -653,12 +2889,135 @@ const file_proto_chat_proto_rawDesc = "" +
 	"\bmessages\x18\x01 \x03(\v2\r.chat.MessageR\bmessages\"\x19\n" +
 	"\x17GetConversationsRequest\"T\n" +
 	"\x18GetConversationsResponse\x128\n" +
-	"\rconversations\x18\x01 \x03(\v2\x12.chat.ConversationR\rconversations2\xc4\x02\n" +
+	"\rconversations\x18\x01 \x03(\v2\x12.chat.ConversationR\rconversations\"\x87\x01\n" +
+	"\x17MuteConversationRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12)\n" +
+	"\x10duration_seconds\x18\x02 \x01(\x03R\x0fdurationSeconds\x12\x18\n" +
+	"\aforever\x18\x03 \x01(\bR\aforever\";\n" +
+	"\x18MuteConversationResponse\x12\x1f\n" +
+	"\vmuted_until\x18\x01 \x01(\tR\n" +
+	"mutedUntil\"M\n" +
+	"\x12EditMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\">\n" +
+	"\x13EditMessageResponse\x12'\n" +
+	"\amessage\x18\x01 \x01(\v2\r.chat.MessageR\amessage\"X\n" +
+	"\x14DeleteMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12!\n" +
+	"\ffor_everyone\x18\x02 \x01(\bR\vforEveryone\"\x17\n" +
+	"\x15DeleteMessageResponse\"L\n" +
+	"\x15ReactToMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x02 \x01(\tR\x05emoji\"F\n" +
+	"\x16ReactToMessageResponse\x12,\n" +
+	"\treactions\x18\x01 \x03(\v2\x0e.chat.ReactionR\treactions\"6\n" +
+	"\x15RemoveReactionRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\"F\n" +
+	"\x16RemoveReactionResponse\x12,\n" +
+	"\treactions\x18\x01 \x03(\v2\x0e.chat.ReactionR\treactions\"W\n" +
+	"\x11AddMembersRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\":\n" +
+	"\x12AddMembersResponse\x12$\n" +
+	"\x0eadded_user_ids\x18\x01 \x03(\tR\faddedUserIds\"W\n" +
+	"\x13RemoveMemberRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"@\n" +
+	"\x14RemoveMemberResponse\x12(\n" +
+	"\x10promoted_user_id\x18\x01 \x01(\tR\x0epromotedUserId\"<\n" +
+	"\x11LeaveGroupRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\">\n" +
+	"\x12LeaveGroupResponse\x12(\n" +
+	"\x10promoted_user_id\x18\x01 \x01(\tR\x0epromotedUserId\"W\n" +
+	"\x13PromoteAdminRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x16\n" +
+	"\x14PromoteAdminResponse\"V\n" +
+	"\x12DemoteAdminRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x15\n" +
+	"\x13DemoteAdminResponse\"\xfa\x01\n" +
+	"\x16UpdateGroupInfoRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\"\n" +
+	"\n" +
+	"group_name\x18\x02 \x01(\tH\x00R\tgroupName\x88\x01\x01\x120\n" +
+	"\x11group_description\x18\x03 \x01(\tH\x01R\x10groupDescription\x88\x01\x01\x12)\n" +
+	"\x0egroup_icon_url\x18\x04 \x01(\tH\x02R\fgroupIconUrl\x88\x01\x01B\r\n" +
+	"\v_group_nameB\x14\n" +
+	"\x12_group_descriptionB\x11\n" +
+	"\x0f_group_icon_url\"Q\n" +
+	"\x17UpdateGroupInfoResponse\x126\n" +
+	"\fconversation\x18\x01 \x01(\v2\x12.chat.ConversationR\fconversation\"\xdf\x01\n" +
+	"\x1aUpdateGroupSettingsRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x126\n" +
+	"\x15only_admins_edit_info\x18\x02 \x01(\bH\x00R\x12onlyAdminsEditInfo\x88\x01\x01\x120\n" +
+	"\x11announcement_mode\x18\x03 \x01(\bH\x01R\x10announcementMode\x88\x01\x01B\x18\n" +
+	"\x16_only_admins_edit_infoB\x14\n" +
+	"\x12_announcement_mode\"U\n" +
+	"\x1bUpdateGroupSettingsResponse\x126\n" +
+	"\fconversation\x18\x01 \x01(\v2\x12.chat.ConversationR\fconversation\"\xb4\x01\n" +
+	"\x13CreateInviteRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12,\n" +
+	"\x12expires_in_seconds\x18\x02 \x01(\x03R\x10expiresInSeconds\x12\x19\n" +
+	"\bmax_uses\x18\x03 \x01(\x05R\amaxUses\x12+\n" +
+	"\x11requires_approval\x18\x04 \x01(\bR\x10requiresApproval\"A\n" +
+	"\x14CreateInviteResponse\x12)\n" +
+	"\x06invite\x18\x01 \x01(\v2\x11.chat.GroupInviteR\x06invite\"=\n" +
+	"\x12ListInvitesRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"B\n" +
+	"\x13ListInvitesResponse\x12+\n" +
+	"\ainvites\x18\x01 \x03(\v2\x11.chat.GroupInviteR\ainvites\"T\n" +
+	"\x13RevokeInviteRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x16\n" +
+	"\x14RevokeInviteResponse\",\n" +
+	"\x14JoinViaInviteRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"i\n" +
+	"\x15JoinViaInviteResponse\x126\n" +
+	"\fconversation\x18\x01 \x01(\v2\x12.chat.ConversationR\fconversation\x12\x18\n" +
+	"\apending\x18\x02 \x01(\bR\apending\"B\n" +
+	"\x17ListJoinRequestsRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"I\n" +
+	"\x18ListJoinRequestsResponse\x12-\n" +
+	"\brequests\x18\x01 \x03(\v2\x11.chat.JoinRequestR\brequests\"]\n" +
+	"\x19ApproveJoinRequestRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x1c\n" +
+	"\x1aApproveJoinRequestResponse\"]\n" +
+	"\x19DeclineJoinRequestRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x1c\n" +
+	"\x1aDeclineJoinRequestResponse2\xe0\r\n" +
 	"\vChatService\x12W\n" +
 	"\x12CreateConversation\x12\x1f.chat.CreateConversationRequest\x1a .chat.CreateConversationResponse\x12B\n" +
 	"\vSendMessage\x12\x18.chat.SendMessageRequest\x1a\x19.chat.SendMessageResponse\x12E\n" +
 	"\fListMessages\x12\x19.chat.ListMessagesRequest\x1a\x1a.chat.ListMessagesResponse\x12Q\n" +
-	"\x10GetConversations\x12\x1d.chat.GetConversationsRequest\x1a\x1e.chat.GetConversationsResponseB'Z%github.com/dykethecreator/GoApp/protob\x06proto3"
+	"\x10GetConversations\x12\x1d.chat.GetConversationsRequest\x1a\x1e.chat.GetConversationsResponse\x12Q\n" +
+	"\x10MuteConversation\x12\x1d.chat.MuteConversationRequest\x1a\x1e.chat.MuteConversationResponse\x12B\n" +
+	"\vEditMessage\x12\x18.chat.EditMessageRequest\x1a\x19.chat.EditMessageResponse\x12H\n" +
+	"\rDeleteMessage\x12\x1a.chat.DeleteMessageRequest\x1a\x1b.chat.DeleteMessageResponse\x12K\n" +
+	"\x0eReactToMessage\x12\x1b.chat.ReactToMessageRequest\x1a\x1c.chat.ReactToMessageResponse\x12K\n" +
+	"\x0eRemoveReaction\x12\x1b.chat.RemoveReactionRequest\x1a\x1c.chat.RemoveReactionResponse\x12?\n" +
+	"\n" +
+	"AddMembers\x12\x17.chat.AddMembersRequest\x1a\x18.chat.AddMembersResponse\x12E\n" +
+	"\fRemoveMember\x12\x19.chat.RemoveMemberRequest\x1a\x1a.chat.RemoveMemberResponse\x12?\n" +
+	"\n" +
+	"LeaveGroup\x12\x17.chat.LeaveGroupRequest\x1a\x18.chat.LeaveGroupResponse\x12E\n" +
+	"\fPromoteAdmin\x12\x19.chat.PromoteAdminRequest\x1a\x1a.chat.PromoteAdminResponse\x12B\n" +
+	"\vDemoteAdmin\x12\x18.chat.DemoteAdminRequest\x1a\x19.chat.DemoteAdminResponse\x12N\n" +
+	"\x0fUpdateGroupInfo\x12\x1c.chat.UpdateGroupInfoRequest\x1a\x1d.chat.UpdateGroupInfoResponse\x12Z\n" +
+	"\x13UpdateGroupSettings\x12 .chat.UpdateGroupSettingsRequest\x1a!.chat.UpdateGroupSettingsResponse\x12E\n" +
+	"\fCreateInvite\x12\x19.chat.CreateInviteRequest\x1a\x1a.chat.CreateInviteResponse\x12B\n" +
+	"\vListInvites\x12\x18.chat.ListInvitesRequest\x1a\x19.chat.ListInvitesResponse\x12E\n" +
+	"\fRevokeInvite\x12\x19.chat.RevokeInviteRequest\x1a\x1a.chat.RevokeInviteResponse\x12H\n" +
+	"\rJoinViaInvite\x12\x1a.chat.JoinViaInviteRequest\x1a\x1b.chat.JoinViaInviteResponse\x12Q\n" +
+	"\x10ListJoinRequests\x12\x1d.chat.ListJoinRequestsRequest\x1a\x1e.chat.ListJoinRequestsResponse\x12W\n" +
+	"\x12ApproveJoinRequest\x12\x1f.chat.ApproveJoinRequestRequest\x1a .chat.ApproveJoinRequestResponse\x12W\n" +
+	"\x12DeclineJoinRequest\x12\x1f.chat.DeclineJoinRequestRequest\x1a .chat.DeclineJoinRequestResponseB'Z%github.com/dykethecreator/GoApp/protob\x06proto3"
 
 var (
 	file_proto_chat_proto_rawDescOnce sync.Once
@@ -672,37 +3031,128 @@ func file_proto_chat_proto_rawDescGZIP() []byte {
 	return file_proto_chat_proto_rawDescData
 }
 
-var file_proto_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_proto_chat_proto_goTypes = []any{
-	(*Conversation)(nil),               // 0: chat.Conversation
-	(*Message)(nil),                    // 1: chat.Message
-	(*CreateConversationRequest)(nil),  // 2: chat.CreateConversationRequest
-	(*CreateConversationResponse)(nil), // 3: chat.CreateConversationResponse
-	(*SendMessageRequest)(nil),         // 4: chat.SendMessageRequest
-	(*SendMessageResponse)(nil),        // 5: chat.SendMessageResponse
-	(*ListMessagesRequest)(nil),        // 6: chat.ListMessagesRequest
-	(*ListMessagesResponse)(nil),       // 7: chat.ListMessagesResponse
-	(*GetConversationsRequest)(nil),    // 8: chat.GetConversationsRequest
-	(*GetConversationsResponse)(nil),   // 9: chat.GetConversationsResponse
+	(*Conversation)(nil),                // 0: chat.Conversation
+	(*Message)(nil),                     // 1: chat.Message
+	(*QuotedMessage)(nil),               // 2: chat.QuotedMessage
+	(*GroupInvite)(nil),                 // 3: chat.GroupInvite
+	(*JoinRequest)(nil),                 // 4: chat.JoinRequest
+	(*Reaction)(nil),                    // 5: chat.Reaction
+	(*CreateConversationRequest)(nil),   // 6: chat.CreateConversationRequest
+	(*CreateConversationResponse)(nil),  // 7: chat.CreateConversationResponse
+	(*SendMessageRequest)(nil),          // 8: chat.SendMessageRequest
+	(*SendMessageResponse)(nil),         // 9: chat.SendMessageResponse
+	(*ListMessagesRequest)(nil),         // 10: chat.ListMessagesRequest
+	(*ListMessagesResponse)(nil),        // 11: chat.ListMessagesResponse
+	(*GetConversationsRequest)(nil),     // 12: chat.GetConversationsRequest
+	(*GetConversationsResponse)(nil),    // 13: chat.GetConversationsResponse
+	(*MuteConversationRequest)(nil),     // 14: chat.MuteConversationRequest
+	(*MuteConversationResponse)(nil),    // 15: chat.MuteConversationResponse
+	(*EditMessageRequest)(nil),          // 16: chat.EditMessageRequest
+	(*EditMessageResponse)(nil),         // 17: chat.EditMessageResponse
+	(*DeleteMessageRequest)(nil),        // 18: chat.DeleteMessageRequest
+	(*DeleteMessageResponse)(nil),       // 19: chat.DeleteMessageResponse
+	(*ReactToMessageRequest)(nil),       // 20: chat.ReactToMessageRequest
+	(*ReactToMessageResponse)(nil),      // 21: chat.ReactToMessageResponse
+	(*RemoveReactionRequest)(nil),       // 22: chat.RemoveReactionRequest
+	(*RemoveReactionResponse)(nil),      // 23: chat.RemoveReactionResponse
+	(*AddMembersRequest)(nil),           // 24: chat.AddMembersRequest
+	(*AddMembersResponse)(nil),          // 25: chat.AddMembersResponse
+	(*RemoveMemberRequest)(nil),         // 26: chat.RemoveMemberRequest
+	(*RemoveMemberResponse)(nil),        // 27: chat.RemoveMemberResponse
+	(*LeaveGroupRequest)(nil),           // 28: chat.LeaveGroupRequest
+	(*LeaveGroupResponse)(nil),          // 29: chat.LeaveGroupResponse
+	(*PromoteAdminRequest)(nil),         // 30: chat.PromoteAdminRequest
+	(*PromoteAdminResponse)(nil),        // 31: chat.PromoteAdminResponse
+	(*DemoteAdminRequest)(nil),          // 32: chat.DemoteAdminRequest
+	(*DemoteAdminResponse)(nil),         // 33: chat.DemoteAdminResponse
+	(*UpdateGroupInfoRequest)(nil),      // 34: chat.UpdateGroupInfoRequest
+	(*UpdateGroupInfoResponse)(nil),     // 35: chat.UpdateGroupInfoResponse
+	(*UpdateGroupSettingsRequest)(nil),  // 36: chat.UpdateGroupSettingsRequest
+	(*UpdateGroupSettingsResponse)(nil), // 37: chat.UpdateGroupSettingsResponse
+	(*CreateInviteRequest)(nil),         // 38: chat.CreateInviteRequest
+	(*CreateInviteResponse)(nil),        // 39: chat.CreateInviteResponse
+	(*ListInvitesRequest)(nil),          // 40: chat.ListInvitesRequest
+	(*ListInvitesResponse)(nil),         // 41: chat.ListInvitesResponse
+	(*RevokeInviteRequest)(nil),         // 42: chat.RevokeInviteRequest
+	(*RevokeInviteResponse)(nil),        // 43: chat.RevokeInviteResponse
+	(*JoinViaInviteRequest)(nil),        // 44: chat.JoinViaInviteRequest
+	(*JoinViaInviteResponse)(nil),       // 45: chat.JoinViaInviteResponse
+	(*ListJoinRequestsRequest)(nil),     // 46: chat.ListJoinRequestsRequest
+	(*ListJoinRequestsResponse)(nil),    // 47: chat.ListJoinRequestsResponse
+	(*ApproveJoinRequestRequest)(nil),   // 48: chat.ApproveJoinRequestRequest
+	(*ApproveJoinRequestResponse)(nil),  // 49: chat.ApproveJoinRequestResponse
+	(*DeclineJoinRequestRequest)(nil),   // 50: chat.DeclineJoinRequestRequest
+	(*DeclineJoinRequestResponse)(nil),  // 51: chat.DeclineJoinRequestResponse
 }
 var file_proto_chat_proto_depIdxs = []int32{
-	0, // 0: chat.CreateConversationResponse.conversation:type_name -> chat.Conversation
-	1, // 1: chat.SendMessageResponse.message:type_name -> chat.Message
-	1, // 2: chat.ListMessagesResponse.messages:type_name -> chat.Message
-	0, // 3: chat.GetConversationsResponse.conversations:type_name -> chat.Conversation
-	2, // 4: chat.ChatService.CreateConversation:input_type -> chat.CreateConversationRequest
-	4, // 5: chat.ChatService.SendMessage:input_type -> chat.SendMessageRequest
-	6, // 6: chat.ChatService.ListMessages:input_type -> chat.ListMessagesRequest
-	8, // 7: chat.ChatService.GetConversations:input_type -> chat.GetConversationsRequest
-	3, // 8: chat.ChatService.CreateConversation:output_type -> chat.CreateConversationResponse
-	5, // 9: chat.ChatService.SendMessage:output_type -> chat.SendMessageResponse
-	7, // 10: chat.ChatService.ListMessages:output_type -> chat.ListMessagesResponse
-	9, // 11: chat.ChatService.GetConversations:output_type -> chat.GetConversationsResponse
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5,  // 0: chat.Message.reactions:type_name -> chat.Reaction
+	2,  // 1: chat.Message.reply_to:type_name -> chat.QuotedMessage
+	0,  // 2: chat.CreateConversationResponse.conversation:type_name -> chat.Conversation
+	1,  // 3: chat.SendMessageResponse.message:type_name -> chat.Message
+	1,  // 4: chat.ListMessagesResponse.messages:type_name -> chat.Message
+	0,  // 5: chat.GetConversationsResponse.conversations:type_name -> chat.Conversation
+	1,  // 6: chat.EditMessageResponse.message:type_name -> chat.Message
+	5,  // 7: chat.ReactToMessageResponse.reactions:type_name -> chat.Reaction
+	5,  // 8: chat.RemoveReactionResponse.reactions:type_name -> chat.Reaction
+	0,  // 9: chat.UpdateGroupInfoResponse.conversation:type_name -> chat.Conversation
+	0,  // 10: chat.UpdateGroupSettingsResponse.conversation:type_name -> chat.Conversation
+	3,  // 11: chat.CreateInviteResponse.invite:type_name -> chat.GroupInvite
+	3,  // 12: chat.ListInvitesResponse.invites:type_name -> chat.GroupInvite
+	0,  // 13: chat.JoinViaInviteResponse.conversation:type_name -> chat.Conversation
+	4,  // 14: chat.ListJoinRequestsResponse.requests:type_name -> chat.JoinRequest
+	6,  // 15: chat.ChatService.CreateConversation:input_type -> chat.CreateConversationRequest
+	8,  // 16: chat.ChatService.SendMessage:input_type -> chat.SendMessageRequest
+	10, // 17: chat.ChatService.ListMessages:input_type -> chat.ListMessagesRequest
+	12, // 18: chat.ChatService.GetConversations:input_type -> chat.GetConversationsRequest
+	14, // 19: chat.ChatService.MuteConversation:input_type -> chat.MuteConversationRequest
+	16, // 20: chat.ChatService.EditMessage:input_type -> chat.EditMessageRequest
+	18, // 21: chat.ChatService.DeleteMessage:input_type -> chat.DeleteMessageRequest
+	20, // 22: chat.ChatService.ReactToMessage:input_type -> chat.ReactToMessageRequest
+	22, // 23: chat.ChatService.RemoveReaction:input_type -> chat.RemoveReactionRequest
+	24, // 24: chat.ChatService.AddMembers:input_type -> chat.AddMembersRequest
+	26, // 25: chat.ChatService.RemoveMember:input_type -> chat.RemoveMemberRequest
+	28, // 26: chat.ChatService.LeaveGroup:input_type -> chat.LeaveGroupRequest
+	30, // 27: chat.ChatService.PromoteAdmin:input_type -> chat.PromoteAdminRequest
+	32, // 28: chat.ChatService.DemoteAdmin:input_type -> chat.DemoteAdminRequest
+	34, // 29: chat.ChatService.UpdateGroupInfo:input_type -> chat.UpdateGroupInfoRequest
+	36, // 30: chat.ChatService.UpdateGroupSettings:input_type -> chat.UpdateGroupSettingsRequest
+	38, // 31: chat.ChatService.CreateInvite:input_type -> chat.CreateInviteRequest
+	40, // 32: chat.ChatService.ListInvites:input_type -> chat.ListInvitesRequest
+	42, // 33: chat.ChatService.RevokeInvite:input_type -> chat.RevokeInviteRequest
+	44, // 34: chat.ChatService.JoinViaInvite:input_type -> chat.JoinViaInviteRequest
+	46, // 35: chat.ChatService.ListJoinRequests:input_type -> chat.ListJoinRequestsRequest
+	48, // 36: chat.ChatService.ApproveJoinRequest:input_type -> chat.ApproveJoinRequestRequest
+	50, // 37: chat.ChatService.DeclineJoinRequest:input_type -> chat.DeclineJoinRequestRequest
+	7,  // 38: chat.ChatService.CreateConversation:output_type -> chat.CreateConversationResponse
+	9,  // 39: chat.ChatService.SendMessage:output_type -> chat.SendMessageResponse
+	11, // 40: chat.ChatService.ListMessages:output_type -> chat.ListMessagesResponse
+	13, // 41: chat.ChatService.GetConversations:output_type -> chat.GetConversationsResponse
+	15, // 42: chat.ChatService.MuteConversation:output_type -> chat.MuteConversationResponse
+	17, // 43: chat.ChatService.EditMessage:output_type -> chat.EditMessageResponse
+	19, // 44: chat.ChatService.DeleteMessage:output_type -> chat.DeleteMessageResponse
+	21, // 45: chat.ChatService.ReactToMessage:output_type -> chat.ReactToMessageResponse
+	23, // 46: chat.ChatService.RemoveReaction:output_type -> chat.RemoveReactionResponse
+	25, // 47: chat.ChatService.AddMembers:output_type -> chat.AddMembersResponse
+	27, // 48: chat.ChatService.RemoveMember:output_type -> chat.RemoveMemberResponse
+	29, // 49: chat.ChatService.LeaveGroup:output_type -> chat.LeaveGroupResponse
+	31, // 50: chat.ChatService.PromoteAdmin:output_type -> chat.PromoteAdminResponse
+	33, // 51: chat.ChatService.DemoteAdmin:output_type -> chat.DemoteAdminResponse
+	35, // 52: chat.ChatService.UpdateGroupInfo:output_type -> chat.UpdateGroupInfoResponse
+	37, // 53: chat.ChatService.UpdateGroupSettings:output_type -> chat.UpdateGroupSettingsResponse
+	39, // 54: chat.ChatService.CreateInvite:output_type -> chat.CreateInviteResponse
+	41, // 55: chat.ChatService.ListInvites:output_type -> chat.ListInvitesResponse
+	43, // 56: chat.ChatService.RevokeInvite:output_type -> chat.RevokeInviteResponse
+	45, // 57: chat.ChatService.JoinViaInvite:output_type -> chat.JoinViaInviteResponse
+	47, // 58: chat.ChatService.ListJoinRequests:output_type -> chat.ListJoinRequestsResponse
+	49, // 59: chat.ChatService.ApproveJoinRequest:output_type -> chat.ApproveJoinRequestResponse
+	51, // 60: chat.ChatService.DeclineJoinRequest:output_type -> chat.DeclineJoinRequestResponse
+	38, // [38:61] is the sub-list for method output_type
+	15, // [15:38] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_proto_chat_proto_init() }
@@ -710,13 +3160,15 @@ func file_proto_chat_proto_init() {
 	if File_proto_chat_proto != nil {
 		return
 	}
+	file_proto_chat_proto_msgTypes[34].OneofWrappers = []any{}
+	file_proto_chat_proto_msgTypes[36].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_chat_proto_rawDesc), len(file_proto_chat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -130,6 +130,10 @@ type ServerEvent struct {
 	//	*ServerEvent_Typing
 	//	*ServerEvent_Presence
 	//	*ServerEvent_Delivered
+	//	*ServerEvent_ProfileUpdated
+	//	*ServerEvent_MessageEdited
+	//	*ServerEvent_MessageDeleted
+	//	*ServerEvent_ReactionUpdated
 	Event         isServerEvent_Event `protobuf_oneof:"event"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -217,6 +221,42 @@ func (x *ServerEvent) GetDelivered() *MessageDelivered {
 	return nil
 }
 
+func (x *ServerEvent) GetProfileUpdated() *ProfileUpdated {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_ProfileUpdated); ok {
+			return x.ProfileUpdated
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetMessageEdited() *MessageEdited {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_MessageEdited); ok {
+			return x.MessageEdited
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetMessageDeleted() *MessageDeleted {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_MessageDeleted); ok {
+			return x.MessageDeleted
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetReactionUpdated() *ReactionUpdated {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_ReactionUpdated); ok {
+			return x.ReactionUpdated
+		}
+	}
+	return nil
+}
+
 type isServerEvent_Event interface {
 	isServerEvent_Event()
 }
@@ -241,6 +281,22 @@ type ServerEvent_Delivered struct {
 	Delivered *MessageDelivered `protobuf:"bytes,5,opt,name=delivered,proto3,oneof"`
 }
 
+type ServerEvent_ProfileUpdated struct {
+	ProfileUpdated *ProfileUpdated `protobuf:"bytes,6,opt,name=profile_updated,json=profileUpdated,proto3,oneof"`
+}
+
+type ServerEvent_MessageEdited struct {
+	MessageEdited *MessageEdited `protobuf:"bytes,7,opt,name=message_edited,json=messageEdited,proto3,oneof"`
+}
+
+type ServerEvent_MessageDeleted struct {
+	MessageDeleted *MessageDeleted `protobuf:"bytes,8,opt,name=message_deleted,json=messageDeleted,proto3,oneof"`
+}
+
+type ServerEvent_ReactionUpdated struct {
+	ReactionUpdated *ReactionUpdated `protobuf:"bytes,9,opt,name=reaction_updated,json=reactionUpdated,proto3,oneof"`
+}
+
 func (*ServerEvent_Pong) isServerEvent_Event() {}
 
 func (*ServerEvent_NewMessage) isServerEvent_Event() {}
@@ -251,6 +307,14 @@ func (*ServerEvent_Presence) isServerEvent_Event() {}
 
 func (*ServerEvent_Delivered) isServerEvent_Event() {}
 
+func (*ServerEvent_ProfileUpdated) isServerEvent_Event() {}
+
+func (*ServerEvent_MessageEdited) isServerEvent_Event() {}
+
+func (*ServerEvent_MessageDeleted) isServerEvent_Event() {}
+
+func (*ServerEvent_ReactionUpdated) isServerEvent_Event() {}
+
 // Ping for keep-alive
 type Ping struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -351,6 +415,8 @@ type NewMessage struct {
 	MediaUrl       string                 `protobuf:"bytes,5,opt,name=media_url,json=mediaUrl,proto3" json:"media_url,omitempty"`
 	MediaType      string                 `protobuf:"bytes,6,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
 	CreatedAt      string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	IsSystem       bool                   `protobuf:"varint,8,opt,name=is_system,json=isSystem,proto3" json:"is_system,omitempty"`
+	ReplyTo        *QuotedMessage         `protobuf:"bytes,9,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"` // set on replies
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -434,6 +500,20 @@ func (x *NewMessage) GetCreatedAt() string {
 	return ""
 }
 
+func (x *NewMessage) GetIsSystem() bool {
+	if x != nil {
+		return x.IsSystem
+	}
+	return false
+}
+
+func (x *NewMessage) GetReplyTo() *QuotedMessage {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return nil
+}
+
 // TypingIndicator shows when someone is typing
 type TypingIndicator struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -556,6 +636,309 @@ func (x *PresenceUpdate) GetLastSeen() string {
 	return ""
 }
 
+// ProfileUpdated is sent to a user's contacts when they change their profile
+type ProfileUpdated struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DisplayName       string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	ProfilePictureUrl string                 `protobuf:"bytes,3,opt,name=profile_picture_url,json=profilePictureUrl,proto3" json:"profile_picture_url,omitempty"`
+	AboutText         string                 `protobuf:"bytes,4,opt,name=about_text,json=aboutText,proto3" json:"about_text,omitempty"`
+	UpdatedAt         string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ProfileUpdated) Reset() {
+	*x = ProfileUpdated{}
+	mi := &file_proto_realtime_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileUpdated) ProtoMessage() {}
+
+func (x *ProfileUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_realtime_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileUpdated.ProtoReflect.Descriptor instead.
+func (*ProfileUpdated) Descriptor() ([]byte, []int) {
+	return file_proto_realtime_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProfileUpdated) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ProfileUpdated) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *ProfileUpdated) GetProfilePictureUrl() string {
+	if x != nil {
+		return x.ProfilePictureUrl
+	}
+	return ""
+}
+
+func (x *ProfileUpdated) GetAboutText() string {
+	if x != nil {
+		return x.AboutText
+	}
+	return ""
+}
+
+func (x *ProfileUpdated) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// MessageEdited is sent to a conversation's participants when a message's
+// content is edited
+type MessageEdited struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MessageId      string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ConversationId string                 `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	SenderId       string                 `protobuf:"bytes,3,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	Content        string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	EditedAt       string                 `protobuf:"bytes,5,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MessageEdited) Reset() {
+	*x = MessageEdited{}
+	mi := &file_proto_realtime_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageEdited) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageEdited) ProtoMessage() {}
+
+func (x *MessageEdited) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_realtime_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageEdited.ProtoReflect.Descriptor instead.
+func (*MessageEdited) Descriptor() ([]byte, []int) {
+	return file_proto_realtime_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MessageEdited) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *MessageEdited) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *MessageEdited) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *MessageEdited) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *MessageEdited) GetEditedAt() string {
+	if x != nil {
+		return x.EditedAt
+	}
+	return ""
+}
+
+// MessageDeleted is sent to a conversation's participants when a message is
+// deleted for everyone, and to the user's own devices when deleted for them
+type MessageDeleted struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MessageId      string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ConversationId string                 `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	DeletedBy      string                 `protobuf:"bytes,3,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`
+	ForEveryone    bool                   `protobuf:"varint,4,opt,name=for_everyone,json=forEveryone,proto3" json:"for_everyone,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MessageDeleted) Reset() {
+	*x = MessageDeleted{}
+	mi := &file_proto_realtime_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageDeleted) ProtoMessage() {}
+
+func (x *MessageDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_realtime_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageDeleted.ProtoReflect.Descriptor instead.
+func (*MessageDeleted) Descriptor() ([]byte, []int) {
+	return file_proto_realtime_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MessageDeleted) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *MessageDeleted) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *MessageDeleted) GetDeletedBy() string {
+	if x != nil {
+		return x.DeletedBy
+	}
+	return ""
+}
+
+func (x *MessageDeleted) GetForEveryone() bool {
+	if x != nil {
+		return x.ForEveryone
+	}
+	return false
+}
+
+// ReactionUpdated is sent to a conversation's participants when a user
+// reacts to a message or removes their reaction
+type ReactionUpdated struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MessageId      string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ConversationId string                 `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Emoji          string                 `protobuf:"bytes,4,opt,name=emoji,proto3" json:"emoji,omitempty"`                                                                              // empty when the reaction was removed
+	Counts         map[string]int32       `protobuf:"bytes,5,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // reactions to the message by emoji
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReactionUpdated) Reset() {
+	*x = ReactionUpdated{}
+	mi := &file_proto_realtime_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionUpdated) ProtoMessage() {}
+
+func (x *ReactionUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_realtime_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionUpdated.ProtoReflect.Descriptor instead.
+func (*ReactionUpdated) Descriptor() ([]byte, []int) {
+	return file_proto_realtime_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReactionUpdated) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReactionUpdated) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ReactionUpdated) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReactionUpdated) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactionUpdated) GetCounts() map[string]int32 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
 // MessageDelivered confirms message delivery
 type MessageDelivered struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -567,7 +950,7 @@ type MessageDelivered struct {
 
 func (x *MessageDelivered) Reset() {
 	*x = MessageDelivered{}
-	mi := &file_proto_realtime_proto_msgTypes[7]
+	mi := &file_proto_realtime_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -579,7 +962,7 @@ func (x *MessageDelivered) String() string {
 func (*MessageDelivered) ProtoMessage() {}
 
 func (x *MessageDelivered) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_realtime_proto_msgTypes[7]
+	mi := &file_proto_realtime_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -592,7 +975,7 @@ func (x *MessageDelivered) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageDelivered.ProtoReflect.Descriptor instead.
 func (*MessageDelivered) Descriptor() ([]byte, []int) {
-	return file_proto_realtime_proto_rawDescGZIP(), []int{7}
+	return file_proto_realtime_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *MessageDelivered) GetMessageId() string {
@@ -620,7 +1003,7 @@ type ReadReceipt struct {
 
 func (x *ReadReceipt) Reset() {
 	*x = ReadReceipt{}
-	mi := &file_proto_realtime_proto_msgTypes[8]
+	mi := &file_proto_realtime_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -632,7 +1015,7 @@ func (x *ReadReceipt) String() string {
 func (*ReadReceipt) ProtoMessage() {}
 
 func (x *ReadReceipt) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_realtime_proto_msgTypes[8]
+	mi := &file_proto_realtime_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -645,7 +1028,7 @@ func (x *ReadReceipt) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadReceipt.ProtoReflect.Descriptor instead.
 func (*ReadReceipt) Descriptor() ([]byte, []int) {
-	return file_proto_realtime_proto_rawDescGZIP(), []int{8}
+	return file_proto_realtime_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ReadReceipt) GetConversationId() string {
@@ -666,24 +1049,28 @@ var File_proto_realtime_proto protoreflect.FileDescriptor
 
 const file_proto_realtime_proto_rawDesc = "" +
 	"\n" +
-	"\x14proto/realtime.proto\x12\x05proto\"\xa4\x01\n" +
+	"\x14proto/realtime.proto\x12\x05proto\x1a\x10proto/chat.proto\"\xa4\x01\n" +
 	"\vClientEvent\x12!\n" +
 	"\x04ping\x18\x01 \x01(\v2\v.proto.PingH\x00R\x04ping\x120\n" +
 	"\x06typing\x18\x02 \x01(\v2\x16.proto.TypingIndicatorH\x00R\x06typing\x127\n" +
 	"\fread_receipt\x18\x03 \x01(\v2\x12.proto.ReadReceiptH\x00R\vreadReceiptB\a\n" +
-	"\x05event\"\x8f\x02\n" +
+	"\x05event\"\x97\x04\n" +
 	"\vServerEvent\x12!\n" +
 	"\x04pong\x18\x01 \x01(\v2\v.proto.PongH\x00R\x04pong\x124\n" +
 	"\vnew_message\x18\x02 \x01(\v2\x11.proto.NewMessageH\x00R\n" +
 	"newMessage\x120\n" +
 	"\x06typing\x18\x03 \x01(\v2\x16.proto.TypingIndicatorH\x00R\x06typing\x123\n" +
 	"\bpresence\x18\x04 \x01(\v2\x15.proto.PresenceUpdateH\x00R\bpresence\x127\n" +
-	"\tdelivered\x18\x05 \x01(\v2\x17.proto.MessageDeliveredH\x00R\tdeliveredB\a\n" +
+	"\tdelivered\x18\x05 \x01(\v2\x17.proto.MessageDeliveredH\x00R\tdelivered\x12@\n" +
+	"\x0fprofile_updated\x18\x06 \x01(\v2\x15.proto.ProfileUpdatedH\x00R\x0eprofileUpdated\x12=\n" +
+	"\x0emessage_edited\x18\a \x01(\v2\x14.proto.MessageEditedH\x00R\rmessageEdited\x12@\n" +
+	"\x0fmessage_deleted\x18\b \x01(\v2\x15.proto.MessageDeletedH\x00R\x0emessageDeleted\x12C\n" +
+	"\x10reaction_updated\x18\t \x01(\v2\x16.proto.ReactionUpdatedH\x00R\x0freactionUpdatedB\a\n" +
 	"\x05event\"$\n" +
 	"\x04Ping\x12\x1c\n" +
 	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\"$\n" +
 	"\x04Pong\x12\x1c\n" +
-	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\"\xe6\x01\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\"\xb3\x02\n" +
 	"\n" +
 	"NewMessage\x12\x1d\n" +
 	"\n" +
@@ -695,7 +1082,9 @@ const file_proto_realtime_proto_rawDesc = "" +
 	"\n" +
 	"media_type\x18\x06 \x01(\tR\tmediaType\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\a \x01(\tR\tcreatedAt\"p\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1b\n" +
+	"\tis_system\x18\b \x01(\bR\bisSystem\x12.\n" +
+	"\breply_to\x18\t \x01(\v2\x13.chat.QuotedMessageR\areplyTo\"p\n" +
 	"\x0fTypingIndicator\x12'\n" +
 	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
@@ -703,7 +1092,39 @@ const file_proto_realtime_proto_rawDesc = "" +
 	"\x0ePresenceUpdate\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1b\n" +
-	"\tlast_seen\x18\x03 \x01(\tR\blastSeen\"Z\n" +
+	"\tlast_seen\x18\x03 \x01(\tR\blastSeen\"\xba\x01\n" +
+	"\x0eProfileUpdated\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12.\n" +
+	"\x13profile_picture_url\x18\x03 \x01(\tR\x11profilePictureUrl\x12\x1d\n" +
+	"\n" +
+	"about_text\x18\x04 \x01(\tR\taboutText\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"\xab\x01\n" +
+	"\rMessageEdited\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x1b\n" +
+	"\tsender_id\x18\x03 \x01(\tR\bsenderId\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x12\x1b\n" +
+	"\tedited_at\x18\x05 \x01(\tR\beditedAt\"\x9a\x01\n" +
+	"\x0eMessageDeleted\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"deleted_by\x18\x03 \x01(\tR\tdeletedBy\x12!\n" +
+	"\ffor_everyone\x18\x04 \x01(\bR\vforEveryone\"\xff\x01\n" +
+	"\x0fReactionUpdated\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05emoji\x18\x04 \x01(\tR\x05emoji\x12:\n" +
+	"\x06counts\x18\x05 \x03(\v2\".proto.ReactionUpdated.CountsEntryR\x06counts\x1a9\n" +
+	"\vCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"Z\n" +
 	"\x10MessageDelivered\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tR\tmessageId\x12'\n" +
@@ -727,7 +1148,7 @@ func file_proto_realtime_proto_rawDescGZIP() []byte {
 	return file_proto_realtime_proto_rawDescData
 }
 
-var file_proto_realtime_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_realtime_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_realtime_proto_goTypes = []any{
 	(*ClientEvent)(nil),      // 0: proto.ClientEvent
 	(*ServerEvent)(nil),      // 1: proto.ServerEvent
@@ -736,25 +1157,37 @@ var file_proto_realtime_proto_goTypes = []any{
 	(*NewMessage)(nil),       // 4: proto.NewMessage
 	(*TypingIndicator)(nil),  // 5: proto.TypingIndicator
 	(*PresenceUpdate)(nil),   // 6: proto.PresenceUpdate
-	(*MessageDelivered)(nil), // 7: proto.MessageDelivered
-	(*ReadReceipt)(nil),      // 8: proto.ReadReceipt
+	(*ProfileUpdated)(nil),   // 7: proto.ProfileUpdated
+	(*MessageEdited)(nil),    // 8: proto.MessageEdited
+	(*MessageDeleted)(nil),   // 9: proto.MessageDeleted
+	(*ReactionUpdated)(nil),  // 10: proto.ReactionUpdated
+	(*MessageDelivered)(nil), // 11: proto.MessageDelivered
+	(*ReadReceipt)(nil),      // 12: proto.ReadReceipt
+	nil,                      // 13: proto.ReactionUpdated.CountsEntry
+	(*QuotedMessage)(nil),    // 14: chat.QuotedMessage
 }
 var file_proto_realtime_proto_depIdxs = []int32{
-	2, // 0: proto.ClientEvent.ping:type_name -> proto.Ping
-	5, // 1: proto.ClientEvent.typing:type_name -> proto.TypingIndicator
-	8, // 2: proto.ClientEvent.read_receipt:type_name -> proto.ReadReceipt
-	3, // 3: proto.ServerEvent.pong:type_name -> proto.Pong
-	4, // 4: proto.ServerEvent.new_message:type_name -> proto.NewMessage
-	5, // 5: proto.ServerEvent.typing:type_name -> proto.TypingIndicator
-	6, // 6: proto.ServerEvent.presence:type_name -> proto.PresenceUpdate
-	7, // 7: proto.ServerEvent.delivered:type_name -> proto.MessageDelivered
-	0, // 8: proto.RealtimeService.Connect:input_type -> proto.ClientEvent
-	1, // 9: proto.RealtimeService.Connect:output_type -> proto.ServerEvent
-	9, // [9:10] is the sub-list for method output_type
-	8, // [8:9] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	2,  // 0: proto.ClientEvent.ping:type_name -> proto.Ping
+	5,  // 1: proto.ClientEvent.typing:type_name -> proto.TypingIndicator
+	12, // 2: proto.ClientEvent.read_receipt:type_name -> proto.ReadReceipt
+	3,  // 3: proto.ServerEvent.pong:type_name -> proto.Pong
+	4,  // 4: proto.ServerEvent.new_message:type_name -> proto.NewMessage
+	5,  // 5: proto.ServerEvent.typing:type_name -> proto.TypingIndicator
+	6,  // 6: proto.ServerEvent.presence:type_name -> proto.PresenceUpdate
+	11, // 7: proto.ServerEvent.delivered:type_name -> proto.MessageDelivered
+	7,  // 8: proto.ServerEvent.profile_updated:type_name -> proto.ProfileUpdated
+	8,  // 9: proto.ServerEvent.message_edited:type_name -> proto.MessageEdited
+	9,  // 10: proto.ServerEvent.message_deleted:type_name -> proto.MessageDeleted
+	10, // 11: proto.ServerEvent.reaction_updated:type_name -> proto.ReactionUpdated
+	14, // 12: proto.NewMessage.reply_to:type_name -> chat.QuotedMessage
+	13, // 13: proto.ReactionUpdated.counts:type_name -> proto.ReactionUpdated.CountsEntry
+	0,  // 14: proto.RealtimeService.Connect:input_type -> proto.ClientEvent
+	1,  // 15: proto.RealtimeService.Connect:output_type -> proto.ServerEvent
+	15, // [15:16] is the sub-list for method output_type
+	14, // [14:15] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_proto_realtime_proto_init() }
@@ -762,6 +1195,7 @@ func file_proto_realtime_proto_init() {
 	if File_proto_realtime_proto != nil {
 		return
 	}
+	file_proto_chat_proto_init()
 	file_proto_realtime_proto_msgTypes[0].OneofWrappers = []any{
 		(*ClientEvent_Ping)(nil),
 		(*ClientEvent_Typing)(nil),
@@ -773,6 +1207,10 @@ func file_proto_realtime_proto_init() {
 		(*ServerEvent_Typing)(nil),
 		(*ServerEvent_Presence)(nil),
 		(*ServerEvent_Delivered)(nil),
+		(*ServerEvent_ProfileUpdated)(nil),
+		(*ServerEvent_MessageEdited)(nil),
+		(*ServerEvent_MessageDeleted)(nil),
+		(*ServerEvent_ReactionUpdated)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -780,7 +1218,7 @@ func file_proto_realtime_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_realtime_proto_rawDesc), len(file_proto_realtime_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,1758 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: proto/user.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PrivacyVisibility is who may see a part of the caller's profile or presence.
+type PrivacyVisibility int32
+
+const (
+	PrivacyVisibility_PRIVACY_VISIBILITY_UNSPECIFIED PrivacyVisibility = 0 // in updates: leave unchanged
+	PrivacyVisibility_PRIVACY_VISIBILITY_EVERYONE    PrivacyVisibility = 1
+	PrivacyVisibility_PRIVACY_VISIBILITY_CONTACTS    PrivacyVisibility = 2 // users in the caller's address book
+	PrivacyVisibility_PRIVACY_VISIBILITY_NOBODY      PrivacyVisibility = 3
+)
+
+// Enum value maps for PrivacyVisibility.
+var (
+	PrivacyVisibility_name = map[int32]string{
+		0: "PRIVACY_VISIBILITY_UNSPECIFIED",
+		1: "PRIVACY_VISIBILITY_EVERYONE",
+		2: "PRIVACY_VISIBILITY_CONTACTS",
+		3: "PRIVACY_VISIBILITY_NOBODY",
+	}
+	PrivacyVisibility_value = map[string]int32{
+		"PRIVACY_VISIBILITY_UNSPECIFIED": 0,
+		"PRIVACY_VISIBILITY_EVERYONE":    1,
+		"PRIVACY_VISIBILITY_CONTACTS":    2,
+		"PRIVACY_VISIBILITY_NOBODY":      3,
+	}
+)
+
+func (x PrivacyVisibility) Enum() *PrivacyVisibility {
+	p := new(PrivacyVisibility)
+	*p = x
+	return p
+}
+
+func (x PrivacyVisibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PrivacyVisibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_proto_enumTypes[0].Descriptor()
+}
+
+func (PrivacyVisibility) Type() protoreflect.EnumType {
+	return &file_proto_user_proto_enumTypes[0]
+}
+
+func (x PrivacyVisibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PrivacyVisibility.Descriptor instead.
+func (PrivacyVisibility) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{0}
+}
+
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0 // ZIP
+	ExportFormat_EXPORT_FORMAT_ZIP         ExportFormat = 1 // one JSON file per section
+	ExportFormat_EXPORT_FORMAT_JSON        ExportFormat = 2 // a single JSON document
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_ZIP",
+		2: "EXPORT_FORMAT_JSON",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"EXPORT_FORMAT_ZIP":         1,
+		"EXPORT_FORMAT_JSON":        2,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_proto_enumTypes[1].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_proto_user_proto_enumTypes[1]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{1}
+}
+
+// UserProfile is the public view of a user. phone_number is only set on the caller's own profile.
+type UserProfile struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PhoneNumber       string                 `protobuf:"bytes,2,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	DisplayName       string                 `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	ProfilePictureUrl string                 `protobuf:"bytes,4,opt,name=profile_picture_url,json=profilePictureUrl,proto3" json:"profile_picture_url,omitempty"`
+	AboutText         string                 `protobuf:"bytes,5,opt,name=about_text,json=aboutText,proto3" json:"about_text,omitempty"`
+	LastSeenAt        string                 `protobuf:"bytes,6,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"` // RFC3339
+	UpdatedAt         string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`      // RFC3339
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UserProfile) Reset() {
+	*x = UserProfile{}
+	mi := &file_proto_user_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserProfile) ProtoMessage() {}
+
+func (x *UserProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
+func (*UserProfile) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserProfile) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserProfile) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *UserProfile) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *UserProfile) GetProfilePictureUrl() string {
+	if x != nil {
+		return x.ProfilePictureUrl
+	}
+	return ""
+}
+
+func (x *UserProfile) GetAboutText() string {
+	if x != nil {
+		return x.AboutText
+	}
+	return ""
+}
+
+func (x *UserProfile) GetLastSeenAt() string {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return ""
+}
+
+func (x *UserProfile) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // empty = caller
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_proto_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserProfile           `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserResponse) Reset() {
+	*x = GetUserResponse{}
+	mi := &file_proto_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserResponse) ProtoMessage() {}
+
+func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
+func (*GetUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetUserResponse) GetUser() *UserProfile {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type BatchGetUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // at most 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetUsersRequest) Reset() {
+	*x = BatchGetUsersRequest{}
+	mi := &file_proto_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUsersRequest) ProtoMessage() {}
+
+func (x *BatchGetUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUsersRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetUsersRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type BatchGetUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserProfile         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetUsersResponse) Reset() {
+	*x = BatchGetUsersResponse{}
+	mi := &file_proto_user_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUsersResponse) ProtoMessage() {}
+
+func (x *BatchGetUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUsersResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetUsersResponse) GetUsers() []*UserProfile {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type UpdateProfileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only fields that are set are changed
+	DisplayName       *string `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3,oneof" json:"display_name,omitempty"`                     // 1-100 characters
+	AboutText         *string `protobuf:"bytes,2,opt,name=about_text,json=aboutText,proto3,oneof" json:"about_text,omitempty"`                           // up to 250 characters, may be empty
+	ProfilePictureUrl *string `protobuf:"bytes,3,opt,name=profile_picture_url,json=profilePictureUrl,proto3,oneof" json:"profile_picture_url,omitempty"` // http(s) URL, or empty to remove
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateProfileRequest) Reset() {
+	*x = UpdateProfileRequest{}
+	mi := &file_proto_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileRequest) ProtoMessage() {}
+
+func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateProfileRequest) GetDisplayName() string {
+	if x != nil && x.DisplayName != nil {
+		return *x.DisplayName
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetAboutText() string {
+	if x != nil && x.AboutText != nil {
+		return *x.AboutText
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetProfilePictureUrl() string {
+	if x != nil && x.ProfilePictureUrl != nil {
+		return *x.ProfilePictureUrl
+	}
+	return ""
+}
+
+type UpdateProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserProfile           `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProfileResponse) Reset() {
+	*x = UpdateProfileResponse{}
+	mi := &file_proto_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileResponse) ProtoMessage() {}
+
+func (x *UpdateProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProfileResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateProfileResponse) GetUser() *UserProfile {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// ContactEntry is one address book entry. Set either phone_number or phone_hash.
+type ContactEntry struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PhoneNumber string                 `protobuf:"bytes,1,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"` // E.164, e.g. "+905551112233"
+	// Lowercase hex SHA-256 of hash_salt + phone_number, for clients that do not upload numbers.
+	// Hashed entries that match no registered user are not stored.
+	PhoneHash string `protobuf:"bytes,2,opt,name=phone_hash,json=phoneHash,proto3" json:"phone_hash,omitempty"`
+	// Name to show instead of the contact's display_name; unset = keep, empty = clear
+	DisplayNameOverride *string `protobuf:"bytes,3,opt,name=display_name_override,json=displayNameOverride,proto3,oneof" json:"display_name_override,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ContactEntry) Reset() {
+	*x = ContactEntry{}
+	mi := &file_proto_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContactEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContactEntry) ProtoMessage() {}
+
+func (x *ContactEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContactEntry.ProtoReflect.Descriptor instead.
+func (*ContactEntry) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ContactEntry) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *ContactEntry) GetPhoneHash() string {
+	if x != nil {
+		return x.PhoneHash
+	}
+	return ""
+}
+
+func (x *ContactEntry) GetDisplayNameOverride() string {
+	if x != nil && x.DisplayNameOverride != nil {
+		return *x.DisplayNameOverride
+	}
+	return ""
+}
+
+type SyncContactsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// true: the upload is the whole address book and contacts not in it are removed.
+	// false: delta upload; entries are added/updated and removed_* entries are deleted.
+	FullSync            bool            `protobuf:"varint,1,opt,name=full_sync,json=fullSync,proto3" json:"full_sync,omitempty"`
+	Contacts            []*ContactEntry `protobuf:"bytes,2,rep,name=contacts,proto3" json:"contacts,omitempty"` // at most 1000 per request
+	RemovedPhoneNumbers []string        `protobuf:"bytes,3,rep,name=removed_phone_numbers,json=removedPhoneNumbers,proto3" json:"removed_phone_numbers,omitempty"`
+	RemovedPhoneHashes  []string        `protobuf:"bytes,4,rep,name=removed_phone_hashes,json=removedPhoneHashes,proto3" json:"removed_phone_hashes,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SyncContactsRequest) Reset() {
+	*x = SyncContactsRequest{}
+	mi := &file_proto_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncContactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncContactsRequest) ProtoMessage() {}
+
+func (x *SyncContactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncContactsRequest.ProtoReflect.Descriptor instead.
+func (*SyncContactsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SyncContactsRequest) GetFullSync() bool {
+	if x != nil {
+		return x.FullSync
+	}
+	return false
+}
+
+func (x *SyncContactsRequest) GetContacts() []*ContactEntry {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+func (x *SyncContactsRequest) GetRemovedPhoneNumbers() []string {
+	if x != nil {
+		return x.RemovedPhoneNumbers
+	}
+	return nil
+}
+
+func (x *SyncContactsRequest) GetRemovedPhoneHashes() []string {
+	if x != nil {
+		return x.RemovedPhoneHashes
+	}
+	return nil
+}
+
+// SyncedContact is a contact of the caller who is registered on the platform.
+type SyncedContact struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	PhoneNumber         string                 `protobuf:"bytes,1,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	PhoneHash           string                 `protobuf:"bytes,2,opt,name=phone_hash,json=phoneHash,proto3" json:"phone_hash,omitempty"` // set when hashing is enabled
+	DisplayNameOverride string                 `protobuf:"bytes,3,opt,name=display_name_override,json=displayNameOverride,proto3" json:"display_name_override,omitempty"`
+	User                *UserProfile           `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SyncedContact) Reset() {
+	*x = SyncedContact{}
+	mi := &file_proto_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncedContact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncedContact) ProtoMessage() {}
+
+func (x *SyncedContact) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncedContact.ProtoReflect.Descriptor instead.
+func (*SyncedContact) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SyncedContact) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *SyncedContact) GetPhoneHash() string {
+	if x != nil {
+		return x.PhoneHash
+	}
+	return ""
+}
+
+func (x *SyncedContact) GetDisplayNameOverride() string {
+	if x != nil {
+		return x.DisplayNameOverride
+	}
+	return ""
+}
+
+func (x *SyncedContact) GetUser() *UserProfile {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type SyncContactsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// All of the caller's contacts that are registered, after applying the upload
+	Contacts []*SyncedContact `protobuf:"bytes,1,rep,name=contacts,proto3" json:"contacts,omitempty"`
+	// Salt for phone_hash; empty when hashed uploads are disabled. An empty delta sync returns it.
+	HashSalt      string `protobuf:"bytes,2,opt,name=hash_salt,json=hashSalt,proto3" json:"hash_salt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncContactsResponse) Reset() {
+	*x = SyncContactsResponse{}
+	mi := &file_proto_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncContactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncContactsResponse) ProtoMessage() {}
+
+func (x *SyncContactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncContactsResponse.ProtoReflect.Descriptor instead.
+func (*SyncContactsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SyncContactsResponse) GetContacts() []*SyncedContact {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+func (x *SyncContactsResponse) GetHashSalt() string {
+	if x != nil {
+		return x.HashSalt
+	}
+	return ""
+}
+
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_proto_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BlockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type BlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserResponse) Reset() {
+	*x = BlockUserResponse{}
+	mi := &file_proto_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserResponse) ProtoMessage() {}
+
+func (x *BlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserResponse.ProtoReflect.Descriptor instead.
+func (*BlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{12}
+}
+
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_proto_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UnblockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnblockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserResponse) Reset() {
+	*x = UnblockUserResponse{}
+	mi := &file_proto_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserResponse) ProtoMessage() {}
+
+func (x *UnblockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnblockUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{14}
+}
+
+type ListBlockedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedRequest) Reset() {
+	*x = ListBlockedRequest{}
+	mi := &file_proto_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedRequest) ProtoMessage() {}
+
+func (x *ListBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedRequest.ProtoReflect.Descriptor instead.
+func (*ListBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{15}
+}
+
+// BlockedUser is an entry of the caller's block list.
+type BlockedUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserProfile           `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	BlockedAt     string                 `protobuf:"bytes,2,opt,name=blocked_at,json=blockedAt,proto3" json:"blocked_at,omitempty"` // RFC3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockedUser) Reset() {
+	*x = BlockedUser{}
+	mi := &file_proto_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockedUser) ProtoMessage() {}
+
+func (x *BlockedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockedUser.ProtoReflect.Descriptor instead.
+func (*BlockedUser) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BlockedUser) GetUser() *UserProfile {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *BlockedUser) GetBlockedAt() string {
+	if x != nil {
+		return x.BlockedAt
+	}
+	return ""
+}
+
+type ListBlockedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*BlockedUser         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedResponse) Reset() {
+	*x = ListBlockedResponse{}
+	mi := &file_proto_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedResponse) ProtoMessage() {}
+
+func (x *ListBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedResponse.ProtoReflect.Descriptor instead.
+func (*ListBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListBlockedResponse) GetUsers() []*BlockedUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type PrivacySettings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LastSeen      PrivacyVisibility      `protobuf:"varint,1,opt,name=last_seen,json=lastSeen,proto3,enum=user.PrivacyVisibility" json:"last_seen,omitempty"`
+	Online        PrivacyVisibility      `protobuf:"varint,2,opt,name=online,proto3,enum=user.PrivacyVisibility" json:"online,omitempty"`
+	ProfilePhoto  PrivacyVisibility      `protobuf:"varint,3,opt,name=profile_photo,json=profilePhoto,proto3,enum=user.PrivacyVisibility" json:"profile_photo,omitempty"`
+	About         PrivacyVisibility      `protobuf:"varint,4,opt,name=about,proto3,enum=user.PrivacyVisibility" json:"about,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // RFC3339; empty if never changed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrivacySettings) Reset() {
+	*x = PrivacySettings{}
+	mi := &file_proto_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrivacySettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrivacySettings) ProtoMessage() {}
+
+func (x *PrivacySettings) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrivacySettings.ProtoReflect.Descriptor instead.
+func (*PrivacySettings) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PrivacySettings) GetLastSeen() PrivacyVisibility {
+	if x != nil {
+		return x.LastSeen
+	}
+	return PrivacyVisibility_PRIVACY_VISIBILITY_UNSPECIFIED
+}
+
+func (x *PrivacySettings) GetOnline() PrivacyVisibility {
+	if x != nil {
+		return x.Online
+	}
+	return PrivacyVisibility_PRIVACY_VISIBILITY_UNSPECIFIED
+}
+
+func (x *PrivacySettings) GetProfilePhoto() PrivacyVisibility {
+	if x != nil {
+		return x.ProfilePhoto
+	}
+	return PrivacyVisibility_PRIVACY_VISIBILITY_UNSPECIFIED
+}
+
+func (x *PrivacySettings) GetAbout() PrivacyVisibility {
+	if x != nil {
+		return x.About
+	}
+	return PrivacyVisibility_PRIVACY_VISIBILITY_UNSPECIFIED
+}
+
+func (x *PrivacySettings) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type GetPrivacySettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPrivacySettingsRequest) Reset() {
+	*x = GetPrivacySettingsRequest{}
+	mi := &file_proto_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPrivacySettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPrivacySettingsRequest) ProtoMessage() {}
+
+func (x *GetPrivacySettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPrivacySettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetPrivacySettingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{19}
+}
+
+type GetPrivacySettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *PrivacySettings       `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPrivacySettingsResponse) Reset() {
+	*x = GetPrivacySettingsResponse{}
+	mi := &file_proto_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPrivacySettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPrivacySettingsResponse) ProtoMessage() {}
+
+func (x *GetPrivacySettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPrivacySettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetPrivacySettingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetPrivacySettingsResponse) GetSettings() *PrivacySettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdatePrivacySettingsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// UNSPECIFIED fields are left unchanged
+	LastSeen      PrivacyVisibility `protobuf:"varint,1,opt,name=last_seen,json=lastSeen,proto3,enum=user.PrivacyVisibility" json:"last_seen,omitempty"`
+	Online        PrivacyVisibility `protobuf:"varint,2,opt,name=online,proto3,enum=user.PrivacyVisibility" json:"online,omitempty"`
+	ProfilePhoto  PrivacyVisibility `protobuf:"varint,3,opt,name=profile_photo,json=profilePhoto,proto3,enum=user.PrivacyVisibility" json:"profile_photo,omitempty"`
+	About         PrivacyVisibility `protobuf:"varint,4,opt,name=about,proto3,enum=user.PrivacyVisibility" json:"about,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePrivacySettingsRequest) Reset() {
+	*x = UpdatePrivacySettingsRequest{}
+	mi := &file_proto_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePrivacySettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePrivacySettingsRequest) ProtoMessage() {}
+
+func (x *UpdatePrivacySettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePrivacySettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePrivacySettingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdatePrivacySettingsRequest) GetLastSeen() PrivacyVisibility {
+	if x != nil {
+		return x.LastSeen
+	}
+	return PrivacyVisibility_PRIVACY_VISIBILITY_UNSPECIFIED
+}
+
+func (x *UpdatePrivacySettingsRequest) GetOnline() PrivacyVisibility {
+	if x != nil {
+		return x.Online
+	}
+	return PrivacyVisibility_PRIVACY_VISIBILITY_UNSPECIFIED
+}
+
+func (x *UpdatePrivacySettingsRequest) GetProfilePhoto() PrivacyVisibility {
+	if x != nil {
+		return x.ProfilePhoto
+	}
+	return PrivacyVisibility_PRIVACY_VISIBILITY_UNSPECIFIED
+}
+
+func (x *UpdatePrivacySettingsRequest) GetAbout() PrivacyVisibility {
+	if x != nil {
+		return x.About
+	}
+	return PrivacyVisibility_PRIVACY_VISIBILITY_UNSPECIFIED
+}
+
+type UpdatePrivacySettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *PrivacySettings       `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePrivacySettingsResponse) Reset() {
+	*x = UpdatePrivacySettingsResponse{}
+	mi := &file_proto_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePrivacySettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePrivacySettingsResponse) ProtoMessage() {}
+
+func (x *UpdatePrivacySettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePrivacySettingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePrivacySettingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdatePrivacySettingsResponse) GetSettings() *PrivacySettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type DeleteAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PhoneNumber   string                 `protobuf:"bytes,1,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"` // must match the caller's number, as a confirmation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_proto_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DeleteAccountRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+type DeleteAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountResponse) Reset() {
+	*x = DeleteAccountResponse{}
+	mi := &file_proto_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountResponse) ProtoMessage() {}
+
+func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{24}
+}
+
+type ExportMyDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        ExportFormat           `protobuf:"varint,1,opt,name=format,proto3,enum=user.ExportFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_proto_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ExportMyDataRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+type ExportMyDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	BlobKey       string                 `protobuf:"bytes,2,opt,name=blob_key,json=blobKey,proto3" json:"blob_key,omitempty"` // path relative to the export blob directory
+	Format        ExportFormat           `protobuf:"varint,3,opt,name=format,proto3,enum=user.ExportFormat" json:"format,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataResponse) Reset() {
+	*x = ExportMyDataResponse{}
+	mi := &file_proto_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataResponse) ProtoMessage() {}
+
+func (x *ExportMyDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportMyDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ExportMyDataResponse) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+func (x *ExportMyDataResponse) GetBlobKey() string {
+	if x != nil {
+		return x.BlobKey
+	}
+	return ""
+}
+
+func (x *ExportMyDataResponse) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ExportMyDataResponse) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *ExportMyDataResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+var File_proto_user_proto protoreflect.FileDescriptor
+
+const file_proto_user_proto_rawDesc = "" +
+	"\n" +
+	"\x10proto/user.proto\x12\x04user\"\xf3\x01\n" +
+	"\vUserProfile\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fphone_number\x18\x02 \x01(\tR\vphoneNumber\x12!\n" +
+	"\fdisplay_name\x18\x03 \x01(\tR\vdisplayName\x12.\n" +
+	"\x13profile_picture_url\x18\x04 \x01(\tR\x11profilePictureUrl\x12\x1d\n" +
+	"\n" +
+	"about_text\x18\x05 \x01(\tR\taboutText\x12 \n" +
+	"\flast_seen_at\x18\x06 \x01(\tR\n" +
+	"lastSeenAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"8\n" +
+	"\x0fGetUserResponse\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.user.UserProfileR\x04user\"1\n" +
+	"\x14BatchGetUsersRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"@\n" +
+	"\x15BatchGetUsersResponse\x12'\n" +
+	"\x05users\x18\x01 \x03(\v2\x11.user.UserProfileR\x05users\"\xcf\x01\n" +
+	"\x14UpdateProfileRequest\x12&\n" +
+	"\fdisplay_name\x18\x01 \x01(\tH\x00R\vdisplayName\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"about_text\x18\x02 \x01(\tH\x01R\taboutText\x88\x01\x01\x123\n" +
+	"\x13profile_picture_url\x18\x03 \x01(\tH\x02R\x11profilePictureUrl\x88\x01\x01B\x0f\n" +
+	"\r_display_nameB\r\n" +
+	"\v_about_textB\x16\n" +
+	"\x14_profile_picture_url\">\n" +
+	"\x15UpdateProfileResponse\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.user.UserProfileR\x04user\"\xa3\x01\n" +
+	"\fContactEntry\x12!\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\x12\x1d\n" +
+	"\n" +
+	"phone_hash\x18\x02 \x01(\tR\tphoneHash\x127\n" +
+	"\x15display_name_override\x18\x03 \x01(\tH\x00R\x13displayNameOverride\x88\x01\x01B\x18\n" +
+	"\x16_display_name_override\"\xc8\x01\n" +
+	"\x13SyncContactsRequest\x12\x1b\n" +
+	"\tfull_sync\x18\x01 \x01(\bR\bfullSync\x12.\n" +
+	"\bcontacts\x18\x02 \x03(\v2\x12.user.ContactEntryR\bcontacts\x122\n" +
+	"\x15removed_phone_numbers\x18\x03 \x03(\tR\x13removedPhoneNumbers\x120\n" +
+	"\x14removed_phone_hashes\x18\x04 \x03(\tR\x12removedPhoneHashes\"\xac\x01\n" +
+	"\rSyncedContact\x12!\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\x12\x1d\n" +
+	"\n" +
+	"phone_hash\x18\x02 \x01(\tR\tphoneHash\x122\n" +
+	"\x15display_name_override\x18\x03 \x01(\tR\x13displayNameOverride\x12%\n" +
+	"\x04user\x18\x04 \x01(\v2\x11.user.UserProfileR\x04user\"d\n" +
+	"\x14SyncContactsResponse\x12/\n" +
+	"\bcontacts\x18\x01 \x03(\v2\x13.user.SyncedContactR\bcontacts\x12\x1b\n" +
+	"\thash_salt\x18\x02 \x01(\tR\bhashSalt\"+\n" +
+	"\x10BlockUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x13\n" +
+	"\x11BlockUserResponse\"-\n" +
+	"\x12UnblockUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x15\n" +
+	"\x13UnblockUserResponse\"\x14\n" +
+	"\x12ListBlockedRequest\"S\n" +
+	"\vBlockedUser\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.user.UserProfileR\x04user\x12\x1d\n" +
+	"\n" +
+	"blocked_at\x18\x02 \x01(\tR\tblockedAt\">\n" +
+	"\x13ListBlockedResponse\x12'\n" +
+	"\x05users\x18\x01 \x03(\v2\x11.user.BlockedUserR\x05users\"\x84\x02\n" +
+	"\x0fPrivacySettings\x124\n" +
+	"\tlast_seen\x18\x01 \x01(\x0e2\x17.user.PrivacyVisibilityR\blastSeen\x12/\n" +
+	"\x06online\x18\x02 \x01(\x0e2\x17.user.PrivacyVisibilityR\x06online\x12<\n" +
+	"\rprofile_photo\x18\x03 \x01(\x0e2\x17.user.PrivacyVisibilityR\fprofilePhoto\x12-\n" +
+	"\x05about\x18\x04 \x01(\x0e2\x17.user.PrivacyVisibilityR\x05about\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"\x1b\n" +
+	"\x19GetPrivacySettingsRequest\"O\n" +
+	"\x1aGetPrivacySettingsResponse\x121\n" +
+	"\bsettings\x18\x01 \x01(\v2\x15.user.PrivacySettingsR\bsettings\"\xf2\x01\n" +
+	"\x1cUpdatePrivacySettingsRequest\x124\n" +
+	"\tlast_seen\x18\x01 \x01(\x0e2\x17.user.PrivacyVisibilityR\blastSeen\x12/\n" +
+	"\x06online\x18\x02 \x01(\x0e2\x17.user.PrivacyVisibilityR\x06online\x12<\n" +
+	"\rprofile_photo\x18\x03 \x01(\x0e2\x17.user.PrivacyVisibilityR\fprofilePhoto\x12-\n" +
+	"\x05about\x18\x04 \x01(\x0e2\x17.user.PrivacyVisibilityR\x05about\"R\n" +
+	"\x1dUpdatePrivacySettingsResponse\x121\n" +
+	"\bsettings\x18\x01 \x01(\v2\x15.user.PrivacySettingsR\bsettings\"9\n" +
+	"\x14DeleteAccountRequest\x12!\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\"\x17\n" +
+	"\x15DeleteAccountResponse\"A\n" +
+	"\x13ExportMyDataRequest\x12*\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x12.user.ExportFormatR\x06format\"\xb8\x01\n" +
+	"\x14ExportMyDataResponse\x12\x1b\n" +
+	"\texport_id\x18\x01 \x01(\tR\bexportId\x12\x19\n" +
+	"\bblob_key\x18\x02 \x01(\tR\ablobKey\x12*\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x12.user.ExportFormatR\x06format\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x04 \x01(\x03R\tsizeBytes\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt*\x98\x01\n" +
+	"\x11PrivacyVisibility\x12\"\n" +
+	"\x1ePRIVACY_VISIBILITY_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bPRIVACY_VISIBILITY_EVERYONE\x10\x01\x12\x1f\n" +
+	"\x1bPRIVACY_VISIBILITY_CONTACTS\x10\x02\x12\x1d\n" +
+	"\x19PRIVACY_VISIBILITY_NOBODY\x10\x03*\\\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11EXPORT_FORMAT_ZIP\x10\x01\x12\x16\n" +
+	"\x12EXPORT_FORMAT_JSON\x10\x022\xb2\x06\n" +
+	"\vUserService\x126\n" +
+	"\aGetUser\x12\x14.user.GetUserRequest\x1a\x15.user.GetUserResponse\x12H\n" +
+	"\rBatchGetUsers\x12\x1a.user.BatchGetUsersRequest\x1a\x1b.user.BatchGetUsersResponse\x12H\n" +
+	"\rUpdateProfile\x12\x1a.user.UpdateProfileRequest\x1a\x1b.user.UpdateProfileResponse\x12E\n" +
+	"\fSyncContacts\x12\x19.user.SyncContactsRequest\x1a\x1a.user.SyncContactsResponse\x12<\n" +
+	"\tBlockUser\x12\x16.user.BlockUserRequest\x1a\x17.user.BlockUserResponse\x12B\n" +
+	"\vUnblockUser\x12\x18.user.UnblockUserRequest\x1a\x19.user.UnblockUserResponse\x12B\n" +
+	"\vListBlocked\x12\x18.user.ListBlockedRequest\x1a\x19.user.ListBlockedResponse\x12W\n" +
+	"\x12GetPrivacySettings\x12\x1f.user.GetPrivacySettingsRequest\x1a .user.GetPrivacySettingsResponse\x12`\n" +
+	"\x15UpdatePrivacySettings\x12\".user.UpdatePrivacySettingsRequest\x1a#.user.UpdatePrivacySettingsResponse\x12H\n" +
+	"\rDeleteAccount\x12\x1a.user.DeleteAccountRequest\x1a\x1b.user.DeleteAccountResponse\x12E\n" +
+	"\fExportMyData\x12\x19.user.ExportMyDataRequest\x1a\x1a.user.ExportMyDataResponseB'Z%github.com/dykethecreator/GoApp/protob\x06proto3"
+
+var (
+	file_proto_user_proto_rawDescOnce sync.Once
+	file_proto_user_proto_rawDescData []byte
+)
+
+func file_proto_user_proto_rawDescGZIP() []byte {
+	file_proto_user_proto_rawDescOnce.Do(func() {
+		file_proto_user_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_user_proto_rawDesc), len(file_proto_user_proto_rawDesc)))
+	})
+	return file_proto_user_proto_rawDescData
+}
+
+var file_proto_user_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_proto_user_proto_goTypes = []any{
+	(PrivacyVisibility)(0),                // 0: user.PrivacyVisibility
+	(ExportFormat)(0),                     // 1: user.ExportFormat
+	(*UserProfile)(nil),                   // 2: user.UserProfile
+	(*GetUserRequest)(nil),                // 3: user.GetUserRequest
+	(*GetUserResponse)(nil),               // 4: user.GetUserResponse
+	(*BatchGetUsersRequest)(nil),          // 5: user.BatchGetUsersRequest
+	(*BatchGetUsersResponse)(nil),         // 6: user.BatchGetUsersResponse
+	(*UpdateProfileRequest)(nil),          // 7: user.UpdateProfileRequest
+	(*UpdateProfileResponse)(nil),         // 8: user.UpdateProfileResponse
+	(*ContactEntry)(nil),                  // 9: user.ContactEntry
+	(*SyncContactsRequest)(nil),           // 10: user.SyncContactsRequest
+	(*SyncedContact)(nil),                 // 11: user.SyncedContact
+	(*SyncContactsResponse)(nil),          // 12: user.SyncContactsResponse
+	(*BlockUserRequest)(nil),              // 13: user.BlockUserRequest
+	(*BlockUserResponse)(nil),             // 14: user.BlockUserResponse
+	(*UnblockUserRequest)(nil),            // 15: user.UnblockUserRequest
+	(*UnblockUserResponse)(nil),           // 16: user.UnblockUserResponse
+	(*ListBlockedRequest)(nil),            // 17: user.ListBlockedRequest
+	(*BlockedUser)(nil),                   // 18: user.BlockedUser
+	(*ListBlockedResponse)(nil),           // 19: user.ListBlockedResponse
+	(*PrivacySettings)(nil),               // 20: user.PrivacySettings
+	(*GetPrivacySettingsRequest)(nil),     // 21: user.GetPrivacySettingsRequest
+	(*GetPrivacySettingsResponse)(nil),    // 22: user.GetPrivacySettingsResponse
+	(*UpdatePrivacySettingsRequest)(nil),  // 23: user.UpdatePrivacySettingsRequest
+	(*UpdatePrivacySettingsResponse)(nil), // 24: user.UpdatePrivacySettingsResponse
+	(*DeleteAccountRequest)(nil),          // 25: user.DeleteAccountRequest
+	(*DeleteAccountResponse)(nil),         // 26: user.DeleteAccountResponse
+	(*ExportMyDataRequest)(nil),           // 27: user.ExportMyDataRequest
+	(*ExportMyDataResponse)(nil),          // 28: user.ExportMyDataResponse
+}
+var file_proto_user_proto_depIdxs = []int32{
+	2,  // 0: user.GetUserResponse.user:type_name -> user.UserProfile
+	2,  // 1: user.BatchGetUsersResponse.users:type_name -> user.UserProfile
+	2,  // 2: user.UpdateProfileResponse.user:type_name -> user.UserProfile
+	9,  // 3: user.SyncContactsRequest.contacts:type_name -> user.ContactEntry
+	2,  // 4: user.SyncedContact.user:type_name -> user.UserProfile
+	11, // 5: user.SyncContactsResponse.contacts:type_name -> user.SyncedContact
+	2,  // 6: user.BlockedUser.user:type_name -> user.UserProfile
+	18, // 7: user.ListBlockedResponse.users:type_name -> user.BlockedUser
+	0,  // 8: user.PrivacySettings.last_seen:type_name -> user.PrivacyVisibility
+	0,  // 9: user.PrivacySettings.online:type_name -> user.PrivacyVisibility
+	0,  // 10: user.PrivacySettings.profile_photo:type_name -> user.PrivacyVisibility
+	0,  // 11: user.PrivacySettings.about:type_name -> user.PrivacyVisibility
+	20, // 12: user.GetPrivacySettingsResponse.settings:type_name -> user.PrivacySettings
+	0,  // 13: user.UpdatePrivacySettingsRequest.last_seen:type_name -> user.PrivacyVisibility
+	0,  // 14: user.UpdatePrivacySettingsRequest.online:type_name -> user.PrivacyVisibility
+	0,  // 15: user.UpdatePrivacySettingsRequest.profile_photo:type_name -> user.PrivacyVisibility
+	0,  // 16: user.UpdatePrivacySettingsRequest.about:type_name -> user.PrivacyVisibility
+	20, // 17: user.UpdatePrivacySettingsResponse.settings:type_name -> user.PrivacySettings
+	1,  // 18: user.ExportMyDataRequest.format:type_name -> user.ExportFormat
+	1,  // 19: user.ExportMyDataResponse.format:type_name -> user.ExportFormat
+	3,  // 20: user.UserService.GetUser:input_type -> user.GetUserRequest
+	5,  // 21: user.UserService.BatchGetUsers:input_type -> user.BatchGetUsersRequest
+	7,  // 22: user.UserService.UpdateProfile:input_type -> user.UpdateProfileRequest
+	10, // 23: user.UserService.SyncContacts:input_type -> user.SyncContactsRequest
+	13, // 24: user.UserService.BlockUser:input_type -> user.BlockUserRequest
+	15, // 25: user.UserService.UnblockUser:input_type -> user.UnblockUserRequest
+	17, // 26: user.UserService.ListBlocked:input_type -> user.ListBlockedRequest
+	21, // 27: user.UserService.GetPrivacySettings:input_type -> user.GetPrivacySettingsRequest
+	23, // 28: user.UserService.UpdatePrivacySettings:input_type -> user.UpdatePrivacySettingsRequest
+	25, // 29: user.UserService.DeleteAccount:input_type -> user.DeleteAccountRequest
+	27, // 30: user.UserService.ExportMyData:input_type -> user.ExportMyDataRequest
+	4,  // 31: user.UserService.GetUser:output_type -> user.GetUserResponse
+	6,  // 32: user.UserService.BatchGetUsers:output_type -> user.BatchGetUsersResponse
+	8,  // 33: user.UserService.UpdateProfile:output_type -> user.UpdateProfileResponse
+	12, // 34: user.UserService.SyncContacts:output_type -> user.SyncContactsResponse
+	14, // 35: user.UserService.BlockUser:output_type -> user.BlockUserResponse
+	16, // 36: user.UserService.UnblockUser:output_type -> user.UnblockUserResponse
+	19, // 37: user.UserService.ListBlocked:output_type -> user.ListBlockedResponse
+	22, // 38: user.UserService.GetPrivacySettings:output_type -> user.GetPrivacySettingsResponse
+	24, // 39: user.UserService.UpdatePrivacySettings:output_type -> user.UpdatePrivacySettingsResponse
+	26, // 40: user.UserService.DeleteAccount:output_type -> user.DeleteAccountResponse
+	28, // 41: user.UserService.ExportMyData:output_type -> user.ExportMyDataResponse
+	31, // [31:42] is the sub-list for method output_type
+	20, // [20:31] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_proto_user_proto_init() }
+func file_proto_user_proto_init() {
+	if File_proto_user_proto != nil {
+		return
+	}
+	file_proto_user_proto_msgTypes[5].OneofWrappers = []any{}
+	file_proto_user_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_proto_rawDesc), len(file_proto_user_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_user_proto_goTypes,
+		DependencyIndexes: file_proto_user_proto_depIdxs,
+		EnumInfos:         file_proto_user_proto_enumTypes,
+		MessageInfos:      file_proto_user_proto_msgTypes,
+	}.Build()
+	File_proto_user_proto = out.File
+	file_proto_user_proto_goTypes = nil
+	file_proto_user_proto_depIdxs = nil
+}